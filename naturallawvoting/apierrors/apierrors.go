@@ -0,0 +1,44 @@
+package apierrors
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes let clients tell failures apart without matching on message text,
+// which is meant for people and may be reworded.
+const (
+	ErrCodeBadRequest           = "BAD_REQUEST"
+	ErrCodeValidation           = "VALIDATION_FAILED"
+	ErrCodeUnauthorized         = "UNAUTHORIZED"
+	ErrCodeForbidden            = "FORBIDDEN"
+	ErrCodeNotFound             = "NOT_FOUND"
+	ErrCodeNotAcceptable        = "NOT_ACCEPTABLE"
+	ErrCodeConflict             = "CONFLICT"
+	ErrCodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeRateLimited          = "RATE_LIMITED"
+	ErrCodeInternal             = "INTERNAL_ERROR"
+)
+
+// APIError is the body of every error response. The message keeps the "error"
+// key that responses have always used, so existing clients are unaffected.
+type APIError struct {
+	Code    string      `json:"code"`
+	Message string      `json:"error"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// RespondError writes an error response with the given status and code.
+func RespondError(c *gin.Context, status int, code string, message string) {
+	c.JSON(status, APIError{Code: code, Message: message})
+}
+
+// RespondErrorWithDetails writes an error response carrying extra machine-readable
+// context, such as the limit a request exceeded.
+func RespondErrorWithDetails(c *gin.Context, status int, code string, message string, details interface{}) {
+	c.JSON(status, APIError{Code: code, Message: message, Details: details})
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is not cached.
+var ErrMiss = errors.New("cache miss")
+
+// Cacher stores serialized values for a limited time.
+type Cacher interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte, ttl time.Duration) error
+	Delete(key string) error
+}
+
+// NoOpCache is used when no cache backend is configured. Every lookup misses.
+type NoOpCache struct{}
+
+func (NoOpCache) Get(key string) ([]byte, error) {
+	return nil, ErrMiss
+}
+
+func (NoOpCache) Set(key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+func (NoOpCache) Delete(key string) error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process Cacher holding at most capacity entries. Once full, the
+// least recently used entry is dropped to make room. Entries expire after their
+// TTL like in Redis, but the cache is local to one API instance.
+type LRU struct {
+	mu         sync.Mutex
+	capacity   int
+	defaultTTL time.Duration
+	order      *list.List // front is most recently used
+	entries    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU creates an LRU cache. ttl applies when Set is given a TTL of zero.
+func NewLRU(capacity int, ttl time.Duration) *LRU {
+	return &LRU{
+		capacity:   capacity,
+		defaultTTL: ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (l *LRU) Get(key string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		l.remove(element)
+		return nil, ErrMiss
+	}
+
+	l.order.MoveToFront(element)
+	return entry.value, nil
+}
+
+func (l *LRU) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = l.defaultTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.order.MoveToFront(element)
+		return nil
+	}
+
+	if l.capacity <= 0 {
+		return nil
+	}
+	for l.order.Len() >= l.capacity {
+		l.remove(l.order.Back())
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	return nil
+}
+
+func (l *LRU) Delete(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.remove(element)
+	}
+	return nil
+}
+
+// Len returns the number of entries held, including any that have expired but
+// not yet been looked up.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*lruEntry).key)
+}
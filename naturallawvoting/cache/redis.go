@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisTimeout = 2 * time.Second
+
+// RedisCache is a Cacher backed by Redis.
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache connects to the Redis server at url (redis://host:port/db) and
+// verifies the connection.
+func NewRedisCache(url string) (*RedisCache, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing redis url: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error connecting to redis: %w", err)
+	}
+
+	return &RedisCache{client: client}, nil
+}
+
+func (r *RedisCache) Get(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+func (r *RedisCache) Set(key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *RedisCache) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	return r.client.Del(ctx, key).Err()
+}
+
+// Close releases the underlying connection pool.
+func (r *RedisCache) Close() error {
+	return r.client.Close()
+}
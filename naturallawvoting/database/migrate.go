@@ -0,0 +1,147 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Migrations live in migrations/ as {version}_{name}.up.sql and .down.sql pairs,
+// the layout and schema_migrations table used by golang-migrate, so its CLI can
+// inspect or roll back a database migrated by RunMigrations.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID keys the advisory lock that stops two API instances starting
+// at once from applying the same migration twice.
+const migrationLockID = 726354901
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type migration struct {
+	version int64
+	name    string // file name without the .up.sql suffix
+	up      string
+}
+
+// loadMigrations reads the up migrations from fsys in version order. Every up
+// file must have a matching down file.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	ups := make(map[int64]migration)
+	downs := make(map[int64]bool)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected file in migrations: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+
+		if match[3] == "down" {
+			downs[version] = true
+			continue
+		}
+
+		if _, exists := ups[version]; exists {
+			return nil, fmt.Errorf("duplicate migration version %d", version)
+		}
+		contents, err := fs.ReadFile(fsys, "migrations/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", entry.Name(), err)
+		}
+		ups[version] = migration{version: version, name: match[1] + "_" + match[2], up: string(contents)}
+	}
+
+	migrations := make([]migration, 0, len(ups))
+	for version, m := range ups {
+		if !downs[version] {
+			return nil, fmt.Errorf("migration %s has no down file", m.name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// RunMigrations applies every embedded migration newer than the version recorded
+// in schema_migrations. All pending migrations run in one transaction, so a
+// failure leaves the schema as it was; with nothing pending it changes nothing.
+//
+// TODO: replace this runner with golang-migrate (iofs source over migrationFiles
+// and the postgres database driver) once github.com/golang-migrate/migrate/v4 is
+// added to go.mod. It keeps golang-migrate's file layout and schema_migrations
+// table, so the switch needs no change to existing databases or migration files,
+// and until then rollbacks go through the golang-migrate CLI.
+func (db *DB) RunMigrations() error {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	if err != nil {
+		return fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error running migrations: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("error locking migrations: %w", err)
+	}
+
+	var current int64
+	var dirty bool
+	err = tx.QueryRow("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("error reading migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("database is at dirty migration version %d; repair it and clear the dirty flag", current)
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if _, err := tx.Exec(m.up); err != nil {
+			return fmt.Errorf("error running migration %s: %w", m.name, err)
+		}
+		current = m.version
+		applied++
+	}
+
+	if applied > 0 {
+		if _, err := tx.Exec("DELETE FROM schema_migrations"); err != nil {
+			return fmt.Errorf("error recording migration version: %w", err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", current); err != nil {
+			return fmt.Errorf("error recording migration version: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error running migrations: %w", err)
+	}
+
+	log.Printf("Database migrations completed successfully (%d applied, at version %d)", applied, current)
+	return nil
+}
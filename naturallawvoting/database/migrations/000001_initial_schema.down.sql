@@ -0,0 +1,27 @@
+DROP VIEW IF EXISTS ballot_item_vote_counts;
+
+DROP TABLE IF EXISTS economic_info;
+DROP TABLE IF EXISTS user_race_ethnicity;
+DROP TABLE IF EXISTS user_religious_affiliations;
+DROP TABLE IF EXISTS user_political_affiliations;
+DROP TABLE IF EXISTS user_addresses;
+DROP TABLE IF EXISTS user_profiles;
+DROP TABLE IF EXISTS multi_votes;
+DROP TABLE IF EXISTS score_votes;
+DROP TABLE IF EXISTS ballot_sponsors;
+DROP TABLE IF EXISTS ballot_co_creators;
+DROP TABLE IF EXISTS ballot_changelog;
+DROP TABLE IF EXISTS ranked_votes;
+DROP TABLE IF EXISTS admin_audit_log;
+DROP TABLE IF EXISTS impersonation_audit;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS user_notifications;
+DROP TABLE IF EXISTS state_populations;
+DROP TABLE IF EXISTS ballot_result_snapshots;
+DROP TABLE IF EXISTS ballot_announcements;
+DROP TABLE IF EXISTS votes;
+DROP TABLE IF EXISTS ballot_items;
+DROP TABLE IF EXISTS ballots;
+DROP TABLE IF EXISTS users;
+
+DROP FUNCTION IF EXISTS update_updated_at_column();
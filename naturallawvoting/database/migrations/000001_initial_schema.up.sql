@@ -0,0 +1,401 @@
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) UNIQUE NOT NULL,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'user',
+    deleted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create ballots table
+CREATE TABLE IF NOT EXISTS ballots (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    category VARCHAR(100),
+    superstate VARCHAR(100),
+    state VARCHAR(100),
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    is_active BOOLEAN DEFAULT true,
+    ballot_type VARCHAR(20) NOT NULL DEFAULT 'plurality',
+    allow_vote_retraction BOOLEAN DEFAULT true,
+    language VARCHAR(10) DEFAULT 'en',
+    locked BOOLEAN DEFAULT false,
+    activate_at TIMESTAMP,
+    deactivate_at TIMESTAMP,
+    closes_at TIMESTAMP,
+    minimum_quorum INTEGER CHECK (minimum_quorum >= 1),
+    deleted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add columns introduced after the initial schema if they don't exist (for existing databases)
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'users' AND column_name = 'role') THEN
+        ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'users' AND column_name = 'deleted_at') THEN
+        ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'superstate') THEN
+        ALTER TABLE ballots ADD COLUMN superstate VARCHAR(100);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'state') THEN
+        ALTER TABLE ballots ADD COLUMN state VARCHAR(100);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'activate_at') THEN
+        ALTER TABLE ballots ADD COLUMN activate_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'deactivate_at') THEN
+        ALTER TABLE ballots ADD COLUMN deactivate_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'closes_at') THEN
+        ALTER TABLE ballots ADD COLUMN closes_at TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'minimum_quorum') THEN
+        ALTER TABLE ballots ADD COLUMN minimum_quorum INTEGER CHECK (minimum_quorum >= 1);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'ballot_type') THEN
+        ALTER TABLE ballots ADD COLUMN ballot_type VARCHAR(20) NOT NULL DEFAULT 'plurality';
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'allow_vote_retraction') THEN
+        ALTER TABLE ballots ADD COLUMN allow_vote_retraction BOOLEAN DEFAULT true;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'language') THEN
+        ALTER TABLE ballots ADD COLUMN language VARCHAR(10) DEFAULT 'en';
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'locked') THEN
+        ALTER TABLE ballots ADD COLUMN locked BOOLEAN DEFAULT false;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballots' AND column_name = 'deleted_at') THEN
+        ALTER TABLE ballots ADD COLUMN deleted_at TIMESTAMP;
+    END IF;
+END $$;
+
+-- Create ballot_items table
+CREATE TABLE IF NOT EXISTS ballot_items (
+    id SERIAL PRIMARY KEY,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    vote_count INTEGER DEFAULT 0,
+    is_write_in BOOLEAN DEFAULT false,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballot_items' AND column_name = 'updated_at') THEN
+        ALTER TABLE ballot_items ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'ballot_items' AND column_name = 'is_write_in') THEN
+        ALTER TABLE ballot_items ADD COLUMN is_write_in BOOLEAN DEFAULT false;
+    END IF;
+END $$;
+
+-- Create votes table
+CREATE TABLE IF NOT EXISTS votes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    ballot_item_id INTEGER NOT NULL REFERENCES ballot_items(id) ON DELETE CASCADE,
+    previous_ballot_item_id INTEGER REFERENCES ballot_items(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, ballot_id)
+);
+
+-- Votes from deleted accounts are kept but detached from the user
+ALTER TABLE votes ALTER COLUMN user_id DROP NOT NULL;
+
+-- The item a changed vote was moved away from, for vote exports
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'votes' AND column_name = 'previous_ballot_item_id') THEN
+        ALTER TABLE votes ADD COLUMN previous_ballot_item_id INTEGER REFERENCES ballot_items(id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+-- Create ballot_announcements table
+CREATE TABLE IF NOT EXISTS ballot_announcements (
+    id SERIAL PRIMARY KEY,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    creator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create ballot_result_snapshots table (periodic copies of each active ballot's standings)
+CREATE TABLE IF NOT EXISTS ballot_result_snapshots (
+    id SERIAL PRIMARY KEY,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    results JSONB NOT NULL,
+    snapshotted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create state_populations table (census populations by ballot state slug, seeded separately)
+CREATE TABLE IF NOT EXISTS state_populations (
+    state_code VARCHAR(100) PRIMARY KEY,
+    population BIGINT NOT NULL CHECK (population > 0),
+    year SMALLINT NOT NULL
+);
+
+-- Create user_notifications table
+CREATE TABLE IF NOT EXISTS user_notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ballot_id INTEGER REFERENCES ballots(id) ON DELETE CASCADE,
+    message VARCHAR(500) NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create refresh_tokens table (only a SHA-256 hash of each token is stored)
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT false
+);
+
+-- Create impersonation_audit table
+CREATE TABLE IF NOT EXISTS impersonation_audit (
+    id SERIAL PRIMARY KEY,
+    admin_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP
+);
+
+-- Create admin_audit_log table (one row per admin action)
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    admin_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50),
+    target_id INTEGER,
+    payload JSONB,
+    ip_address INET,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create ranked_votes table (one row per ranked item; rank 1 is the first preference)
+CREATE TABLE IF NOT EXISTS ranked_votes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    ballot_item_id INTEGER NOT NULL REFERENCES ballot_items(id) ON DELETE CASCADE,
+    rank INTEGER NOT NULL CHECK (rank >= 1),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, ballot_id, ballot_item_id),
+    UNIQUE(user_id, ballot_id, rank)
+);
+
+-- Create ballot_changelog table (one row per edited ballot field)
+CREATE TABLE IF NOT EXISTS ballot_changelog (
+    id SERIAL PRIMARY KEY,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    field VARCHAR(50) NOT NULL,
+    old_value TEXT,
+    new_value TEXT,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create ballot_co_creators table (users who may manage a ballot alongside its creator)
+CREATE TABLE IF NOT EXISTS ballot_co_creators (
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    added_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ballot_id, user_id)
+);
+
+-- Create ballot_sponsors table (organizations officially endorsing a ballot)
+CREATE TABLE IF NOT EXISTS ballot_sponsors (
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    organization_name VARCHAR(200) NOT NULL,
+    sponsor_url VARCHAR(2048),
+    sponsored_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ballot_id, organization_name)
+);
+
+-- Create score_votes table (one 0-10 score per item per voter on score ballots)
+CREATE TABLE IF NOT EXISTS score_votes (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    ballot_item_id INTEGER NOT NULL REFERENCES ballot_items(id) ON DELETE CASCADE,
+    score SMALLINT NOT NULL CHECK (score >= 0 AND score <= 10),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, ballot_id, ballot_item_id)
+);
+
+-- Create multi_votes table (one row per selected item on ballots that allow several selections)
+CREATE TABLE IF NOT EXISTS multi_votes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ballot_id INTEGER NOT NULL REFERENCES ballots(id) ON DELETE CASCADE,
+    ballot_item_id INTEGER NOT NULL REFERENCES ballot_items(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(user_id, ballot_id, ballot_item_id)
+);
+
+-- Create user_profiles table
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) PRIMARY KEY REFERENCES users(email) ON DELETE CASCADE,
+    full_name VARCHAR(255),
+    birthday DATE,
+    gender VARCHAR(50),
+    mothers_maiden_name VARCHAR(100),
+    phone_number VARCHAR(20),
+    additional_emails TEXT[],
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_addresses table
+CREATE TABLE IF NOT EXISTS user_addresses (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    street_number VARCHAR(20),
+    street_name VARCHAR(255),
+    address_line_2 VARCHAR(255),
+    city VARCHAR(100),
+    state VARCHAR(50),
+    zip_code VARCHAR(20),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_political_affiliations table
+CREATE TABLE IF NOT EXISTS user_political_affiliations (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    party_affiliation VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_religious_affiliations table
+CREATE TABLE IF NOT EXISTS user_religious_affiliations (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    religion VARCHAR(100),
+    supporting_religion INTEGER CHECK (supporting_religion >= 0 AND supporting_religion <= 10),
+    religious_services_types TEXT[],
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create user_race_ethnicity table
+CREATE TABLE IF NOT EXISTS user_race_ethnicity (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    race TEXT[],
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create economic_info table
+CREATE TABLE IF NOT EXISTS economic_info (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    for_current_political_structure VARCHAR(255),
+    for_capitalism VARCHAR(255),
+    for_laws VARCHAR(255),
+    goods_services TEXT[],
+    affiliations TEXT[],
+    support_of_alt_econ VARCHAR(255),
+    support_alt_comm VARCHAR(255),
+    additional_text VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_ballots_creator_id ON ballots(creator_id);
+CREATE INDEX IF NOT EXISTS idx_ballots_superstate ON ballots(superstate);
+CREATE INDEX IF NOT EXISTS idx_ballots_state ON ballots(state);
+CREATE INDEX IF NOT EXISTS idx_ballots_category ON ballots(category);
+-- The category summary only counts active ballots, so a partial index lets it
+-- group from the index instead of scanning the whole table.
+CREATE INDEX IF NOT EXISTS idx_ballots_active_category ON ballots(category) WHERE is_active = true;
+CREATE INDEX IF NOT EXISTS idx_ballots_search ON ballots USING GIN (to_tsvector('english', title || ' ' || COALESCE(description, '')));
+-- The ballot listing computes total_votes and item_count with a correlated subquery
+-- per ballot; idx_ballot_items_ballot_id keeps those lookups to an index scan.
+CREATE INDEX IF NOT EXISTS idx_ballot_items_ballot_id ON ballot_items(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_votes_user_id ON votes(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_votes_ballot_id ON votes(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_votes_ballot_item_id ON votes(ballot_item_id);
+CREATE INDEX IF NOT EXISTS idx_ranked_votes_ballot_id ON ranked_votes(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_multi_votes_ballot_id ON multi_votes(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_multi_votes_ballot_item_id ON multi_votes(ballot_item_id);
+CREATE INDEX IF NOT EXISTS idx_score_votes_ballot_id ON score_votes(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_ballot_announcements_ballot_id ON ballot_announcements(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_ballot_result_snapshots_ballot_id ON ballot_result_snapshots(ballot_id, snapshotted_at);
+CREATE INDEX IF NOT EXISTS idx_ballot_changelog_ballot_id ON ballot_changelog(ballot_id);
+CREATE INDEX IF NOT EXISTS idx_user_notifications_user_id ON user_notifications(user_id);
+CREATE INDEX IF NOT EXISTS idx_impersonation_audit_admin_id ON impersonation_audit(admin_id);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_log_created_at ON admin_audit_log(created_at);
+
+-- Vote counts derived from the recorded votes. Single-choice votes live in votes
+-- and multi-select/approval selections in multi_votes; both used to bump
+-- ballot_items.vote_count, which can drift, so reads go through this view.
+CREATE OR REPLACE VIEW ballot_item_vote_counts AS
+SELECT ballot_item_id, COUNT(*) AS vote_count
+FROM (
+    SELECT ballot_item_id FROM votes
+    UNION ALL
+    SELECT ballot_item_id FROM multi_votes
+) recorded_votes
+GROUP BY ballot_item_id;
+
+COMMENT ON COLUMN ballot_items.vote_count IS 'Deprecated: kept for backwards compatibility, read ballot_item_vote_counts instead';
+
+-- Function to update updated_at timestamp
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Triggers to automatically update updated_at
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_ballots_updated_at ON ballots;
+CREATE TRIGGER update_ballots_updated_at BEFORE UPDATE ON ballots
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_ballot_items_updated_at ON ballot_items;
+CREATE TRIGGER update_ballot_items_updated_at BEFORE UPDATE ON ballot_items
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_user_profiles_updated_at ON user_profiles;
+CREATE TRIGGER update_user_profiles_updated_at BEFORE UPDATE ON user_profiles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_user_addresses_updated_at ON user_addresses;
+CREATE TRIGGER update_user_addresses_updated_at BEFORE UPDATE ON user_addresses
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_user_political_affiliations_updated_at ON user_political_affiliations;
+CREATE TRIGGER update_user_political_affiliations_updated_at BEFORE UPDATE ON user_political_affiliations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_user_religious_affiliations_updated_at ON user_religious_affiliations;
+CREATE TRIGGER update_user_religious_affiliations_updated_at BEFORE UPDATE ON user_religious_affiliations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_user_race_ethnicity_updated_at ON user_race_ethnicity;
+CREATE TRIGGER update_user_race_ethnicity_updated_at BEFORE UPDATE ON user_race_ethnicity
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_economic_info_updated_at ON economic_info;
+CREATE TRIGGER update_economic_info_updated_at BEFORE UPDATE ON economic_info
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1 @@
+ALTER TABLE ballot_items DROP CONSTRAINT IF EXISTS ballot_items_ballot_id_title_key;
@@ -0,0 +1,18 @@
+-- Items with the same title on one ballot can't be told apart in the results.
+-- Existing duplicates keep the first occurrence as-is; later ones are suffixed
+-- with their item id so the constraint can be added. Ids are unique, so unlike a
+-- running number the new title can't collide with one already on the ballot.
+UPDATE ballot_items bi
+SET title = LEFT(bi.title, 200 - LENGTH(' (#' || bi.id || ')')) || ' (#' || bi.id || ')'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY ballot_id, title ORDER BY id) AS occurrence
+    FROM ballot_items
+) d
+WHERE d.id = bi.id AND d.occurrence > 1;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'ballot_items_ballot_id_title_key') THEN
+        ALTER TABLE ballot_items ADD CONSTRAINT ballot_items_ballot_id_title_key UNIQUE (ballot_id, title);
+    END IF;
+END $$;
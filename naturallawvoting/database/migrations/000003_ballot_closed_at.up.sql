@@ -0,0 +1,3 @@
+-- When the creator closed the ballot by hand; NULL for ballots that are open or
+-- were closed by their schedule.
+ALTER TABLE ballots ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
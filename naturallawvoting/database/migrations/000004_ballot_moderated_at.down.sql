@@ -0,0 +1 @@
+ALTER TABLE ballots DROP COLUMN IF EXISTS moderated_at;
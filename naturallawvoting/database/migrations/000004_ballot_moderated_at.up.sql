@@ -0,0 +1,3 @@
+-- When an admin took the ballot out of circulation; NULL unless moderated. A
+-- moderated ballot cannot be reactivated by its creator or by the scheduler.
+ALTER TABLE ballots ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMP;
@@ -0,0 +1,298 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+)
+
+// expectedSchema mirrors the tables created by RunMigrations. Column types use the
+// data_type names reported by information_schema.columns. Keep it in sync with
+// every migration change.
+var expectedSchema = map[string]map[string]string{
+	"users": {
+		"id":            "integer",
+		"username":      "character varying",
+		"email":         "character varying",
+		"password_hash": "character varying",
+		"role":          "character varying",
+		"deleted_at":    "timestamp without time zone",
+		"created_at":    "timestamp without time zone",
+		"updated_at":    "timestamp without time zone",
+	},
+	"ballots": {
+		"id":                    "integer",
+		"title":                 "character varying",
+		"description":           "text",
+		"category":              "character varying",
+		"superstate":            "character varying",
+		"state":                 "character varying",
+		"creator_id":            "integer",
+		"is_active":             "boolean",
+		"ballot_type":           "character varying",
+		"allow_vote_retraction": "boolean",
+		"language":              "character varying",
+		"locked":                "boolean",
+		"activate_at":           "timestamp without time zone",
+		"deactivate_at":         "timestamp without time zone",
+		"closes_at":             "timestamp without time zone",
+		"closed_at":             "timestamp without time zone",
+		"moderated_at":          "timestamp without time zone",
+		"minimum_quorum":        "integer",
+		"deleted_at":            "timestamp without time zone",
+		"created_at":            "timestamp without time zone",
+		"updated_at":            "timestamp without time zone",
+	},
+	"ballot_items": {
+		"id":          "integer",
+		"ballot_id":   "integer",
+		"title":       "character varying",
+		"description": "text",
+		"vote_count":  "integer",
+		"is_write_in": "boolean",
+		"updated_at":  "timestamp without time zone",
+	},
+	"votes": {
+		"id":                      "integer",
+		"user_id":                 "integer",
+		"ballot_id":               "integer",
+		"ballot_item_id":          "integer",
+		"previous_ballot_item_id": "integer",
+		"created_at":              "timestamp without time zone",
+	},
+	"ranked_votes": {
+		"id":             "integer",
+		"user_id":        "integer",
+		"ballot_id":      "integer",
+		"ballot_item_id": "integer",
+		"rank":           "integer",
+		"created_at":     "timestamp without time zone",
+	},
+	"ballot_changelog": {
+		"id":         "integer",
+		"ballot_id":  "integer",
+		"changed_by": "integer",
+		"field":      "character varying",
+		"old_value":  "text",
+		"new_value":  "text",
+		"changed_at": "timestamp without time zone",
+	},
+	"ballot_co_creators": {
+		"ballot_id": "integer",
+		"user_id":   "integer",
+		"added_by":  "integer",
+		"added_at":  "timestamp without time zone",
+	},
+	"ballot_sponsors": {
+		"ballot_id":         "integer",
+		"organization_name": "character varying",
+		"sponsor_url":       "character varying",
+		"sponsored_at":      "timestamp without time zone",
+	},
+	"score_votes": {
+		"user_id":        "integer",
+		"ballot_id":      "integer",
+		"ballot_item_id": "integer",
+		"score":          "smallint",
+		"created_at":     "timestamp without time zone",
+	},
+	"multi_votes": {
+		"id":             "integer",
+		"user_id":        "integer",
+		"ballot_id":      "integer",
+		"ballot_item_id": "integer",
+		"created_at":     "timestamp without time zone",
+	},
+	"ballot_announcements": {
+		"id":         "integer",
+		"ballot_id":  "integer",
+		"creator_id": "integer",
+		"message":    "character varying",
+		"created_at": "timestamp without time zone",
+	},
+	"ballot_result_snapshots": {
+		"id":             "integer",
+		"ballot_id":      "integer",
+		"results":        "jsonb",
+		"snapshotted_at": "timestamp without time zone",
+	},
+	"state_populations": {
+		"state_code": "character varying",
+		"population": "bigint",
+		"year":       "smallint",
+	},
+	"user_notifications": {
+		"id":         "integer",
+		"user_id":    "integer",
+		"ballot_id":  "integer",
+		"message":    "character varying",
+		"read_at":    "timestamp without time zone",
+		"created_at": "timestamp without time zone",
+	},
+	"refresh_tokens": {
+		"id":         "integer",
+		"user_id":    "integer",
+		"token_hash": "character varying",
+		"expires_at": "timestamp without time zone",
+		"revoked":    "boolean",
+	},
+	"impersonation_audit": {
+		"id":             "integer",
+		"admin_id":       "integer",
+		"target_user_id": "integer",
+		"started_at":     "timestamp without time zone",
+		"ended_at":       "timestamp without time zone",
+	},
+	"admin_audit_log": {
+		"id":            "bigint",
+		"admin_user_id": "integer",
+		"action":        "character varying",
+		"target_type":   "character varying",
+		"target_id":     "integer",
+		"payload":       "jsonb",
+		"ip_address":    "inet",
+		"created_at":    "timestamp without time zone",
+	},
+	"user_profiles": {
+		"user_id":             "integer",
+		"email":               "character varying",
+		"full_name":           "character varying",
+		"birthday":            "date",
+		"gender":              "character varying",
+		"mothers_maiden_name": "character varying",
+		"phone_number":        "character varying",
+		"additional_emails":   "ARRAY",
+		"created_at":          "timestamp without time zone",
+		"updated_at":          "timestamp without time zone",
+	},
+	"user_addresses": {
+		"user_id":        "integer",
+		"street_number":  "character varying",
+		"street_name":    "character varying",
+		"address_line_2": "character varying",
+		"city":           "character varying",
+		"state":          "character varying",
+		"zip_code":       "character varying",
+		"created_at":     "timestamp without time zone",
+		"updated_at":     "timestamp without time zone",
+	},
+	"user_political_affiliations": {
+		"user_id":           "integer",
+		"party_affiliation": "character varying",
+		"created_at":        "timestamp without time zone",
+		"updated_at":        "timestamp without time zone",
+	},
+	"user_religious_affiliations": {
+		"user_id":                  "integer",
+		"religion":                 "character varying",
+		"supporting_religion":      "integer",
+		"religious_services_types": "ARRAY",
+		"created_at":               "timestamp without time zone",
+		"updated_at":               "timestamp without time zone",
+	},
+	"user_race_ethnicity": {
+		"user_id":    "integer",
+		"race":       "ARRAY",
+		"created_at": "timestamp without time zone",
+		"updated_at": "timestamp without time zone",
+	},
+	"economic_info": {
+		"user_id":                         "integer",
+		"for_current_political_structure": "character varying",
+		"for_capitalism":                  "character varying",
+		"for_laws":                        "character varying",
+		"goods_services":                  "ARRAY",
+		"affiliations":                    "ARRAY",
+		"support_of_alt_econ":             "character varying",
+		"support_alt_comm":                "character varying",
+		"additional_text":                 "character varying",
+		"created_at":                      "timestamp without time zone",
+		"updated_at":                      "timestamp without time zone",
+	},
+}
+
+// ExpectedSchema returns a copy of the table/column/type map the validator checks against.
+func ExpectedSchema() map[string]map[string]string {
+	schema := make(map[string]map[string]string, len(expectedSchema))
+	for table, columns := range expectedSchema {
+		schema[table] = make(map[string]string, len(columns))
+		for column, dataType := range columns {
+			schema[table][column] = dataType
+		}
+	}
+	return schema
+}
+
+// SchemaDiscrepancy describes a single difference between the live database and
+// expectedSchema. Column is empty when the whole table is missing.
+type SchemaDiscrepancy struct {
+	Table    string `json:"table"`
+	Column   string `json:"column,omitempty"`
+	Issue    string `json:"issue"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+// ValidateSchema compares the public schema reported by information_schema against
+// expectedSchema. Extra tables and columns are ignored; only missing objects and
+// type mismatches are reported, sorted by table and column.
+func (db *DB) ValidateSchema() ([]SchemaDiscrepancy, error) {
+	rows, err := db.Query("SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = 'public'")
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema: %w", err)
+	}
+	defer rows.Close()
+
+	actual := make(map[string]map[string]string)
+	for rows.Next() {
+		var table, column, dataType string
+		if err := rows.Scan(&table, &column, &dataType); err != nil {
+			return nil, fmt.Errorf("error reading schema: %w", err)
+		}
+		if actual[table] == nil {
+			actual[table] = make(map[string]string)
+		}
+		actual[table][column] = dataType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading schema: %w", err)
+	}
+
+	tables := make([]string, 0, len(expectedSchema))
+	for table := range expectedSchema {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	discrepancies := []SchemaDiscrepancy{}
+	for _, table := range tables {
+		actualColumns, ok := actual[table]
+		if !ok {
+			discrepancies = append(discrepancies, SchemaDiscrepancy{Table: table, Issue: "missing"})
+			continue
+		}
+
+		columns := make([]string, 0, len(expectedSchema[table]))
+		for column := range expectedSchema[table] {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		for _, column := range columns {
+			expectedType := expectedSchema[table][column]
+			actualType, ok := actualColumns[column]
+			if !ok {
+				discrepancies = append(discrepancies, SchemaDiscrepancy{Table: table, Column: column, Issue: "missing"})
+			} else if actualType != expectedType {
+				discrepancies = append(discrepancies, SchemaDiscrepancy{
+					Table:    table,
+					Column:   column,
+					Issue:    "type_mismatch",
+					Expected: expectedType,
+					Actual:   actualType,
+				})
+			}
+		}
+	}
+
+	return discrepancies, nil
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VoteCountDiscrepancy is a ballot item whose stored vote_count no longer matches
+// the votes recorded for it.
+type VoteCountDiscrepancy struct {
+	BallotItemID int `json:"ballot_item_id"`
+	Stored       int `json:"stored"`
+	Actual       int `json:"actual"`
+}
+
+// repairVoteCountsSQL resets every drifted vote_count in one statement. Actual
+// counts come from ballot_item_vote_counts, so items picked on multi-select
+// ballots keep their multi_votes.
+const repairVoteCountsSQL = `
+	UPDATE ballot_items bi
+	SET vote_count = COALESCE((SELECT vc.vote_count FROM ballot_item_vote_counts vc WHERE vc.ballot_item_id = bi.id), 0)
+	WHERE bi.vote_count <> COALESCE((SELECT vc.vote_count FROM ballot_item_vote_counts vc WHERE vc.ballot_item_id = bi.id), 0)`
+
+// CompareVoteCounts lists the items whose stored count differs from the actual
+// count, sorted by item ID. Items missing from actual have no votes.
+func CompareVoteCounts(stored, actual map[int]int) []VoteCountDiscrepancy {
+	discrepancies := []VoteCountDiscrepancy{}
+	for itemID, count := range stored {
+		if actual[itemID] != count {
+			discrepancies = append(discrepancies, VoteCountDiscrepancy{BallotItemID: itemID, Stored: count, Actual: actual[itemID]})
+		}
+	}
+	sort.Slice(discrepancies, func(i, j int) bool { return discrepancies[i].BallotItemID < discrepancies[j].BallotItemID })
+	return discrepancies
+}
+
+// VoteCountDiscrepancies reads the stored and actual counts and compares them.
+func (db *DB) VoteCountDiscrepancies() ([]VoteCountDiscrepancy, error) {
+	stored, err := db.countsByItem("SELECT id, vote_count FROM ballot_items")
+	if err != nil {
+		return nil, fmt.Errorf("error reading stored vote counts: %w", err)
+	}
+
+	actual, err := db.countsByItem("SELECT ballot_item_id, vote_count FROM ballot_item_vote_counts")
+	if err != nil {
+		return nil, fmt.Errorf("error reading recorded votes: %w", err)
+	}
+
+	return CompareVoteCounts(stored, actual), nil
+}
+
+// RepairVoteCounts recalculates drifted vote counts and returns how many items
+// were corrected.
+func (db *DB) RepairVoteCounts() (int64, error) {
+	result, err := db.Exec(repairVoteCountsSQL)
+	if err != nil {
+		return 0, fmt.Errorf("error repairing vote counts: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (db *DB) countsByItem(query string) (map[int]int, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var itemID, count int
+		if err := rows.Scan(&itemID, &count); err != nil {
+			return nil, err
+		}
+		counts[itemID] = count
+	}
+	return counts, rows.Err()
+}
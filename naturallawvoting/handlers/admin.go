@@ -0,0 +1,921 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"voting-api/apierrors"
+	"voting-api/cache"
+	"voting-api/database"
+	"voting-api/models"
+	"voting-api/services"
+	"voting-api/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	impersonationTTL      = 30 * time.Minute
+	defaultTopVotersLimit = 10
+	maxTopVotersLimit     = 100
+	defaultAuditLogLimit  = 50
+	maxAuditLogLimit      = 200
+	defaultUserListLimit  = 50
+	maxUserListLimit      = 200
+	// maxVoteExportRows caps a single vote export; larger exports must be narrowed
+	maxVoteExportRows = 1000000
+)
+
+type AdminHandler struct {
+	db    *database.DB
+	cache cache.Cacher
+	audit *services.AuditLogger
+}
+
+func NewAdminHandler(db *database.DB, ballotCache cache.Cacher, audit *services.AuditLogger) *AdminHandler {
+	return &AdminHandler{db: db, cache: ballotCache, audit: audit}
+}
+
+// recordAudit writes an admin action to the audit log. A failed write is logged
+// rather than failing the request, since the action has already been carried out.
+func (h *AdminHandler) recordAudit(c *gin.Context, action, targetType string, targetID int, payload interface{}) {
+	if err := h.audit.Log(c, action, targetType, targetID, payload); err != nil {
+		log.Printf("Error recording %s in audit log: %v", action, err)
+	}
+}
+
+// ValidateSchema reports drift between the live database and the schema the
+// application expects. Drift is reported with a 200 so operators can read the list.
+func (h *AdminHandler) ValidateSchema(c *gin.Context) {
+	discrepancies, err := h.db.ValidateSchema()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	h.recordAudit(c, "schema.validate", "schema", 0, gin.H{"discrepancies": len(discrepancies)})
+
+	c.JSON(http.StatusOK, gin.H{
+		"valid":         len(discrepancies) == 0,
+		"discrepancies": discrepancies,
+	})
+}
+
+// Impersonate issues a short-lived token for another user so an admin can see the
+// platform as they do. Every impersonation is recorded in impersonation_audit.
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot impersonate while impersonating")
+		return
+	}
+
+	var req models.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if req.UserID == adminID.(int) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Cannot impersonate yourself")
+		return
+	}
+
+	var email string
+	err := h.db.QueryRow("SELECT email FROM users WHERE id = $1", req.UserID).Scan(&email)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	startedAt := time.Now().UTC()
+	expiresAt := startedAt.Add(impersonationTTL)
+
+	_, err = h.db.Exec(
+		"INSERT INTO impersonation_audit (admin_id, target_user_id, started_at, ended_at) VALUES ($1, $2, $3, $4)",
+		adminID, req.UserID, startedAt, expiresAt,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error recording impersonation")
+		return
+	}
+
+	token, err := utils.GenerateImpersonationJWT(req.UserID, email, adminID.(int), impersonationTTL)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
+		return
+	}
+
+	h.recordAudit(c, "user.impersonate", "user", req.UserID, gin.H{"expires_at": expiresAt})
+
+	c.JSON(http.StatusOK, models.ImpersonateResponse{
+		Token:     token,
+		UserID:    req.UserID,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// impersonationLogSQL lists impersonation sessions newest first. The token expiry
+// is derived from started_at because that is when the impersonation JWT was issued.
+var impersonationLogSQL = fmt.Sprintf(`
+	SELECT ia.admin_id, a.username, ia.target_user_id, t.username, ia.started_at,
+		ia.started_at + INTERVAL '%d minutes' AS token_expiry
+	FROM impersonation_audit ia
+	JOIN users a ON a.id = ia.admin_id
+	JOIN users t ON t.id = ia.target_user_id
+	WHERE %%s
+	ORDER BY ia.started_at DESC
+`, int(impersonationTTL.Minutes()))
+
+// GetUserImpersonationLog lists every impersonation session targeting a user.
+func (h *AdminHandler) GetUserImpersonationLog(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid user ID")
+		return
+	}
+
+	h.listImpersonations(c, "ia.target_user_id = $1", userID)
+}
+
+// GetMyImpersonationLog lists the impersonation sessions started by the calling admin.
+func (h *AdminHandler) GetMyImpersonationLog(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	h.listImpersonations(c, "ia.admin_id = $1", adminID)
+}
+
+// GetActiveImpersonations lists the sessions whose impersonation token has not
+// expired yet.
+func (h *AdminHandler) GetActiveImpersonations(c *gin.Context) {
+	h.listImpersonations(c, fmt.Sprintf("ia.started_at + INTERVAL '%d minutes' > NOW()", int(impersonationTTL.Minutes())))
+}
+
+func (h *AdminHandler) listImpersonations(c *gin.Context, condition string, args ...interface{}) {
+	rows, err := h.db.Query(fmt.Sprintf(impersonationLogSQL, condition), args...)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	sessions := make([]models.ImpersonationSession, 0)
+	for rows.Next() {
+		var session models.ImpersonationSession
+		if err := rows.Scan(&session.AdminID, &session.AdminUsername, &session.TargetUserID, &session.TargetUsername, &session.StartedAt, &session.TokenExpiry); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// AdminDeleteUser soft-deletes an account reported for abuse. The user row is kept
+// so existing foreign keys stay valid, but its username and email are replaced to
+// free the unique values, its password is cleared so it can no longer sign in, its
+// profile is removed and its votes are detached. Vote counts are unaffected.
+func (h *AdminHandler) AdminDeleteUser(c *gin.Context) {
+	adminID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req models.AdminDeleteUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	confirmation := fmt.Sprintf("DELETE_USER_%d", userID)
+	if req.ConfirmDelete != confirmation {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "CONFIRM_DELETE must be "+confirmation)
+		return
+	}
+
+	if userID == adminID.(int) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Cannot delete yourself")
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	// user_profiles.email references users.email without ON UPDATE, so the
+	// profile has to go before the email is anonymized
+	if _, err := tx.Exec("DELETE FROM user_profiles WHERE user_id = $1", userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	result, err := tx.Exec(
+		"UPDATE users SET deleted_at = NOW(), username = $2, email = $3, password_hash = '' WHERE id = $1 AND deleted_at IS NULL",
+		userID, fmt.Sprintf("deleted_user_%d", userID), fmt.Sprintf("deleted_%d@deleted.invalid", userID),
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	}
+
+	// Without this the user could keep minting access tokens from an old session
+	if _, err := tx.Exec("UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND revoked = false", userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	result, err = tx.Exec("UPDATE votes SET user_id = NULL WHERE user_id = $1", userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	votesDetached, _ := result.RowsAffected()
+
+	if err := tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	h.recordAudit(c, "user.delete", "user", userID, gin.H{"votes_detached": votesDetached})
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully", "user_id": userID})
+}
+
+// ListUsers lists accounts, including deleted ones, in signup order.
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	page := 1
+	if pageStr := c.Query("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid page")
+			return
+		}
+	}
+
+	limit := defaultUserListLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid limit")
+			return
+		}
+		if limit > maxUserListLimit {
+			limit = maxUserListLimit
+		}
+	}
+
+	rows, err := h.db.Query(
+		"SELECT id, username, email, role, deleted_at, created_at FROM users ORDER BY id LIMIT $1 OFFSET $2",
+		limit, (page-1)*limit,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	users := make([]models.AdminUserSummary, 0)
+	for rows.Next() {
+		var user models.AdminUserSummary
+		if err := rows.Scan(&user.ID, &user.Username, &user.Email, &user.Role, &user.DeletedAt, &user.CreatedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		users = append(users, user)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":  page,
+		"limit": limit,
+		"users": users,
+	})
+}
+
+// DeactivateBallot takes a ballot out of circulation without deleting it. The
+// ballot is stamped with moderated_at so neither its creator nor the activation
+// scheduler can bring it back.
+func (h *AdminHandler) DeactivateBallot(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	result, err := h.db.Exec("UPDATE ballots SET is_active = false, activate_at = NULL, moderated_at = NOW() WHERE id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deactivating ballot")
+		return
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	}
+
+	if err := h.cache.Delete(ballotCacheKey(ballotID)); err != nil {
+		log.Printf("Error invalidating cached ballot %d: %v", ballotID, err)
+	}
+	h.recordAudit(c, "ballot.deactivate", "ballot", ballotID, nil)
+
+	c.JSON(http.StatusOK, gin.H{"id": ballotID, "is_active": false})
+}
+
+// ballotDiagnosticSQL gathers everything GetBallotDiagnostic reports in one round
+// trip: one row per ballot item, with the ballot-wide orphaned and duplicate vote
+// counts repeated on each. A ballot without items yields a single row with a NULL
+// item ID, and a missing ballot yields no rows.
+const ballotDiagnosticSQL = `
+	WITH ballot AS (
+		SELECT id FROM ballots WHERE id = $1
+	),
+	actual AS (
+		SELECT ballot_item_id, COUNT(*) AS vote_count
+		FROM votes
+		WHERE ballot_id = $1
+		GROUP BY ballot_item_id
+	),
+	orphaned AS (
+		SELECT COUNT(*) AS vote_count
+		FROM votes v
+		WHERE v.ballot_id = $1
+		AND NOT EXISTS (SELECT 1 FROM ballot_items bi WHERE bi.id = v.ballot_item_id AND bi.ballot_id = v.ballot_id)
+	),
+	duplicates AS (
+		SELECT COALESCE(SUM(vote_count - 1), 0) AS vote_count
+		FROM (
+			SELECT COUNT(*) AS vote_count
+			FROM votes
+			WHERE ballot_id = $1 AND user_id IS NOT NULL
+			GROUP BY user_id
+			HAVING COUNT(*) > 1
+		) repeated
+	)
+	SELECT bi.id, bi.vote_count, COALESCE(a.vote_count, 0), o.vote_count, d.vote_count
+	FROM ballot b
+	CROSS JOIN orphaned o
+	CROSS JOIN duplicates d
+	LEFT JOIN ballot_items bi ON bi.ballot_id = b.id
+	LEFT JOIN actual a ON a.ballot_item_id = bi.id
+	ORDER BY bi.id
+`
+
+// GetBallotDiagnostic compares a ballot's stored vote counts with the votes
+// actually recorded and reports votes that point at missing items or repeat a
+// voter. Duplicates should be impossible given the unique constraint on votes, so
+// any found indicate the constraint has been lost.
+func (h *AdminHandler) GetBallotDiagnostic(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	rows, err := h.db.Query(ballotDiagnosticSQL, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	found := false
+	items := make([]models.BallotDiagnosticItem, 0)
+	var orphanedVotes, duplicateVotes, storedTotal, actualTotal int
+	for rows.Next() {
+		var itemID, storedCount sql.NullInt64
+		var actualCount int
+		if err := rows.Scan(&itemID, &storedCount, &actualCount, &orphanedVotes, &duplicateVotes); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		found = true
+		if !itemID.Valid {
+			continue
+		}
+		items = append(items, models.BallotDiagnosticItem{
+			ItemID:          int(itemID.Int64),
+			StoredVoteCount: int(storedCount.Int64),
+			ActualVoteCount: actualCount,
+			Discrepancy:     int(storedCount.Int64) - actualCount,
+		})
+		storedTotal += int(storedCount.Int64)
+		actualTotal += actualCount
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !found {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	}
+
+	voteCountMatch := true
+	for _, item := range items {
+		if item.Discrepancy != 0 {
+			voteCountMatch = false
+			break
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":         ballotID,
+		"vote_count_match":  voteCountMatch,
+		"items":             items,
+		"total_votes_match": storedTotal == actualTotal+orphanedVotes,
+		"orphaned_votes":    orphanedVotes,
+		"duplicate_votes":   duplicateVotes,
+	})
+}
+
+// GetTopVoters ranks users by the number of votes cast between from and to
+// (RFC3339, defaulting to all time).
+func (h *AdminHandler) GetTopVoters(c *gin.Context) {
+	limit := defaultTopVotersLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid limit")
+			return
+		}
+		if limit > maxTopVotersLimit {
+			limit = maxTopVotersLimit
+		}
+	}
+
+	from := time.Unix(0, 0).UTC()
+	if fromStr := c.Query("from"); fromStr != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	to := time.Now().UTC()
+	if toStr := c.Query("to"); toStr != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "to must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	if to.Before(from) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be before to")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT u.id, u.username, u.email, up.full_name, COUNT(v.id) as vote_count, COUNT(DISTINCT v.ballot_id) as ballots_voted, MAX(v.created_at) as last_vote
+		FROM votes v
+		JOIN users u ON v.user_id = u.id
+		LEFT JOIN user_profiles up ON up.user_id = u.id
+		WHERE v.created_at BETWEEN $1 AND $2
+		GROUP BY u.id, up.full_name
+		ORDER BY vote_count DESC
+		LIMIT $3
+	`, from, to, limit)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	voters := make([]models.TopVoter, 0)
+	for rows.Next() {
+		var voter models.TopVoter
+		if err := rows.Scan(&voter.UserID, &voter.Username, &voter.Email, &voter.FullName, &voter.VoteCount, &voter.BallotsVoted, &voter.LastVote); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		voters = append(voters, voter)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"from":   from.Format(time.RFC3339),
+		"to":     to.Format(time.RFC3339),
+		"limit":  limit,
+		"voters": voters,
+	})
+}
+
+// creationRateBuckets maps each supported bucket to the step between its periods.
+var creationRateBuckets = map[string]func(time.Time) time.Time{
+	"day":   func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+	"week":  func(t time.Time) time.Time { return t.AddDate(0, 0, 7) },
+	"month": func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+}
+
+// GetBallotCreationRate reports how many ballots were created per day, week or
+// month between from and to (RFC3339, defaulting to all time), with each period's
+// growth over the one before. Periods with no ballots are filled in as zero so the
+// series has no gaps.
+func (h *AdminHandler) GetBallotCreationRate(c *gin.Context) {
+	bucket := c.DefaultQuery("bucket", "day")
+	nextPeriod, ok := creationRateBuckets[bucket]
+	if !ok {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "bucket must be day, week or month")
+		return
+	}
+
+	from := time.Unix(0, 0).UTC()
+	if fromStr := c.Query("from"); fromStr != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	to := time.Now().UTC()
+	if toStr := c.Query("to"); toStr != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "to must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	if to.Before(from) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be before to")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT DATE_TRUNC($1, created_at) AS period, COUNT(*) AS ballots_created, COUNT(DISTINCT creator_id) AS unique_creators
+		FROM ballots
+		WHERE created_at BETWEEN $2 AND $3
+		GROUP BY period
+		ORDER BY period
+	`, bucket, from, to)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	periods := make([]models.BallotCreationPeriod, 0)
+	for rows.Next() {
+		var period models.BallotCreationPeriod
+		if err := rows.Scan(&period.Period, &period.BallotsCreated, &period.UniqueCreators); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		for len(periods) > 0 {
+			gap := nextPeriod(periods[len(periods)-1].Period)
+			if !gap.Before(period.Period) {
+				break
+			}
+			periods = append(periods, models.BallotCreationPeriod{Period: gap})
+		}
+		periods = append(periods, period)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	for i := 1; i < len(periods); i++ {
+		previous := periods[i-1].BallotsCreated
+		if previous == 0 {
+			continue
+		}
+		growth := math.Round(float64(periods[i].BallotsCreated-previous)/float64(previous)*10000) / 100
+		periods[i].GrowthRatePercent = &growth
+	}
+
+	c.JSON(http.StatusOK, periods)
+}
+
+// GetAuditLog lists audit log entries, newest first, optionally filtered by action,
+// acting admin and an RFC3339 from/to range.
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	page := 1
+	if pageStr := c.Query("page"); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid page")
+			return
+		}
+	}
+
+	limit := defaultAuditLogLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid limit")
+			return
+		}
+		if limit > maxAuditLogLimit {
+			limit = maxAuditLogLimit
+		}
+	}
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, column+" $"+strconv.Itoa(len(args)))
+	}
+
+	if action := c.Query("action"); action != "" {
+		addCondition("action =", action)
+	}
+	if adminIDStr := c.Query("admin_id"); adminIDStr != "" {
+		adminID, err := strconv.Atoi(adminIDStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid admin_id")
+			return
+		}
+		addCondition("admin_user_id =", adminID)
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be an RFC3339 timestamp")
+			return
+		}
+		addCondition("created_at >=", from)
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "to must be an RFC3339 timestamp")
+			return
+		}
+		addCondition("created_at <=", to)
+	}
+
+	query := "SELECT id, admin_user_id, action, target_type, target_id, payload, host(ip_address), created_at FROM admin_audit_log"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit, (page-1)*limit)
+	query += " ORDER BY created_at DESC, id DESC LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditLogEntry, 0)
+	for rows.Next() {
+		var entry models.AuditLogEntry
+		var payload []byte
+		if err := rows.Scan(&entry.ID, &entry.AdminUserID, &entry.Action, &entry.TargetType, &entry.TargetID, &payload, &entry.IPAddress, &entry.CreatedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		entry.Payload = payload
+		entries = append(entries, entry)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":    page,
+		"limit":   limit,
+		"entries": entries,
+	})
+}
+
+// GetVoteExport streams raw votes for offline analysis as CSV or NDJSON, one vote
+// per line, oldest first. Rows never carry user IDs; include_user_segment=true adds
+// coarse demographic buckets taken from the voter's profile instead.
+func (h *AdminHandler) GetVoteExport(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "format must be csv or json")
+		return
+	}
+	includeSegment := c.Query("include_user_segment") == "true"
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, column+" $"+strconv.Itoa(len(args)))
+	}
+
+	ballotID := 0
+	if ballotIDStr := c.Query("ballot_id"); ballotIDStr != "" {
+		var err error
+		ballotID, err = strconv.Atoi(ballotIDStr)
+		if err != nil || ballotID < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+			return
+		}
+		addCondition("v.ballot_id =", ballotID)
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "from must be an RFC3339 timestamp")
+			return
+		}
+		addCondition("v.created_at >=", from)
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "to must be an RFC3339 timestamp")
+			return
+		}
+		addCondition("v.created_at <=", to)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var count int
+	if err := h.db.QueryRow("SELECT COUNT(*) FROM votes v"+where, args...).Scan(&count); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if count > maxVoteExportRows {
+		apierrors.RespondErrorWithDetails(c, http.StatusRequestEntityTooLarge, apierrors.ErrCodePayloadTooLarge,
+			fmt.Sprintf("Export would contain %d votes; narrow it with ballot_id, from or to to at most %d", count, maxVoteExportRows),
+			gin.H{"count": count, "max_rows": maxVoteExportRows},
+		)
+		return
+	}
+
+	query := "SELECT v.id, v.ballot_id, v.ballot_item_id, bi.title, v.created_at, v.previous_ballot_item_id"
+	if includeSegment {
+		query += ", up.birthday, up.gender, pa.party_affiliation"
+	}
+	query += " FROM votes v JOIN ballot_items bi ON bi.id = v.ballot_item_id"
+	if includeSegment {
+		query += " LEFT JOIN user_profiles up ON up.user_id = v.user_id LEFT JOIN user_political_affiliations pa ON pa.user_id = v.user_id"
+	}
+	query += where + " ORDER BY v.id"
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	h.recordAudit(c, "votes.export", "ballot", ballotID, gin.H{"format": format, "rows": count, "include_user_segment": includeSegment})
+
+	header := []string{"vote_id", "ballot_id", "ballot_item_id", "item_title", "voted_at", "was_changed", "previous_item_id"}
+	if includeSegment {
+		header = append(header, "age_range", "gender", "political_party")
+	}
+
+	now := time.Now()
+	var csvWriter *csv.Writer
+	var encoder *json.Encoder
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", `attachment; filename="votes.csv"`)
+		csvWriter = csv.NewWriter(c.Writer)
+		// Flush the header right away; an export with no rows never reaches the
+		// per-row flush below.
+		csvWriter.Write(header)
+		csvWriter.Flush()
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		encoder = json.NewEncoder(c.Writer)
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				log.Printf("Error streaming vote export: %v", err)
+			}
+			return false
+		}
+
+		var row models.VoteExportRow
+		dest := []interface{}{&row.VoteID, &row.BallotID, &row.BallotItemID, &row.ItemTitle, &row.VotedAt, &row.PreviousItemID}
+		var birthday *time.Time
+		var gender, party *string
+		if includeSegment {
+			dest = append(dest, &birthday, &gender, &party)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			log.Printf("Error streaming vote export: %v", err)
+			return false
+		}
+		row.WasChanged = row.PreviousItemID != nil
+		if includeSegment {
+			row.AgeRange = ageRange(birthday, now)
+			row.Gender = segmentValue(gender)
+			row.PoliticalParty = segmentValue(party)
+		}
+
+		if encoder != nil {
+			if err := encoder.Encode(row); err != nil {
+				log.Printf("Error streaming vote export: %v", err)
+				return false
+			}
+			return true
+		}
+
+		previousItemID := ""
+		if row.PreviousItemID != nil {
+			previousItemID = strconv.Itoa(*row.PreviousItemID)
+		}
+		record := []string{
+			strconv.Itoa(row.VoteID), strconv.Itoa(row.BallotID), strconv.Itoa(row.BallotItemID), row.ItemTitle,
+			row.VotedAt.UTC().Format(time.RFC3339), strconv.FormatBool(row.WasChanged), previousItemID,
+		}
+		if includeSegment {
+			record = append(record, row.AgeRange, row.Gender, row.PoliticalParty)
+		}
+		csvWriter.Write(record)
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			log.Printf("Error streaming vote export: %v", err)
+			return false
+		}
+		return true
+	})
+}
+
+// ageRange buckets a birthday into a coarse age band so exports cannot single out a voter.
+func ageRange(birthday *time.Time, now time.Time) string {
+	if birthday == nil {
+		return "unknown"
+	}
+	age := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		age--
+	}
+	switch {
+	case age < 18:
+		return "under_18"
+	case age < 25:
+		return "18-24"
+	case age < 35:
+		return "25-34"
+	case age < 45:
+		return "35-44"
+	case age < 55:
+		return "45-54"
+	case age < 65:
+		return "55-64"
+	default:
+		return "65+"
+	}
+}
+
+func segmentValue(value *string) string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return "unknown"
+	}
+	return *value
+}
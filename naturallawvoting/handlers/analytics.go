@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"database/sql"
+	"math"
+	"net/http"
+	"voting-api/apierrors"
+	"voting-api/database"
+	"voting-api/models"
+	"voting-api/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type AnalyticsHandler struct {
+	db *database.DB
+}
+
+func NewAnalyticsHandler(db *database.DB) *AnalyticsHandler {
+	return &AnalyticsHandler{db: db}
+}
+
+// superstateAnalyticsSQL totals a superstate's ballots and votes in one pass.
+// Participation counts distinct voters living in the superstate against every
+// registered user whose address is there, the same eligibility rule as the
+// superstate-scoped participation rate on ballot results. Deleted ballots are
+// left out; closed ones still count.
+const superstateAnalyticsSQL = `
+	WITH superstate_ballots AS (
+		SELECT id, title FROM ballots WHERE superstate = $1 AND deleted_at IS NULL
+	),
+	ballot_totals AS (
+		SELECT sb.id, sb.title, COUNT(v.id) AS total_votes
+		FROM superstate_ballots sb
+		LEFT JOIN ballot_items bi ON bi.ballot_id = sb.id
+		LEFT JOIN votes v ON v.ballot_item_id = bi.id
+		GROUP BY sb.id, sb.title
+	),
+	most_voted AS (
+		SELECT id, title, total_votes FROM ballot_totals ORDER BY total_votes DESC, id ASC LIMIT 1
+	),
+	residents AS (
+		SELECT u.id FROM users u
+		JOIN user_addresses ua ON ua.user_id = u.id
+		WHERE u.deleted_at IS NULL AND LOWER(ua.state) = ANY($2)
+	)
+	SELECT (SELECT COUNT(*) FROM ballot_totals),
+	       (SELECT COALESCE(SUM(total_votes), 0) FROM ballot_totals),
+	       mv.id, mv.title, mv.total_votes,
+	       (SELECT COUNT(DISTINCT v.user_id) FROM votes v JOIN superstate_ballots sb ON sb.id = v.ballot_id JOIN residents r ON r.id = v.user_id),
+	       (SELECT COUNT(*) FROM residents)
+	FROM (SELECT 1) AS one
+	LEFT JOIN most_voted mv ON true
+`
+
+// GetSuperstateAnalytics reports ballot and vote totals for a superstate, its most
+// voted ballot and the share of its residents who have voted on any of its ballots.
+func (h *AnalyticsHandler) GetSuperstateAnalytics(c *gin.Context) {
+	superstate := c.Param("superstate")
+	states, ok := utils.StatesInSuperstate(superstate)
+	if !ok {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Unknown superstate")
+		return
+	}
+
+	analytics := models.SuperstateAnalytics{Superstate: superstate}
+	var mostVotedID sql.NullInt64
+	var mostVotedTitle sql.NullString
+	var mostVotedTotal sql.NullInt64
+	var voters, residents int
+	err := h.db.QueryRow(superstateAnalyticsSQL, superstate, pq.Array(states)).Scan(
+		&analytics.TotalBallots, &analytics.TotalVotes,
+		&mostVotedID, &mostVotedTitle, &mostVotedTotal,
+		&voters, &residents,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if mostVotedID.Valid {
+		analytics.MostVotedBallot = &models.BallotVoteTotal{
+			ID:         int(mostVotedID.Int64),
+			Title:      mostVotedTitle.String,
+			TotalVotes: int(mostVotedTotal.Int64),
+		}
+	}
+	if residents > 0 {
+		analytics.ParticipationRatePct = math.Round(float64(voters)/float64(residents)*10000) / 100
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
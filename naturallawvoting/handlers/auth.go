@@ -3,6 +3,9 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
+	"regexp"
+	"time"
+	"voting-api/apierrors"
 	"voting-api/database"
 	"voting-api/models"
 	"voting-api/utils"
@@ -10,6 +13,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// usernamePattern is the username format accepted by CheckUsername.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]{3,50}$`)
+
 type AuthHandler struct {
 	db *database.DB
 }
@@ -21,25 +27,34 @@ func NewAuthHandler(db *database.DB) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
-	// Check if user already exists
+	// Check email and username separately so the client can tell which one clashed
 	var existingUser models.User
-	err := h.db.QueryRow("SELECT id FROM users WHERE email = $1 OR username = $2", req.Email, req.Username).Scan(&existingUser.ID)
+	err := h.db.QueryRow("SELECT id FROM users WHERE email = $1", req.Email).Scan(&existingUser.ID)
+	if err == nil {
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Email already registered")
+		return
+	} else if err != sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	err = h.db.QueryRow("SELECT id FROM users WHERE username = $1", req.Username).Scan(&existingUser.ID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "User already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Username already taken")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error hashing password"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error hashing password")
 		return
 	}
 
@@ -51,14 +66,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	).Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating user"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating user")
 		return
 	}
 
 	// Generate JWT
 	token, err := utils.GenerateJWT(user.ID, user.Email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
 		return
 	}
 
@@ -71,35 +86,41 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
 	// Get user from database
 	var user models.User
 	err := h.db.QueryRow(
-		"SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1",
+		"SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1",
 		req.Email,
-	).Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Invalid credentials")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	// Check password
 	if !utils.CheckPassword(req.Password, user.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Invalid credentials")
 		return
 	}
 
 	// Generate JWT
-	token, err := utils.GenerateJWT(user.ID, user.Email)
+	token, err := utils.GenerateJWTWithRole(user.ID, user.Email, user.Role)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error generating token"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
+		return
+	}
+
+	refreshToken, err := issueRefreshToken(h.db, user.ID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
 		return
 	}
 
@@ -107,15 +128,108 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	user.Password = ""
 
 	c.JSON(http.StatusOK, models.AuthResponse{
-		Token: token,
-		User:  user,
+		Token:        token,
+		RefreshToken: refreshToken,
+		User:         user,
+	})
+}
+
+// execer is satisfied by both the database handle and a transaction.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// issueRefreshToken stores a new refresh token for userID and returns it.
+func issueRefreshToken(db execer, userID int) (string, error) {
+	token, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = db.Exec(
+		"INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)",
+		userID, hash, time.Now().Add(utils.RefreshTokenTTL),
+	)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RefreshToken exchanges a valid refresh token for a new access token. The
+// refresh token is single use: it is revoked and replaced by a new one.
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	// Tokens belonging to deleted accounts are treated as unknown
+	var tokenID, userID int
+	var email, role string
+	var expiresAt time.Time
+	var revoked bool
+	err = tx.QueryRow(
+		"SELECT rt.id, rt.user_id, u.email, u.role, rt.expires_at, rt.revoked FROM refresh_tokens rt JOIN users u ON u.id = rt.user_id WHERE rt.token_hash = $1 AND u.deleted_at IS NULL FOR UPDATE OF rt",
+		utils.HashRefreshToken(req.RefreshToken),
+	).Scan(&tokenID, &userID, &email, &role, &expiresAt, &revoked)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Invalid refresh token")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if revoked {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Refresh token has been revoked")
+		return
+	}
+	if !time.Now().Before(expiresAt) {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Refresh token has expired")
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE refresh_tokens SET revoked = true WHERE id = $1", tokenID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	refreshToken, err := issueRefreshToken(tx, userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	token, err := utils.GenerateJWTWithRole(userID, email, role)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating token")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.RefreshTokenResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
 	})
 }
 
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -126,12 +240,164 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	).Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	c.JSON(http.StatusOK, user)
+}
+
+// DeleteAccount permanently removes the current user after re-checking their
+// password. Their votes are detached first, as AdminDeleteUser does, so ballot
+// tallies stay consistent; everything else they own goes with the users row
+// through ON DELETE CASCADE.
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot delete an account while impersonating")
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	var passwordHash string
+	err := h.db.QueryRow("SELECT password_hash FROM users WHERE id = $1 AND deleted_at IS NULL", userID).Scan(&passwordHash)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !utils.CheckPassword(req.ConfirmPassword, passwordHash) {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Incorrect password")
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE votes SET user_id = NULL WHERE user_id = $1", userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if _, err := tx.Exec("DELETE FROM users WHERE id = $1", userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting account")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted successfully"})
+}
+
+// ChangePassword replaces the current user's password once the old one is
+// confirmed. Outstanding refresh tokens are revoked in the same transaction so
+// other sessions have to sign in again with the new password.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot change password while impersonating")
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if req.NewPassword == req.OldPassword {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "New password must be different from the old password")
+		return
+	}
+
+	var passwordHash string
+	err := h.db.QueryRow("SELECT password_hash FROM users WHERE id = $1 AND deleted_at IS NULL", userID).Scan(&passwordHash)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !utils.CheckPassword(req.OldPassword, passwordHash) {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Incorrect password")
+		return
+	}
+
+	newHash, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error hashing password")
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE users SET password_hash = $1 WHERE id = $2", newHash, userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating password")
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND revoked = false", userID); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password updated successfully"})
+}
+
+// CheckUsername reports whether a username is still free so signup forms can
+// validate it as the user types, without attempting a registration.
+func (h *AuthHandler) CheckUsername(c *gin.Context) {
+	username := c.Query("username")
+	if !usernamePattern.MatchString(username) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Username must be 3-50 letters, digits or underscores")
+		return
+	}
+
+	var taken bool
+	if err := h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)", username).Scan(&taken); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"username": username, "available": !taken})
 }
\ No newline at end of file
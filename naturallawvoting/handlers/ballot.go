@@ -2,52 +2,112 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
+	"voting-api/apierrors"
+	"voting-api/cache"
 	"voting-api/database"
 	"voting-api/models"
+	"voting-api/sanitize"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/skip2/go-qrcode"
 )
 
+// ballotCacheTTL bounds how stale a cached ballot detail can be, since vote
+// counts are not invalidated on every vote.
+const ballotCacheTTL = 30 * time.Second
+
 type BallotHandler struct {
-	db *database.DB
+	db    *database.DB
+	cache cache.Cacher
+}
+
+func NewBallotHandler(db *database.DB, ballotCache cache.Cacher) *BallotHandler {
+	return &BallotHandler{db: db, cache: ballotCache}
+}
+
+func ballotCacheKey(ballotID int) string {
+	return "ballot:" + strconv.Itoa(ballotID)
 }
 
-func NewBallotHandler(db *database.DB) *BallotHandler {
-	return &BallotHandler{db: db}
+// invalidateBallot drops the cached detail for a ballot after it changes.
+func (h *BallotHandler) invalidateBallot(ballotID int) {
+	if err := h.cache.Delete(ballotCacheKey(ballotID)); err != nil {
+		log.Printf("Error invalidating cached ballot %d: %v", ballotID, err)
+	}
 }
 
 func (h *BallotHandler) CreateBallot(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateBallotRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	// Titles and descriptions are shown verbatim by the frontend, so store plain text only
+	req.Title = sanitize.StripHTML(req.Title)
+	req.Description = sanitize.StripHTML(req.Description)
+	if req.Title == "" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Title must contain text")
+		return
+	}
+	for i := range req.Items {
+		req.Items[i].Title = sanitize.StripHTML(req.Items[i].Title)
+		req.Items[i].Description = sanitize.StripHTML(req.Items[i].Description)
+		if req.Items[i].Title == "" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Item title must contain text")
+			return
+		}
+	}
+
+	if req.ClosesAt != nil && !req.ClosesAt.After(time.Now()) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "closes_at must be in the future")
 		return
 	}
 
 	// Start transaction
 	tx, err := h.db.Begin()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer tx.Rollback()
 
 	// Insert ballot
 	var ballot models.Ballot
+	ballotType := req.BallotType
+	if ballotType == "" {
+		ballotType = models.BallotTypePlurality
+	}
+
+	allowVoteRetraction := true
+	if req.AllowVoteRetraction != nil {
+		allowVoteRetraction = *req.AllowVoteRetraction
+	}
+
 	err = tx.QueryRow(
-		"INSERT INTO ballots (title, description, category, superstate, state, creator_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, title, description, category, superstate, state, creator_id, is_active, created_at, updated_at",
-		req.Title, req.Description, req.Category, req.Superstate, req.State, userID,
-	).Scan(&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID, &ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt)
+		"INSERT INTO ballots (title, description, category, superstate, state, ballot_type, allow_vote_retraction, minimum_quorum, closes_at, creator_id, is_active) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, title, description, category, superstate, state, creator_id, is_active, ballot_type, allow_vote_retraction, minimum_quorum, closes_at, created_at, updated_at",
+		req.Title, req.Description, req.Category, req.Superstate, req.State, ballotType, allowVoteRetraction, req.MinimumQuorum, req.ClosesAt, userID, !req.Draft,
+	).Scan(&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID, &ballot.IsActive, &ballot.BallotType, &ballot.AllowVoteRetraction, &ballot.MinimumQuorum, &ballot.ClosesAt, &ballot.CreatedAt, &ballot.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating ballot"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating ballot")
 		return
 	}
 
@@ -60,8 +120,11 @@ func (h *BallotHandler) CreateBallot(c *gin.Context) {
 			ballot.ID, item.Title, item.Description,
 		).Scan(&ballotItem.ID, &ballotItem.BallotID, &ballotItem.Title, &ballotItem.Description, &ballotItem.VoteCount)
 
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating ballot items"})
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Duplicate ballot item title: "+item.Title)
+			return
+		} else if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating ballot items")
 			return
 		}
 		items = append(items, ballotItem)
@@ -69,143 +132,607 @@ func (h *BallotHandler) CreateBallot(c *gin.Context) {
 
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error committing transaction"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
 		return
 	}
 
 	ballot.Items = items
+	ballot.ItemCount = len(items)
 	c.JSON(http.StatusCreated, ballot)
 }
 
-func (h *BallotHandler) GetAllBallots(c *gin.Context) {
-	category := c.Query("category")
-	superstate := c.Query("superstate")
-	state := c.Query("state")
+// ballotListXML is the XML form of the ballot listing, rooted at <ballots>.
+type ballotListXML struct {
+	XMLName     xml.Name        `xml:"ballots"`
+	Ballots     []models.Ballot `xml:"ballot"`
+	NextCursor  *string         `xml:"next_cursor,omitempty"`
+	NextPageURL *string         `xml:"next_page_url,omitempty"`
+	PrevCursor  *string         `xml:"prev_cursor,omitempty"`
+	PrevPageURL *string         `xml:"prev_page_url,omitempty"`
+	Total       *int            `xml:"total,omitempty"`
+	Limit       *int            `xml:"limit,omitempty"`
+	Offset      *int            `xml:"offset,omitempty"`
+}
 
-	query := `
+// ballotListSelect and ballotListFrom make up the active ballot listing; callers
+// append their filters to ballotListFrom and scan rows with scanBallotListRow.
+const (
+	ballotListSelect = `
 		SELECT b.id, b.title, b.description, b.category, COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, b.created_at, b.updated_at,
-		       u.username as creator_username
+		       b.closes_at, EXTRACT(epoch FROM b.closes_at - NOW())/3600 AS hours_remaining,
+		       u.username as creator_username,
+		       (SELECT COALESCE(SUM(vc.vote_count), 0) FROM ballot_items bi JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id WHERE bi.ballot_id = b.id) AS total_votes,
+		       (SELECT COUNT(*) FROM ballot_items WHERE ballot_id = b.id) AS item_count`
+	ballotListFrom = `
 		FROM ballots b
 		JOIN users u ON b.creator_id = u.id
 		WHERE b.is_active = true`
+)
+
+func scanBallotListRow(rows *sql.Rows) (models.Ballot, error) {
+	var ballot models.Ballot
+	var creatorUsername string
+	err := rows.Scan(
+		&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
+		&ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt, &ballot.ClosesAt, &ballot.HoursRemaining,
+		&creatorUsername, &ballot.TotalVotes, &ballot.ItemCount,
+	)
+	return ballot, err
+}
+
+func (h *BallotHandler) GetAllBallots(c *gin.Context) {
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
+
+	category := c.Query("category")
+	superstate := c.Query("superstate")
+	state := c.Query("state")
+	search := strings.TrimSpace(c.Query("search"))
+
+	sort := c.Query("sort")
+	if sort != "" && sort != "closing_soon" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "sort must be closing_soon")
+		return
+	}
+	onlyClosingSoon := c.Query("only_closing_soon") == "true"
+	hasSponsor := c.Query("has_sponsor") == "true"
+
+	creatorUserID := 0
+	if creatorStr := c.Query("creator_user_id"); creatorStr != "" {
+		var err error
+		creatorUserID, err = strconv.Atoi(creatorStr)
+		if err != nil || creatorUserID < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "creator_user_id must be a positive integer")
+			return
+		}
+	}
+
+	recentlyVotedOn := c.Query("recently_voted_on") == "true"
+	userID, authenticated := c.Get("user_id")
+	if recentlyVotedOn && !authenticated {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Authentication required to filter by recently voted ballots")
+		return
+	}
+
+	// Pagination is opt-in so clients that expect the full list keep working. An
+	// offset selects offset pagination; a cursor or a bare limit selects cursors.
+	afterCursor, beforeCursor := c.Query("after_cursor"), c.Query("before_cursor")
+	offsetStr := c.Query("offset")
+	offsetPaginated := offsetStr != ""
+	paginated := !offsetPaginated && (afterCursor != "" || beforeCursor != "" || c.Query("limit") != "")
+	limit := defaultBallotPageLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid limit")
+			return
+		}
+		if limit > maxBallotPageLimit {
+			limit = maxBallotPageLimit
+		}
+	}
+	offset := 0
+	if offsetPaginated {
+		if afterCursor != "" || beforeCursor != "" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Use either offset or a cursor, not both")
+			return
+		}
+		var err error
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid offset")
+			return
+		}
+	}
+	var cursor ballotCursor
+	if paginated {
+		if afterCursor != "" && beforeCursor != "" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Use only one of after_cursor and before_cursor")
+			return
+		}
+		if sort != "" || recentlyVotedOn {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Cursor pagination is not supported with sort or recently_voted_on")
+			return
+		}
+		if encoded := afterCursor + beforeCursor; encoded != "" {
+			var err error
+			cursor, err = decodeBallotCursor(encoded)
+			if err != nil {
+				apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid cursor")
+				return
+			}
+		}
+	}
+
+	if creatorUserID > 0 {
+		var userExists bool
+		err := h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", creatorUserID).Scan(&userExists)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		if !userExists {
+			apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+			return
+		}
+	}
+
+	query := ballotListSelect
+	// from holds the table and filter clauses, which the offset total is counted over
+	from := ballotListFrom
 
 	var args []interface{}
 	argIndex := 1
 
 	if category != "" {
-		query += ` AND b.category = $` + strconv.Itoa(argIndex)
+		from += ` AND b.category = $` + strconv.Itoa(argIndex)
 		args = append(args, category)
 		argIndex++
 	}
 
 	if superstate != "" {
-		query += ` AND b.superstate = $` + strconv.Itoa(argIndex)
+		from += ` AND b.superstate = $` + strconv.Itoa(argIndex)
 		args = append(args, superstate)
 		argIndex++
 	}
 
 	if state != "" {
-		query += ` AND b.state = $` + strconv.Itoa(argIndex)
+		from += ` AND b.state = $` + strconv.Itoa(argIndex)
 		args = append(args, state)
 		argIndex++
 	}
 
-	query += ` ORDER BY b.created_at DESC`
+	if creatorUserID > 0 {
+		from += ` AND b.creator_id = $` + strconv.Itoa(argIndex)
+		args = append(args, creatorUserID)
+		argIndex++
+	}
+
+	// Keyword search over title and description, served by idx_ballots_search
+	if search != "" {
+		from += ` AND to_tsvector('english', ` + ballotSearchDocument + `) @@ plainto_tsquery('english', $` + strconv.Itoa(argIndex) + `)`
+		args = append(args, search)
+		argIndex++
+	}
+
+	// Ballots that stop accepting votes within the next 48 hours
+	if onlyClosingSoon {
+		from += ` AND b.closes_at IS NOT NULL AND b.closes_at > NOW() AND b.closes_at < NOW() + interval '48 hours'`
+	}
+
+	if hasSponsor {
+		from += ` AND EXISTS (SELECT 1 FROM ballot_sponsors s WHERE s.ballot_id = b.id)`
+	}
+
+	orderBy := ` ORDER BY b.created_at DESC`
+
+	// Ballots the user voted on in the last week, most recently voted first
+	if recentlyVotedOn {
+		userArg := `$` + strconv.Itoa(argIndex)
+		from += ` AND EXISTS (SELECT 1 FROM votes v WHERE v.ballot_id = b.id AND v.user_id = ` + userArg + ` AND v.created_at > NOW() - interval '7 days')`
+		orderBy = ` ORDER BY (SELECT MAX(v.created_at) FROM votes v WHERE v.ballot_id = b.id AND v.user_id = ` + userArg + `) DESC NULLS LAST, b.created_at DESC`
+		args = append(args, userID)
+		argIndex++
+	}
+
+	if sort == "closing_soon" {
+		orderBy = ` ORDER BY b.closes_at ASC NULLS LAST, b.created_at DESC`
+	}
+
+	// Pages run newest first; a before_cursor page is fetched oldest first and
+	// reversed below so the page reads in the usual order.
+	if paginated {
+		orderBy = ` ORDER BY b.created_at DESC, b.id DESC`
+		if afterCursor != "" {
+			from += ` AND (b.created_at, b.id) < ($` + strconv.Itoa(argIndex) + `, $` + strconv.Itoa(argIndex+1) + `)`
+		} else if beforeCursor != "" {
+			from += ` AND (b.created_at, b.id) > ($` + strconv.Itoa(argIndex) + `, $` + strconv.Itoa(argIndex+1) + `)`
+			orderBy = ` ORDER BY b.created_at ASC, b.id ASC`
+		}
+		if afterCursor != "" || beforeCursor != "" {
+			args = append(args, cursor.CreatedAt, cursor.ID)
+			argIndex += 2
+		}
+		// Fetch one extra row to learn whether another page follows
+		orderBy += ` LIMIT $` + strconv.Itoa(argIndex)
+		args = append(args, limit+1)
+		argIndex++
+	}
+
+	var total int
+	if offsetPaginated {
+		if err := h.db.QueryRow(`SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		orderBy += ` LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+		args = append(args, limit, offset)
+		argIndex += 2
+	}
+
+	query += from + orderBy
 
 	rows, err := h.db.Query(query, args...)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer rows.Close()
 
 	var ballots []models.Ballot
 	for rows.Next() {
-		var ballot models.Ballot
-		var creatorUsername string
-		err := rows.Scan(
-			&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
-			&ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt, &creatorUsername,
-		)
+		ballot, err := scanBallotListRow(rows)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning ballot"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot")
 			return
 		}
 		ballots = append(ballots, ballot)
 	}
 
-	c.JSON(http.StatusOK, ballots)
+	if offsetPaginated {
+		if ballots == nil {
+			ballots = []models.Ballot{}
+		}
+		respondNegotiated(c, http.StatusOK, format, gin.H{
+			"data":   ballots,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		}, ballotListXML{Ballots: ballots, Total: &total, Limit: &limit, Offset: &offset})
+		return
+	}
+
+	if !paginated {
+		respondNegotiated(c, http.StatusOK, format, ballots, ballotListXML{Ballots: ballots})
+		return
+	}
+
+	hasMore := len(ballots) > limit
+	if hasMore {
+		ballots = ballots[:limit]
+	}
+	if beforeCursor != "" {
+		for i, j := 0, len(ballots)-1; i < j; i, j = i+1, j-1 {
+			ballots[i], ballots[j] = ballots[j], ballots[i]
+		}
+	}
+
+	// Paging forward, more rows mean a next page; paging backward, they mean a
+	// previous one. The page we came from is always on the other side.
+	hasNext, hasPrev := hasMore, afterCursor != ""
+	if beforeCursor != "" {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	var nextCursor, nextPageURL, prevCursor, prevPageURL *string
+	if len(ballots) > 0 && hasNext {
+		last := ballots[len(ballots)-1]
+		encoded := encodeBallotCursor(last.CreatedAt, last.ID)
+		url := pageURL(c, "after_cursor", encoded, limit)
+		nextCursor, nextPageURL = &encoded, &url
+	}
+	if len(ballots) > 0 && hasPrev {
+		first := ballots[0]
+		encoded := encodeBallotCursor(first.CreatedAt, first.ID)
+		url := pageURL(c, "before_cursor", encoded, limit)
+		prevCursor, prevPageURL = &encoded, &url
+	}
+
+	if ballots == nil {
+		ballots = []models.Ballot{}
+	}
+	respondNegotiated(c, http.StatusOK, format, gin.H{
+		"ballots":       ballots,
+		"next_cursor":   nextCursor,
+		"next_page_url": nextPageURL,
+		"prev_cursor":   prevCursor,
+		"prev_page_url": prevPageURL,
+	}, ballotListXML{
+		Ballots:     ballots,
+		NextCursor:  nextCursor,
+		NextPageURL: nextPageURL,
+		PrevCursor:  prevCursor,
+		PrevPageURL: prevPageURL,
+	})
 }
 
 func (h *BallotHandler) GetBallot(c *gin.Context) {
 	ballotIDStr := c.Param("id")
 	ballotID, err := strconv.Atoi(ballotIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ballot ID"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
 		return
 	}
 
-	// Get ballot
-	var ballot models.Ballot
-	err = h.db.QueryRow(`
-		SELECT b.id, b.title, b.description, b.category, COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, b.created_at, b.updated_at
-		FROM ballots b WHERE b.id = $1
-	`, ballotID).Scan(
-		&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
-		&ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt,
-	)
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return
+	}
 
+	showSimilarVoters := c.Query("show_similar_voters") == "true"
+	userID, authenticated := c.Get("user_id")
+	if showSimilarVoters && !authenticated {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Authentication required to show similar voters")
+		return
+	}
+
+	ballot, err := h.loadBallot(ballotID)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !authenticated {
+		respondNegotiated(c, http.StatusOK, format, ballot, nil)
+		return
+	}
+
+	eligibility, err := h.voteEligibility(c, ballot, userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if showSimilarVoters {
+		popular, err := h.mostPopularAmongParty(ballot, userID)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+
+		respondNegotiated(c, http.StatusOK, format, struct {
+			models.Ballot
+			models.VoteEligibility
+			MostPopularAmongYourParty *models.PartyPopularItem `json:"most_popular_among_your_party" xml:"most_popular_among_your_party"`
+		}{ballot, eligibility, popular}, nil)
+		return
+	}
+
+	respondNegotiated(c, http.StatusOK, format, struct {
+		models.Ballot
+		models.VoteEligibility
+	}{ballot, eligibility}, nil)
+}
+
+// GetBallotWithUserVote returns the ballot along with the caller's own vote,
+// so clients can render the ballot and highlight the chosen item in one request.
+func (h *BallotHandler) GetBallotWithUserVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	ballot, err := h.loadBallot(ballotID)
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Ballot not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	var itemID int
+	err = h.db.QueryRow(
+		"SELECT ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2",
+		userID, ballotID,
+	).Scan(&itemID)
+	if err == nil {
+		ballot.UserVote = &itemID
+	} else if err != sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
-	// Get ballot items with vote counts
+	// Shadow the model's omitempty tag so an unvoted ballot reports "user_vote": null
+	c.JSON(http.StatusOK, struct {
+		models.Ballot
+		UserVote *int `json:"user_vote"`
+	}{ballot, ballot.UserVote})
+}
+
+// voteEligibility runs the checks that would stop the user voting on the ballot,
+// without recording anything, and reports the first one that fails.
+func (h *BallotHandler) voteEligibility(c *gin.Context, ballot models.Ballot, userID interface{}) (models.VoteEligibility, error) {
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		return models.VoteEligibility{CannotVoteReason: "Cannot vote while impersonating"}, nil
+	}
+
+	if !ballot.IsActive {
+		return models.VoteEligibility{CannotVoteReason: "Ballot is not active"}, nil
+	}
+
+	var closed, deactivated bool
+	err := h.db.QueryRow(`
+		SELECT closes_at IS NOT NULL AND closes_at <= NOW(),
+		       EXISTS(SELECT 1 FROM users WHERE id = $2 AND deleted_at IS NOT NULL)
+		FROM ballots WHERE id = $1
+	`, ballot.ID, userID).Scan(&closed, &deactivated)
+	if err != nil {
+		return models.VoteEligibility{}, err
+	}
+
+	if closed {
+		return models.VoteEligibility{CannotVoteReason: "Ballot is closed"}, nil
+	}
+	if deactivated {
+		return models.VoteEligibility{CannotVoteReason: "Account is deactivated"}, nil
+	}
+
+	return models.VoteEligibility{CanVote: true}, nil
+}
+
+// ballotDetailSQL selects a single ballot for queryBallot. Callers append any
+// extra filter on b.
+const ballotDetailSQL = `
+		SELECT b.id, b.title, b.description, b.category, COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, COALESCE(b.ballot_type, 'plurality'), COALESCE(b.locked, false), b.closes_at, b.created_at, b.updated_at,
+		       (SELECT COUNT(*) FROM ballot_sponsors WHERE ballot_id = b.id) AS sponsor_count
+		FROM ballots b WHERE b.id = $1`
+
+// loadBallot returns a ballot with its items, served from the cache when possible.
+// Deleted ballots are reported as sql.ErrNoRows.
+func (h *BallotHandler) loadBallot(ballotID int) (models.Ballot, error) {
+	var ballot models.Ballot
+	if cached, err := h.cache.Get(ballotCacheKey(ballotID)); err == nil {
+		if err := json.Unmarshal(cached, &ballot); err == nil {
+			return ballot, nil
+		}
+	} else if err != cache.ErrMiss {
+		log.Printf("Error reading cached ballot %d: %v", ballotID, err)
+	}
+
+	ballot, err := h.queryBallot(ballotID, false)
+	if err != nil {
+		return ballot, err
+	}
+
+	if encoded, err := json.Marshal(ballot); err == nil {
+		if err := h.cache.Set(ballotCacheKey(ballotID), encoded, ballotCacheTTL); err != nil {
+			log.Printf("Error caching ballot %d: %v", ballotID, err)
+		}
+	}
+
+	return ballot, nil
+}
+
+// queryBallot reads a ballot and its items from the database. Deleted ballots
+// are only returned when includeDeleted is set, and are never cached.
+func (h *BallotHandler) queryBallot(ballotID int, includeDeleted bool) (models.Ballot, error) {
+	query := ballotDetailSQL
+	if !includeDeleted {
+		query += " AND b.deleted_at IS NULL"
+	}
+
+	var ballot models.Ballot
+	var sponsorCount int
+	err := h.db.QueryRow(query, ballotID).Scan(
+		&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
+		&ballot.IsActive, &ballot.BallotType, &ballot.Locked, &ballot.ClosesAt, &ballot.CreatedAt, &ballot.UpdatedAt, &sponsorCount,
+	)
+	if err != nil {
+		return ballot, err
+	}
+	ballot.SponsorCount = &sponsorCount
+
+	// Get ballot items with vote counts derived from the recorded votes
 	rows, err := h.db.Query(`
-		SELECT id, ballot_id, title, description, vote_count
-		FROM ballot_items 
-		WHERE ballot_id = $1 
-		ORDER BY id ASC
+		SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0), bi.updated_at
+		FROM ballot_items bi
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		WHERE bi.ballot_id = $1
+		ORDER BY bi.id ASC
 	`, ballotID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching ballot items"})
-		return
+		return ballot, err
 	}
 	defer rows.Close()
 
 	var items []models.BallotItem
 	for rows.Next() {
 		var item models.BallotItem
-		err := rows.Scan(&item.ID, &item.BallotID, &item.Title, &item.Description, &item.VoteCount)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning ballot item"})
-			return
+		if err := rows.Scan(&item.ID, &item.BallotID, &item.Title, &item.Description, &item.VoteCount, &item.UpdatedAt); err != nil {
+			return ballot, err
 		}
 		items = append(items, item)
 	}
+	if err := rows.Err(); err != nil {
+		return ballot, err
+	}
 
 	ballot.Items = items
-	c.JSON(http.StatusOK, ballot)
+	ballot.ItemCount = len(items)
+	for _, item := range items {
+		ballot.TotalVotes += item.VoteCount
+	}
+
+	return ballot, nil
+}
+
+// mostPopularAmongParty returns the ballot item chosen most often by voters who share
+// the user's party affiliation, or nil when the user has no affiliation or nobody
+// from their party has voted yet.
+func (h *BallotHandler) mostPopularAmongParty(ballot models.Ballot, userID interface{}) (*models.PartyPopularItem, error) {
+	var party sql.NullString
+	err := h.db.QueryRow("SELECT party_affiliation FROM user_political_affiliations WHERE user_id = $1", userID).Scan(&party)
+	if err == sql.ErrNoRows || (err == nil && party.String == "") {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var popular models.PartyPopularItem
+	err = h.db.QueryRow(`
+		SELECT ballot_item_id, COUNT(*) as cnt
+		FROM votes v
+		JOIN user_political_affiliations upa ON v.user_id = upa.user_id
+		WHERE v.ballot_id = $1 AND upa.party_affiliation = $2
+		GROUP BY ballot_item_id
+		ORDER BY cnt DESC
+		LIMIT 1
+	`, ballot.ID, party.String).Scan(&popular.ItemID, &popular.VoteCount)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, item := range ballot.Items {
+		if item.ID == popular.ItemID {
+			popular.Title = item.Title
+			break
+		}
+	}
+
+	return &popular, nil
 }
 
 func (h *BallotHandler) GetUserBallots(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	rows, err := h.db.Query(`
-		SELECT id, title, description, category, COALESCE(superstate, ''), COALESCE(state, ''), creator_id, is_active, created_at, updated_at
+		SELECT id, title, description, category, COALESCE(superstate, ''), COALESCE(state, ''), creator_id, is_active, closes_at, created_at, updated_at
 		FROM ballots
 		WHERE creator_id = $1
 		ORDER BY created_at DESC
 	`, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer rows.Close()
@@ -215,10 +742,10 @@ func (h *BallotHandler) GetUserBallots(c *gin.Context) {
 		var ballot models.Ballot
 		err := rows.Scan(
 			&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
-			&ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt,
+			&ballot.IsActive, &ballot.ClosesAt, &ballot.CreatedAt, &ballot.UpdatedAt,
 		)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning ballot"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot")
 			return
 		}
 		ballots = append(ballots, ballot)
@@ -236,7 +763,7 @@ func (h *BallotHandler) GetSuperstates(c *gin.Context) {
 		ORDER BY superstate
 	`)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer rows.Close()
@@ -245,7 +772,7 @@ func (h *BallotHandler) GetSuperstates(c *gin.Context) {
 	for rows.Next() {
 		var superstate string
 		if err := rows.Scan(&superstate); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning superstate"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning superstate")
 			return
 		}
 		superstates = append(superstates, superstate)
@@ -258,7 +785,7 @@ func (h *BallotHandler) GetSuperstates(c *gin.Context) {
 func (h *BallotHandler) GetStates(c *gin.Context) {
 	superstate := c.Param("superstate")
 	if superstate == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Superstate parameter required"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Superstate parameter required")
 		return
 	}
 
@@ -269,7 +796,7 @@ func (h *BallotHandler) GetStates(c *gin.Context) {
 		ORDER BY state
 	`, superstate)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer rows.Close()
@@ -278,11 +805,1324 @@ func (h *BallotHandler) GetStates(c *gin.Context) {
 	for rows.Next() {
 		var state string
 		if err := rows.Scan(&state); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning state"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning state")
 			return
 		}
 		states = append(states, state)
 	}
 
 	c.JSON(http.StatusOK, gin.H{"superstate": superstate, "states": states})
-}
\ No newline at end of file
+}
+
+// GetSuperstateBallots returns a page of a superstate's active ballots, newest
+// first, with each ballot's items included. The ballots and their items are read
+// in one query rather than one query per ballot.
+func (h *BallotHandler) GetSuperstateBallots(c *gin.Context) {
+	superstate := c.Param("superstate")
+
+	limit, offset, ok := parseLimitOffset(c, defaultBallotPageLimit, maxBallotPageLimit)
+	if !ok {
+		return
+	}
+
+	var total int
+	err := h.db.QueryRow("SELECT COUNT(*) FROM ballots WHERE superstate = $1 AND is_active = true", superstate).Scan(&total)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT b.id, b.title, COALESCE(b.description, ''), COALESCE(b.category, ''), COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, COALESCE(b.ballot_type, 'plurality'), COALESCE(b.locked, false), b.closes_at, b.created_at, b.updated_at,
+		       bi.id, bi.title, bi.description, COALESCE(vc.vote_count, 0)
+		FROM (
+			SELECT * FROM ballots
+			WHERE superstate = $1 AND is_active = true
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2 OFFSET $3
+		) b
+		LEFT JOIN ballot_items bi ON bi.ballot_id = b.id
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		ORDER BY b.created_at DESC, b.id DESC, bi.id ASC
+	`, superstate, limit, offset)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	ballots := []models.Ballot{}
+	for rows.Next() {
+		var ballot models.Ballot
+		var itemID, voteCount sql.NullInt64
+		var itemTitle, itemDescription sql.NullString
+		if err := rows.Scan(
+			&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category, &ballot.Superstate, &ballot.State, &ballot.CreatorID,
+			&ballot.IsActive, &ballot.BallotType, &ballot.Locked, &ballot.ClosesAt, &ballot.CreatedAt, &ballot.UpdatedAt,
+			&itemID, &itemTitle, &itemDescription, &voteCount,
+		); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+
+		// Rows arrive grouped by ballot; start a new ballot when the ID changes
+		if len(ballots) == 0 || ballots[len(ballots)-1].ID != ballot.ID {
+			ballots = append(ballots, ballot)
+		}
+		if !itemID.Valid {
+			continue
+		}
+		current := &ballots[len(ballots)-1]
+		current.Items = append(current.Items, models.BallotItem{
+			ID:          int(itemID.Int64),
+			BallotID:    current.ID,
+			Title:       itemTitle.String,
+			Description: itemDescription.String,
+			VoteCount:   int(voteCount.Int64),
+		})
+		current.ItemCount++
+		current.TotalVotes += int(voteCount.Int64)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"superstate": superstate,
+		"data":       ballots,
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// stateSlugPattern matches the lowercase, hyphenated slugs ballots are filed under,
+// such as new-york-city.
+var stateSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// GetBallotsByState lists a state's active ballots, newest first. It is the same
+// listing as GetAllBallots with ?state=, paginated by limit and offset.
+func (h *BallotHandler) GetBallotsByState(c *gin.Context) {
+	state := c.Param("state")
+	if !stateSlugPattern.MatchString(state) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "State must be a lowercase slug such as new-york-city")
+		return
+	}
+
+	limit, offset, ok := parseLimitOffset(c, defaultBallotPageLimit, maxBallotPageLimit)
+	if !ok {
+		return
+	}
+
+	from := ballotListFrom + ` AND b.state = $1`
+
+	var total int
+	if err := h.db.QueryRow(`SELECT COUNT(*)`+from, state).Scan(&total); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	rows, err := h.db.Query(ballotListSelect+from+` ORDER BY b.created_at DESC, b.id DESC LIMIT $2 OFFSET $3`, state, limit, offset)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	ballots := []models.Ballot{}
+	for rows.Next() {
+		ballot, err := scanBallotListRow(rows)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot")
+			return
+		}
+		ballots = append(ballots, ballot)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"state":  state,
+		"data":   ballots,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// requireBallot verifies the ballot exists and has not been deleted. It writes the
+// error response and returns false when the check fails.
+func requireBallot(c *gin.Context, db *database.DB, ballotID int) bool {
+	var exists bool
+	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)", ballotID).Scan(&exists)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return false
+	}
+	if !exists {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return false
+	}
+	return true
+}
+
+// authorizeBallotCreator verifies the ballot exists and has not been deleted, and
+// that the user created it or was added as a co-creator. It writes the error
+// response and returns false when the check fails.
+func (h *BallotHandler) authorizeBallotCreator(c *gin.Context, ballotID int, userID interface{}) bool {
+	var creatorID int
+	var isCoCreator bool
+	err := h.db.QueryRow(
+		"SELECT creator_id, EXISTS(SELECT 1 FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2) FROM ballots WHERE id = $1 AND deleted_at IS NULL",
+		ballotID, userID,
+	).Scan(&creatorID, &isCoCreator)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return false
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return false
+	}
+
+	if creatorID != userID.(int) && !isCoCreator {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Only the ballot creator can modify this ballot")
+		return false
+	}
+
+	return true
+}
+
+// authorizeOriginalCreator is like authorizeBallotCreator but does not accept
+// co-creators.
+func (h *BallotHandler) authorizeOriginalCreator(c *gin.Context, ballotID int, userID interface{}) bool {
+	var creatorID int
+	err := h.db.QueryRow("SELECT creator_id FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&creatorID)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return false
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return false
+	}
+
+	if creatorID != userID.(int) {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Only the original ballot creator can manage co-creators")
+		return false
+	}
+
+	return true
+}
+
+// UpdateBallot changes a ballot's title, description and/or active flag. Fields
+// left out of the request are not modified. A lock only blocks content edits, and
+// deleted ballots cannot be edited. Changing the active flag follows the same
+// rules as CloseBallot and ReopenBallot.
+func (h *BallotHandler) UpdateBallot(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.UpdateBallotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if req.Title != nil {
+		title := sanitize.StripHTML(*req.Title)
+		if title == "" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Title must contain text")
+			return
+		}
+		req.Title = &title
+	}
+	if req.Description != nil {
+		description := sanitize.StripHTML(*req.Description)
+		req.Description = &description
+	}
+
+	setClauses := []string{}
+	args := []interface{}{}
+	if req.Title != nil {
+		args = append(args, *req.Title)
+		setClauses = append(setClauses, "title = $"+strconv.Itoa(len(args)))
+	}
+	if req.Description != nil {
+		args = append(args, *req.Description)
+		setClauses = append(setClauses, "description = $"+strconv.Itoa(len(args)))
+	}
+	if req.IsActive != nil {
+		args = append(args, *req.IsActive)
+		setClauses = append(setClauses, "is_active = $"+strconv.Itoa(len(args)))
+	}
+	if len(setClauses) == 0 {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
+		return
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return
+	}
+
+	if req.Title != nil || req.Description != nil {
+		var locked bool
+		err = h.db.QueryRow("SELECT COALESCE(locked, false) FROM ballots WHERE id = $1", ballotID).Scan(&locked)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		if locked {
+			apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Ballot is locked")
+			return
+		}
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	var oldTitle, oldDescription string
+	var state ballotOpenState
+	err = tx.QueryRow(
+		"SELECT title, COALESCE(description, ''), is_active, closes_at IS NOT NULL, moderated_at IS NOT NULL FROM ballots WHERE id = $1 AND deleted_at IS NULL FOR UPDATE",
+		ballotID,
+	).Scan(&oldTitle, &oldDescription, &state.isActive, &state.scheduledClose, &state.moderated)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if req.IsActive != nil && *req.IsActive != state.isActive {
+		if *req.IsActive {
+			if refuseReopen(c, state) {
+				return
+			}
+			setClauses = append(setClauses, "closed_at = NULL")
+		} else {
+			setClauses = append(setClauses, "closed_at = NOW()", "deactivate_at = NULL")
+		}
+	}
+
+	args = append(args, ballotID)
+	query := "UPDATE ballots SET " + strings.Join(setClauses, ", ") + " WHERE id = $" + strconv.Itoa(len(args)) +
+		" RETURNING id, title, description, category, creator_id, is_active, created_at, updated_at"
+
+	var ballot models.Ballot
+	err = tx.QueryRow(query, args...).Scan(
+		&ballot.ID, &ballot.Title, &ballot.Description, &ballot.Category,
+		&ballot.CreatorID, &ballot.IsActive, &ballot.CreatedAt, &ballot.UpdatedAt,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating ballot")
+		return
+	}
+
+	// Record each field that actually changed so voters can see what was edited
+	changes := []struct{ field, oldValue, newValue string }{
+		{"title", oldTitle, ballot.Title},
+		{"description", oldDescription, ballot.Description},
+	}
+	for _, change := range changes {
+		if change.oldValue == change.newValue {
+			continue
+		}
+		_, err = tx.Exec(
+			"INSERT INTO ballot_changelog (ballot_id, changed_by, field, old_value, new_value) VALUES ($1, $2, $3, $4, $5)",
+			ballotID, userID, change.field, change.oldValue, change.newValue,
+		)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error recording ballot changes")
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, ballot)
+}
+
+// GetChangelog lists the title and description edits made to a ballot, oldest
+// first. The editor is left out of this public listing.
+func (h *BallotHandler) GetChangelog(c *gin.Context) {
+	h.respondWithChangelog(c, false)
+}
+
+// GetChangelogWithEditors is the admin view of GetChangelog, including who made
+// each change.
+func (h *BallotHandler) GetChangelogWithEditors(c *gin.Context) {
+	h.respondWithChangelog(c, true)
+}
+
+func (h *BallotHandler) respondWithChangelog(c *gin.Context, includeEditors bool) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	rows, err := h.db.Query("SELECT changed_by, field, old_value, new_value, changed_at FROM ballot_changelog WHERE ballot_id = $1 ORDER BY changed_at ASC, id ASC", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	entries := make([]models.BallotChangelogEntry, 0)
+	for rows.Next() {
+		var entry models.BallotChangelogEntry
+		var changedBy sql.NullInt64
+		if err := rows.Scan(&changedBy, &entry.Field, &entry.OldValue, &entry.NewValue, &entry.ChangedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		if includeEditors && changedBy.Valid {
+			editorID := int(changedBy.Int64)
+			entry.ChangedBy = &editorID
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// LockBallot freezes a ballot's title, description and items. A locked ballot can
+// still be published.
+func (h *BallotHandler) LockBallot(c *gin.Context) {
+	h.setBallotLocked(c, true)
+}
+
+// UnlockBallot allows a locked ballot to be edited again.
+func (h *BallotHandler) UnlockBallot(c *gin.Context) {
+	h.setBallotLocked(c, false)
+}
+
+func (h *BallotHandler) setBallotLocked(c *gin.Context, locked bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return
+	}
+
+	_, err = h.db.Exec("UPDATE ballots SET locked = $1 WHERE id = $2", locked, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating ballot")
+		return
+	}
+
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"id": ballotID, "locked": locked})
+}
+
+// ballotOpenState is what CloseBallot, ReopenBallot and UpdateBallot need to
+// know about a ballot before changing whether it accepts votes.
+type ballotOpenState struct {
+	isActive       bool
+	scheduledClose bool
+	moderated      bool
+}
+
+// ballotCloseState loads what CloseBallot and ReopenBallot check before changing
+// a ballot, writing the error response and returning false if the ballot is
+// missing or the user is neither its creator nor a co-creator.
+func (h *BallotHandler) ballotCloseState(c *gin.Context) (int, ballotOpenState, bool) {
+	var state ballotOpenState
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return 0, state, false
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return 0, state, false
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return 0, state, false
+	}
+
+	err = h.db.QueryRow(
+		"SELECT is_active, closes_at IS NOT NULL, moderated_at IS NOT NULL FROM ballots WHERE id = $1 AND deleted_at IS NULL",
+		ballotID,
+	).Scan(&state.isActive, &state.scheduledClose, &state.moderated)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return 0, state, false
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return 0, state, false
+	}
+
+	return ballotID, state, true
+}
+
+// refuseReopen writes the error response and returns true if a closed ballot in
+// the given state may not be reopened by its creator.
+func refuseReopen(c *gin.Context, state ballotOpenState) bool {
+	if state.moderated {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Ballot was deactivated by an administrator")
+		return true
+	}
+	if state.scheduledClose {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballots with a scheduled closing time cannot be reopened")
+		return true
+	}
+	return false
+}
+
+// CloseBallot lets the creator or a co-creator end voting on the ballot early. Any
+// pending scheduled deactivation is dropped since it no longer applies.
+func (h *BallotHandler) CloseBallot(c *gin.Context) {
+	ballotID, state, ok := h.ballotCloseState(c)
+	if !ok {
+		return
+	}
+
+	if !state.isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is already closed")
+		return
+	}
+
+	var closedAt time.Time
+	err := h.db.QueryRow(
+		"UPDATE ballots SET is_active = false, closed_at = NOW(), deactivate_at = NULL WHERE id = $1 RETURNING closed_at",
+		ballotID,
+	).Scan(&closedAt)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating ballot")
+		return
+	}
+
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"id": ballotID, "is_active": false, "closed_at": closedAt})
+}
+
+// ReopenBallot undoes CloseBallot. Ballots with a closes_at time are refused, as
+// reopening them would contradict the voting deadline voters were given, and so
+// are ballots an admin deactivated.
+func (h *BallotHandler) ReopenBallot(c *gin.Context) {
+	ballotID, state, ok := h.ballotCloseState(c)
+	if !ok {
+		return
+	}
+
+	if state.isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is already open")
+		return
+	}
+
+	if refuseReopen(c, state) {
+		return
+	}
+
+	_, err := h.db.Exec("UPDATE ballots SET is_active = true, closed_at = NULL WHERE id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating ballot")
+		return
+	}
+
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"id": ballotID, "is_active": true})
+}
+
+// DeleteBallot soft-deletes a ballot: it is deactivated and stamped with
+// deleted_at, so it leaves the listings while its votes and results are kept.
+func (h *BallotHandler) DeleteBallot(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var creatorID int
+	var deleted bool
+	err = h.db.QueryRow("SELECT creator_id, deleted_at IS NOT NULL FROM ballots WHERE id = $1", ballotID).Scan(&creatorID, &deleted)
+	if err == sql.ErrNoRows || deleted {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if creatorID != userID.(int) {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Only the ballot creator can delete this ballot")
+		return
+	}
+
+	// Clearing activate_at stops a pending scheduled activation reviving the ballot
+	_, err = h.db.Exec("UPDATE ballots SET is_active = false, deleted_at = NOW(), activate_at = NULL WHERE id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting ballot")
+		return
+	}
+
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ballot deleted successfully"})
+}
+
+// GetArchivedBallot returns a deleted ballot, with its items and vote counts, to
+// its creator.
+func (h *BallotHandler) GetArchivedBallot(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var creatorID int
+	var deletedAt time.Time
+	err = h.db.QueryRow("SELECT creator_id, deleted_at FROM ballots WHERE id = $1 AND deleted_at IS NOT NULL", ballotID).Scan(&creatorID, &deletedAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Archived ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if creatorID != userID.(int) {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Only the ballot creator can view an archived ballot")
+		return
+	}
+
+	ballot, err := h.queryBallot(ballotID, true)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	ballot.DeletedAt = &deletedAt
+
+	c.JSON(http.StatusOK, ballot)
+}
+
+// AddCoCreator lets the original creator grant another user the same rights to
+// manage the ballot.
+func (h *BallotHandler) AddCoCreator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.AddCoCreatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !h.authorizeOriginalCreator(c, ballotID, userID) {
+		return
+	}
+
+	if req.UserID == userID.(int) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "The ballot creator cannot be added as a co-creator")
+		return
+	}
+
+	var userExists bool
+	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", req.UserID).Scan(&userExists)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if !userExists {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	}
+
+	coCreator := models.BallotCoCreator{BallotID: ballotID, UserID: req.UserID, AddedBy: userID.(int)}
+	err = h.db.QueryRow(
+		"INSERT INTO ballot_co_creators (ballot_id, user_id, added_by) VALUES ($1, $2, $3) ON CONFLICT (ballot_id, user_id) DO NOTHING RETURNING added_at",
+		ballotID, req.UserID, userID,
+	).Scan(&coCreator.AddedAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "User is already a co-creator")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error adding co-creator")
+		return
+	}
+
+	c.JSON(http.StatusCreated, coCreator)
+}
+
+// RemoveCoCreator revokes a co-creator's rights to manage the ballot.
+func (h *BallotHandler) RemoveCoCreator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	coCreatorID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid user ID")
+		return
+	}
+
+	if !h.authorizeOriginalCreator(c, ballotID, userID) {
+		return
+	}
+
+	result, err := h.db.Exec("DELETE FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2", ballotID, coCreatorID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error removing co-creator")
+		return
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Co-creator not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Co-creator removed successfully"})
+}
+
+// ScheduleActivation sets the time at which a draft ballot is automatically published.
+// Only drafts qualify: ballots that are live or were closed by hand are refused
+// rather than taken offline, and the activation must come before any scheduled
+// deactivation.
+func (h *BallotHandler) ScheduleActivation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.ScheduleActivationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !req.ActivateAt.After(time.Now()) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "activate_at must be in the future")
+		return
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return
+	}
+
+	var isActive, closed, moderated bool
+	var closesAt, deactivateAt *time.Time
+	err = h.db.QueryRow(
+		"SELECT is_active, closed_at IS NOT NULL, moderated_at IS NOT NULL, closes_at, deactivate_at FROM ballots WHERE id = $1 AND deleted_at IS NULL",
+		ballotID,
+	).Scan(&isActive, &closed, &moderated, &closesAt, &deactivateAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if moderated {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Ballot was deactivated by an administrator")
+		return
+	}
+	if isActive || closed {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Only draft ballots can be scheduled for activation")
+		return
+	}
+	// A draft whose closing time has passed would be published already closed
+	now := time.Now()
+	if (closesAt != nil && !closesAt.After(now)) || (deactivateAt != nil && !deactivateAt.After(now)) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot closing time has already passed")
+		return
+	}
+	if closesAt != nil && !req.ActivateAt.Before(*closesAt) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "activate_at must be before closes_at")
+		return
+	}
+	if deactivateAt != nil && !req.ActivateAt.Before(*deactivateAt) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "activate_at must be before deactivate_at")
+		return
+	}
+
+	var ballot models.Ballot
+	err = h.db.QueryRow(
+		"UPDATE ballots SET activate_at = $1 WHERE id = $2 RETURNING id, is_active, activate_at, deactivate_at",
+		req.ActivateAt, ballotID,
+	).Scan(&ballot.ID, &ballot.IsActive, &ballot.ActivateAt, &ballot.DeactivateAt)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scheduling ballot activation")
+		return
+	}
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":     ballot.ID,
+		"is_active":     ballot.IsActive,
+		"activate_at":   ballot.ActivateAt,
+		"deactivate_at": ballot.DeactivateAt,
+	})
+}
+
+// ScheduleDeactivation sets the time at which an active ballot is automatically closed.
+func (h *BallotHandler) ScheduleDeactivation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.ScheduleDeactivationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !req.DeactivateAt.After(time.Now()) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "deactivate_at must be in the future")
+		return
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return
+	}
+
+	var ballot models.Ballot
+	err = h.db.QueryRow(
+		"UPDATE ballots SET deactivate_at = $1 WHERE id = $2 RETURNING id, is_active, activate_at, deactivate_at",
+		req.DeactivateAt, ballotID,
+	).Scan(&ballot.ID, &ballot.IsActive, &ballot.ActivateAt, &ballot.DeactivateAt)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scheduling ballot deactivation")
+		return
+	}
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":     ballot.ID,
+		"is_active":     ballot.IsActive,
+		"activate_at":   ballot.ActivateAt,
+		"deactivate_at": ballot.DeactivateAt,
+	})
+}
+
+const (
+	defaultQRCodeSize = 256
+	minQRCodeSize     = 64
+	maxQRCodeSize     = 512
+)
+
+// GetBallotQRCode renders a PNG QR code linking to the ballot's page on the frontend,
+// for display at physical voting stations.
+func (h *BallotHandler) GetBallotQRCode(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	size := defaultQRCodeSize
+	if sizeStr := c.Query("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil || size < minQRCodeSize || size > maxQRCodeSize {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, fmt.Sprintf("size must be between %d and %d", minQRCodeSize, maxQRCodeSize))
+			return
+		}
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	png, err := qrcode.Encode(ballotPageURL(ballotID), qrcode.Medium, size)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating QR code")
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func frontendURL() string {
+	if url := os.Getenv("FRONTEND_URL"); url != "" {
+		return url
+	}
+	return "http://localhost:3000"
+}
+
+// ballotPageURL links to the ballot's detail page on the frontend.
+func ballotPageURL(ballotID int) string {
+	return fmt.Sprintf("%s/ballot-detail.html?id=%d", strings.TrimRight(frontendURL(), "/"), ballotID)
+}
+
+// GetBallotAccessibility returns ballot data shaped for screen readers and other
+// assistive technology.
+func (h *BallotHandler) GetBallotAccessibility(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var (
+		title, ballotType, language, creatorUsername string
+		isActive                                     bool
+		closesAt                                     *time.Time
+	)
+	// Either closes_at or a scheduled deactivate_at ends voting, whichever comes first
+	err = h.db.QueryRow(`
+		SELECT b.title, b.is_active, LEAST(b.closes_at, b.deactivate_at), COALESCE(b.ballot_type, 'plurality'), COALESCE(b.language, 'en'), u.username
+		FROM ballots b JOIN users u ON b.creator_id = u.id
+		WHERE b.id = $1 AND b.deleted_at IS NULL
+	`, ballotID).Scan(&title, &isActive, &closesAt, &ballotType, &language, &creatorUsername)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	rows, err := h.db.Query("SELECT id, title, COALESCE(description, '') FROM ballot_items WHERE ballot_id = $1 ORDER BY id ASC", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching ballot items")
+		return
+	}
+	defer rows.Close()
+
+	items := make([]models.AccessibleBallotItem, 0)
+	var descriptions []string
+	for rows.Next() {
+		var item models.AccessibleBallotItem
+		var description string
+		if err := rows.Scan(&item.ID, &item.Title, &description); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot item")
+			return
+		}
+		items = append(items, item)
+		descriptions = append(descriptions, description)
+	}
+
+	for i := range items {
+		items[i].Position = i + 1
+		items[i].AriaDescription = fmt.Sprintf("Option %d of %d: %s", i+1, len(items), items[i].Title)
+		if descriptions[i] != "" {
+			items[i].AriaDescription += ". " + descriptions[i]
+		}
+	}
+
+	c.JSON(http.StatusOK, models.BallotAccessibility{
+		BallotID:     ballotID,
+		Title:        title,
+		AriaLabel:    ballotAriaLabel(title, creatorUsername, isActive, closesAt),
+		ItemCount:    len(items),
+		Items:        items,
+		Instructions: ballotInstructions(ballotType, len(items)),
+		Language:     language,
+	})
+}
+
+func ballotAriaLabel(title, creatorUsername string, isActive bool, closesAt *time.Time) string {
+	if !isActive {
+		return fmt.Sprintf("Closed ballot: %s, created by %s, no longer accepting votes", title, creatorUsername)
+	}
+
+	closes := "no end date"
+	if closesAt != nil {
+		closes = closesAt.Format("January 2, 2006")
+	}
+	return fmt.Sprintf("Active ballot: %s, created by %s, closes %s", title, creatorUsername, closes)
+}
+
+func ballotInstructions(ballotType string, itemCount int) string {
+	switch ballotType {
+	case "ranked":
+		return fmt.Sprintf("To vote, rank the following %d options in order of preference and submit.", itemCount)
+	case "approval", "multi_select":
+		return fmt.Sprintf("To vote, select every option you support among the following %d options and submit.", itemCount)
+	case "score":
+		return fmt.Sprintf("To vote, score each of the following %d options from 0 to 10 and submit.", itemCount)
+	default:
+		return fmt.Sprintf("To vote, select one of the following %d options and submit.", itemCount)
+	}
+}
+
+// maxAnnouncementNotifications caps the notifications fanned out per announcement.
+const maxAnnouncementNotifications = 10000
+
+// CreateAnnouncement lets the ballot creator post a message and notifies everyone
+// who has voted on the ballot. One announcement per ballot is allowed per hour.
+func (h *BallotHandler) CreateAnnouncement(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !h.authorizeBallotCreator(c, ballotID, userID) {
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	// Locking the ballot row makes concurrent announcements wait for each other, so
+	// only one of them can pass the hourly limit
+	var recentlyAnnounced bool
+	err = tx.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM ballot_announcements WHERE ballot_id = b.id AND created_at > NOW() - interval '1 hour') FROM ballots b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE",
+		ballotID,
+	).Scan(&recentlyAnnounced)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if recentlyAnnounced {
+		apierrors.RespondError(c, http.StatusTooManyRequests, apierrors.ErrCodeRateLimited, "An announcement was already made for this ballot in the last hour")
+		return
+	}
+
+	var announcement models.BallotAnnouncement
+	err = tx.QueryRow(
+		"INSERT INTO ballot_announcements (ballot_id, creator_id, message) VALUES ($1, $2, $3) RETURNING id, ballot_id, creator_id, message, created_at",
+		ballotID, userID, req.Message,
+	).Scan(&announcement.ID, &announcement.BallotID, &announcement.CreatorID, &announcement.Message, &announcement.CreatedAt)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating announcement")
+		return
+	}
+
+	// Votes detached from deleted accounts have no user to notify
+	result, err := tx.Exec(`
+		INSERT INTO user_notifications (user_id, ballot_id, message)
+		SELECT voters.user_id, $1, $2 FROM (SELECT DISTINCT user_id FROM votes WHERE ballot_id = $1 AND user_id IS NOT NULL LIMIT $3) voters
+	`, ballotID, req.Message, maxAnnouncementNotifications)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error notifying voters")
+		return
+	}
+	notified, err := result.RowsAffected()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error notifying voters")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"announcement":    announcement,
+		"voters_notified": notified,
+	})
+}
+
+// GetAnnouncements lists a ballot's announcements, newest first.
+func (h *BallotHandler) GetAnnouncements(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	rows, err := h.db.Query(
+		"SELECT id, ballot_id, creator_id, message, created_at FROM ballot_announcements WHERE ballot_id = $1 ORDER BY created_at DESC",
+		ballotID,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	announcements := make([]models.BallotAnnouncement, 0)
+	for rows.Next() {
+		var announcement models.BallotAnnouncement
+		if err := rows.Scan(&announcement.ID, &announcement.BallotID, &announcement.CreatorID, &announcement.Message, &announcement.CreatedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	c.JSON(http.StatusOK, announcements)
+}
+
+const (
+	duplicateCheckLimit = 5
+	// duplicateLikelyRank is the ts_rank above which a match is treated as a probable duplicate
+	duplicateLikelyRank = 0.5
+)
+
+// CountBallots returns the number of active ballots, for pagination controls.
+func (h *BallotHandler) CountBallots(c *gin.Context) {
+	var total int
+	if err := h.db.QueryRow("SELECT COUNT(*) FROM ballots WHERE is_active = true").Scan(&total); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"total": total})
+}
+
+// GetCategories lists the categories in use by active ballots, with counts, so
+// clients can build category filters without hardcoding names.
+func (h *BallotHandler) GetCategories(c *gin.Context) {
+	rows, err := h.db.Query("SELECT category, COUNT(*) FROM ballots WHERE is_active = true AND category != '' GROUP BY category ORDER BY category")
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	categories := []models.CategorySummary{}
+	for rows.Next() {
+		var summary models.CategorySummary
+		if err := rows.Scan(&summary.Category, &summary.BallotCount); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		categories = append(categories, summary)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// CheckDuplicateBallots looks for active ballots similar to one about to be
+// created, using the same full-text document as SearchBallots. Only the title
+// is used as the query: plainto_tsquery requires every word to match, so adding
+// the description would hide ballots that share a title but not its wording.
+func (h *BallotHandler) CheckDuplicateBallots(c *gin.Context) {
+	if _, exists := c.Get("user_id"); !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req models.CheckDuplicateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT b.id, b.title, ts_rank(to_tsvector('english', `+ballotSearchDocument+`), plainto_tsquery('english', $1)) AS sim
+		FROM ballots b
+		WHERE b.is_active = true AND to_tsvector('english', `+ballotSearchDocument+`) @@ plainto_tsquery('english', $1)
+		ORDER BY sim DESC
+		LIMIT `+strconv.Itoa(duplicateCheckLimit),
+		strings.TrimSpace(req.Title),
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	similar := make([]models.SimilarBallot, 0)
+	duplicateLikely := false
+	for rows.Next() {
+		var ballot models.SimilarBallot
+		if err := rows.Scan(&ballot.ID, &ballot.Title, &ballot.SimilarityScore); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot")
+			return
+		}
+		if ballot.SimilarityScore > duplicateLikelyRank {
+			duplicateLikely = true
+		}
+		similar = append(similar, ballot)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"similar_ballots":     similar,
+		"is_duplicate_likely": duplicateLikely,
+	})
+}
+
+const (
+	searchResultLimit     = 50
+	maxBoostRecentDays    = 90
+	ballotSearchDocument  = `b.title || ' ' || COALESCE(b.description, '')`
+	ballotSearchHighlight = `'StartSel=<mark>,StopSel=</mark>'`
+)
+
+// SearchBallots runs a full-text search over active ballot titles and descriptions,
+// ordered by ts_rank. highlight=true adds a ts_headline snippet to each result and
+// boost_recent_days=N favours ballots created within the last N days.
+func (h *BallotHandler) SearchBallots(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "q is required")
+		return
+	}
+
+	highlight := c.Query("highlight") == "true"
+
+	boostDays := 0
+	if boostStr := c.Query("boost_recent_days"); boostStr != "" {
+		var err error
+		boostDays, err = strconv.Atoi(boostStr)
+		if err != nil || boostDays < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid boost_recent_days")
+			return
+		}
+		if boostDays > maxBoostRecentDays {
+			boostDays = maxBoostRecentDays
+		}
+	}
+
+	args := []interface{}{q}
+	rank := `ts_rank(to_tsvector('english', ` + ballotSearchDocument + `), query)`
+	if boostDays > 0 {
+		// Ballots older than N days keep their plain rank rather than being penalised
+		rank += ` * GREATEST(1, 1 + ($2::float - extract(epoch from age(b.created_at))/86400) / $2::float)`
+		args = append(args, boostDays)
+	}
+
+	query := `
+		SELECT b.id, b.title, COALESCE(b.description, ''), COALESCE(b.category, ''), b.created_at, ` + rank + ` AS rank`
+	if highlight {
+		query += `, ts_headline('english', ` + ballotSearchDocument + `, query, ` + ballotSearchHighlight + `) AS headline`
+	}
+	query += `
+		FROM ballots b, plainto_tsquery('english', $1) query
+		WHERE b.is_active = true AND to_tsvector('english', ` + ballotSearchDocument + `) @@ query
+		ORDER BY rank DESC, b.created_at DESC
+		LIMIT ` + strconv.Itoa(searchResultLimit)
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	results := make([]models.BallotSearchResult, 0)
+	for rows.Next() {
+		var result models.BallotSearchResult
+		dest := []interface{}{&result.ID, &result.Title, &result.Description, &result.Category, &result.CreatedAt, &result.Rank}
+		if highlight {
+			dest = append(dest, &result.Headline)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error scanning ballot")
+			return
+		}
+		results = append(results, result)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"query":   q,
+		"results": results,
+	})
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"voting-api/apierrors"
+	"voting-api/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedCacheTTL = 10 * time.Minute
+	feedAuthor   = "CommonlawRepublic-USA"
+	atomXMLNS    = "http://www.w3.org/2005/Atom"
+	// maxFeedTitleLength bounds announcement titles, which are taken from the message
+	maxFeedTitleLength = 80
+)
+
+type rssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomXMLNS string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	AtomLink      rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// feedItem is a format-neutral entry in a ballot's feed.
+type feedItem struct {
+	id        string
+	title     string
+	body      string
+	published time.Time
+}
+
+// GetBallotFeed serves a ballot's announcements, newest first and followed by the
+// ballot's creation, as RSS 2.0 (feed.rss) or Atom 1.0 (feed.atom).
+func (h *BallotHandler) GetBallotFeed(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	atom := strings.HasSuffix(c.FullPath(), ".atom")
+	contentType, format := "application/rss+xml", "rss"
+	if atom {
+		contentType, format = "application/atom+xml", "atom"
+	}
+
+	cacheKey := fmt.Sprintf("feed:%s:%d", format, ballotID)
+	if cached, err := h.cache.Get(cacheKey); err == nil {
+		writeFeed(c, contentType, cached)
+		return
+	} else if err != cache.ErrMiss {
+		log.Printf("Error reading cached feed for ballot %d: %v", ballotID, err)
+	}
+
+	var title, description string
+	var createdAt, updatedAt time.Time
+	err = h.db.QueryRow(
+		"SELECT title, COALESCE(description, ''), created_at, updated_at FROM ballots WHERE id = $1 AND deleted_at IS NULL",
+		ballotID,
+	).Scan(&title, &description, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	rows, err := h.db.Query(
+		"SELECT id, message, created_at FROM ballot_announcements WHERE ballot_id = $1 ORDER BY created_at DESC",
+		ballotID,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	pageURL := ballotPageURL(ballotID)
+	var items []feedItem
+	for rows.Next() {
+		var announcementID int
+		var message string
+		var publishedAt time.Time
+		if err := rows.Scan(&announcementID, &message, &publishedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		items = append(items, feedItem{
+			id:        fmt.Sprintf("%s#announcement-%d", pageURL, announcementID),
+			title:     feedTitle(message),
+			body:      message,
+			published: publishedAt,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	items = append(items, feedItem{
+		id:        pageURL + "#created",
+		title:     "Ballot created: " + title,
+		body:      description,
+		published: createdAt,
+	})
+
+	// The feed changes whenever an announcement is posted or the ballot is edited
+	lastUpdated := updatedAt
+	if items[0].published.After(lastUpdated) {
+		lastUpdated = items[0].published
+	}
+
+	selfURL := apiBaseURL(c) + c.Request.URL.Path
+	var document interface{}
+	if atom {
+		feed := atomFeed{
+			XMLNS:   atomXMLNS,
+			Title:   title,
+			ID:      pageURL,
+			Updated: lastUpdated.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: feedAuthor},
+			Links: []atomLink{
+				{Href: selfURL, Rel: "self", Type: contentType},
+				{Href: pageURL, Rel: "alternate", Type: "text/html"},
+			},
+		}
+		for _, item := range items {
+			feed.Entries = append(feed.Entries, atomEntry{
+				Title:   item.title,
+				ID:      item.id,
+				Updated: item.published.UTC().Format(time.RFC3339),
+				Link:    atomLink{Href: pageURL},
+				Content: atomContent{Type: "text", Value: item.body},
+			})
+		}
+		document = feed
+	} else {
+		feed := rssFeed{
+			Version:   "2.0",
+			AtomXMLNS: atomXMLNS,
+			Channel: rssChannel{
+				Title:         title,
+				Link:          pageURL,
+				Description:   description,
+				AtomLink:      rssAtomLink{Href: selfURL, Rel: "self", Type: contentType},
+				LastBuildDate: lastUpdated.UTC().Format(time.RFC1123Z),
+			},
+		}
+		for _, item := range items {
+			feed.Channel.Items = append(feed.Channel.Items, rssItem{
+				Title:       item.title,
+				Link:        pageURL,
+				Description: item.body,
+				GUID:        rssGUID{Value: item.id},
+				PubDate:     item.published.UTC().Format(time.RFC1123Z),
+			})
+		}
+		document = feed
+	}
+
+	body, err := xml.Marshal(document)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating feed")
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+
+	if err := h.cache.Set(cacheKey, body, feedCacheTTL); err != nil {
+		log.Printf("Error caching feed for ballot %d: %v", ballotID, err)
+	}
+
+	writeFeed(c, contentType, body)
+}
+
+func writeFeed(c *gin.Context, contentType string, body []byte) {
+	c.Header("Cache-Control", "public, max-age=600")
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// feedTitle shortens an announcement message to a feed item title.
+func feedTitle(message string) string {
+	runes := []rune(strings.Join(strings.Fields(message), " "))
+	if len(runes) <= maxFeedTitleLength {
+		return string(runes)
+	}
+	return string(runes[:maxFeedTitleLength-1]) + "…"
+}
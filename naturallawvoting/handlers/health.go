@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"voting-api/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthPingTimeout bounds how long a health check waits on the database, so a
+// hung connection shows up as degraded instead of stalling the probe.
+const healthPingTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	db *database.DB
+}
+
+func NewHealthHandler(db *database.DB) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// Check pings the database and reports connection pool usage. It responds 503
+// when the database cannot be reached so load balancers stop routing here.
+func (h *HealthHandler) Check(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthPingTimeout)
+	defer cancel()
+
+	if err := h.db.PingContext(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "degraded", "db_error": err.Error()})
+		return
+	}
+
+	stats := h.db.Stats()
+	c.JSON(http.StatusOK, gin.H{
+		"status":              "ok",
+		"db_open_connections": stats.OpenConnections,
+		"db_in_use":           stats.InUse,
+	})
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"voting-api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// negotiableFormats are the response formats offered to clients that send an
+// Accept header, JSON first so it wins for */* and missing headers.
+var negotiableFormats = []string{gin.MIMEJSON, gin.MIMEXML, gin.MIMEXML2}
+
+// negotiateFormat picks the response format from the Accept header. When nothing
+// offered is acceptable it responds with 406 and returns false.
+func negotiateFormat(c *gin.Context) (string, bool) {
+	format := c.NegotiateFormat(negotiableFormats...)
+	if format == "" {
+		apierrors.RespondError(c, http.StatusNotAcceptable, apierrors.ErrCodeNotAcceptable, "Supported response types are application/json and application/xml")
+		return "", false
+	}
+	return format, true
+}
+
+// respondNegotiated writes body as XML when the client negotiated XML and as JSON
+// otherwise. xmlBody is used in place of body for XML when the JSON shape does not
+// marshal cleanly, such as a gin.H or a bare slice.
+func respondNegotiated(c *gin.Context, code int, format string, body, xmlBody interface{}) {
+	if format == gin.MIMEXML || format == gin.MIMEXML2 {
+		if xmlBody == nil {
+			xmlBody = body
+		}
+		c.XML(code, xmlBody)
+		return
+	}
+	c.JSON(code, body)
+}
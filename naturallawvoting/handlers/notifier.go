@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"sync"
+	"voting-api/models"
+)
+
+// resultNotifier fans out "results changed" signals to clients waiting on a ballot.
+type resultNotifier struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func newResultNotifier() *resultNotifier {
+	return &resultNotifier{subscribers: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Subscribe registers a listener for a ballot. The returned function must be
+// called to release the listener once the caller stops waiting.
+func (n *resultNotifier) Subscribe(ballotID int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mu.Lock()
+	if n.subscribers[ballotID] == nil {
+		n.subscribers[ballotID] = make(map[chan struct{}]struct{})
+	}
+	n.subscribers[ballotID][ch] = struct{}{}
+	n.mu.Unlock()
+
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.subscribers[ballotID], ch)
+		if len(n.subscribers[ballotID]) == 0 {
+			delete(n.subscribers, ballotID)
+		}
+		n.mu.Unlock()
+	}
+}
+
+// Publish wakes every listener waiting on the ballot without blocking the caller.
+func (n *resultNotifier) Publish(ballotID int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscribers[ballotID] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// resultUpdateBufferSize is how many updates a slow stream client may fall behind
+// before further updates to it are dropped.
+const resultUpdateBufferSize = 16
+
+// resultStream fans out per-vote result updates to clients streaming a ballot's
+// results. Unlike resultNotifier it carries the new counts, so clients don't
+// need to refetch the results.
+type resultStream struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan models.ResultUpdate]struct{}
+}
+
+func newResultStream() *resultStream {
+	return &resultStream{subscribers: make(map[int]map[chan models.ResultUpdate]struct{})}
+}
+
+// Subscribe registers a stream for a ballot. The returned function must be called
+// once the client disconnects.
+func (s *resultStream) Subscribe(ballotID int) (<-chan models.ResultUpdate, func()) {
+	ch := make(chan models.ResultUpdate, resultUpdateBufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[ballotID] == nil {
+		s.subscribers[ballotID] = make(map[chan models.ResultUpdate]struct{})
+	}
+	s.subscribers[ballotID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		delete(s.subscribers[ballotID], ch)
+		if len(s.subscribers[ballotID]) == 0 {
+			delete(s.subscribers, ballotID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// HasSubscribers reports whether anyone is streaming the ballot, so voters don't
+// pay for computing updates nobody will receive.
+func (s *resultStream) HasSubscribers(ballotID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[ballotID]) > 0
+}
+
+// Publish sends the update to every stream on the ballot without blocking the
+// caller; streams whose buffer is full miss it.
+func (s *resultStream) Publish(update models.ResultUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers[update.BallotID] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"voting-api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultBallotPageLimit = 20
+	maxBallotPageLimit     = 100
+)
+
+// parseLimitOffset reads limit and offset query parameters for offset-paginated
+// endpoints, capping limit at maxLimit. On bad input it writes a 400 response and
+// returns ok=false.
+func parseLimitOffset(c *gin.Context, defaultLimit, maxLimit int) (limit, offset int, ok bool) {
+	limit = defaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid limit")
+			return 0, 0, false
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		var err error
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid offset")
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
+
+// ballotCursor marks a position in the ballot listing, which pages by
+// (created_at, id) newest first.
+type ballotCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
+
+func encodeBallotCursor(createdAt time.Time, id int) string {
+	encoded, _ := json.Marshal(ballotCursor{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func decodeBallotCursor(s string) (ballotCursor, error) {
+	var cursor ballotCursor
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(decoded, &cursor)
+	return cursor, err
+}
+
+// apiBaseURL is the public base URL of this API, taken from BASE_URL and falling
+// back to the host the request was made to.
+func apiBaseURL(c *gin.Context) string {
+	if url := os.Getenv("BASE_URL"); url != "" {
+		return strings.TrimRight(url, "/")
+	}
+	return requestBaseURL(c)
+}
+
+// pageURL rebuilds the current request URL with its filters intact, pointing at the
+// page on the other side of cursor. param is after_cursor or before_cursor.
+func pageURL(c *gin.Context, param, cursor string, limit int) string {
+	query := c.Request.URL.Query()
+	query.Del("after_cursor")
+	query.Del("before_cursor")
+	query.Set(param, cursor)
+	query.Set("limit", strconv.Itoa(limit))
+	return apiBaseURL(c) + c.Request.URL.Path + "?" + query.Encode()
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
 	"time"
+	"voting-api/apierrors"
 	"voting-api/database"
 	"voting-api/models"
 
@@ -24,7 +26,7 @@ func NewProfileHandler(db *database.DB) *ProfileHandler {
 func (h *ProfileHandler) GetUserProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -32,12 +34,28 @@ func (h *ProfileHandler) GetUserProfile(c *gin.Context) {
 	var email string
 	err := h.db.QueryRow("SELECT email FROM users WHERE id = $1", userID).Scan(&email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
+	profile, err := h.loadUserProfile(email)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if profile == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Profile not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// loadUserProfile looks the profile up by the account email, returning nil if the
+// user has not created one.
+func (h *ProfileHandler) loadUserProfile(email string) (*models.UserProfile, error) {
 	var profile models.UserProfile
-	err = h.db.QueryRow(`
+	err := h.db.QueryRow(`
 		SELECT user_id, email, full_name, birthday, gender, mothers_maiden_name,
 		       phone_number, additional_emails, created_at, updated_at
 		FROM user_profiles WHERE email = $1`,
@@ -45,28 +63,169 @@ func (h *ProfileHandler) GetUserProfile(c *gin.Context) {
 	).Scan(&profile.UserID, &profile.Email, &profile.FullName, &profile.Birthday,
 		&profile.Gender, &profile.MothersMaidenName, &profile.PhoneNumber,
 		&profile.AdditionalEmails, &profile.CreatedAt, &profile.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetFullProfile returns the account and every profile section in one response,
+// so a dashboard does not need a request per section. Sections the user has not
+// filled in are null.
+func (h *ProfileHandler) GetFullProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
 
+	full, err := h.loadFullProfile(userID)
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
-	c.JSON(http.StatusOK, profile)
+	c.JSON(http.StatusOK, full)
+}
+
+// loadFullProfile reads the account and each profile section, returning
+// sql.ErrNoRows if the user does not exist.
+func (h *ProfileHandler) loadFullProfile(userID interface{}) (models.FullUserProfile, error) {
+	var full models.FullUserProfile
+	err := h.db.QueryRow(
+		"SELECT id, username, email, created_at, updated_at FROM users WHERE id = $1",
+		userID,
+	).Scan(&full.User.ID, &full.User.Username, &full.User.Email, &full.User.CreatedAt, &full.User.UpdatedAt)
+	if err != nil {
+		return full, err
+	}
+
+	sections := []func() error{
+		func() (err error) { full.Profile, err = h.loadUserProfile(full.User.Email); return },
+		func() (err error) { full.Address, err = h.loadUserAddress(userID); return },
+		func() (err error) { full.PoliticalAffiliation, err = h.loadPoliticalAffiliation(userID); return },
+		func() (err error) { full.ReligiousAffiliation, err = h.loadReligiousAffiliation(userID); return },
+		func() (err error) { full.RaceEthnicity, err = h.loadRaceEthnicity(userID); return },
+		func() (err error) { full.EconomicInfo, err = h.loadEconomicInfo(userID); return },
+	}
+	for _, load := range sections {
+		if err := load(); err != nil {
+			return full, err
+		}
+	}
+	return full, nil
+}
+
+// ExportUserData serves everything stored about the user, including their votes,
+// as a JSON download so they can exercise their right of access.
+func (h *ProfileHandler) ExportUserData(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	full, err := h.loadFullProfile(userID)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "User not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT ballot_id, ballot_item_id, NULL::integer AS score, NULL::integer AS rank, created_at FROM votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, NULL, NULL, created_at FROM multi_votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, score, NULL, created_at FROM score_votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, NULL, rank, created_at FROM ranked_votes WHERE user_id = $1
+		ORDER BY created_at ASC`,
+		userID,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	export := models.UserDataExport{FullUserProfile: full, Votes: []models.ExportedVote{}, ExportedAt: time.Now().UTC()}
+	for rows.Next() {
+		var vote models.ExportedVote
+		if err := rows.Scan(&vote.BallotID, &vote.BallotItemID, &vote.Score, &vote.Rank, &vote.VotedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		export.Votes = append(export.Votes, vote)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="user_data.json"`)
+	c.JSON(http.StatusOK, export)
+}
+
+// profileSections are the sections counted by GetProfileCompleteness, in the
+// order the profile form presents them.
+var profileSections = []string{"profile", "address", "political", "religious", "race-ethnicity", "economic"}
+
+// GetProfileCompleteness tells the user which profile sections they have filled
+// in and which are still missing, with the completed share as a whole percentage.
+func (h *ProfileHandler) GetProfileCompleteness(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	filled := make([]bool, len(profileSections))
+	err := h.db.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM user_profiles WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_addresses WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_political_affiliations WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_religious_affiliations WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_race_ethnicity WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM economic_info WHERE user_id = $1)`,
+		userID,
+	).Scan(&filled[0], &filled[1], &filled[2], &filled[3], &filled[4], &filled[5])
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	completeness := models.ProfileCompleteness{Missing: []string{}, Completed: []string{}}
+	for i, section := range profileSections {
+		if filled[i] {
+			completeness.Completed = append(completeness.Completed, section)
+		} else {
+			completeness.Missing = append(completeness.Missing, section)
+		}
+	}
+	completeness.ScorePct = len(completeness.Completed) * 100 / len(profileSections)
+
+	c.JSON(http.StatusOK, completeness)
 }
 
 func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateUserProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -74,7 +233,7 @@ func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 	var email string
 	err := h.db.QueryRow("SELECT email FROM users WHERE id = $1", userID).Scan(&email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -82,10 +241,10 @@ func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 	var existingProfile models.UserProfile
 	err = h.db.QueryRow("SELECT user_id FROM user_profiles WHERE email = $1", email).Scan(&existingProfile.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Profile already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Profile already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -94,7 +253,7 @@ func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 	if req.Birthday != "" {
 		parsedDate, err := time.Parse("2006-01-02", req.Birthday)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid birthday format. Use YYYY-MM-DD"})
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid birthday format. Use YYYY-MM-DD")
 			return
 		}
 		birthday = &parsedDate
@@ -114,7 +273,7 @@ func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 		&profile.AdditionalEmails, &profile.CreatedAt, &profile.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating profile"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating profile")
 		return
 	}
 
@@ -124,13 +283,13 @@ func (h *ProfileHandler) CreateUserProfile(c *gin.Context) {
 func (h *ProfileHandler) UpdateUserProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateUserProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -138,7 +297,7 @@ func (h *ProfileHandler) UpdateUserProfile(c *gin.Context) {
 	var email string
 	err := h.db.QueryRow("SELECT email FROM users WHERE id = $1", userID).Scan(&email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -148,49 +307,49 @@ func (h *ProfileHandler) UpdateUserProfile(c *gin.Context) {
 	argCount := 1
 
 	if req.FullName != nil {
-		query += "full_name = $" + string(rune(argCount+'0')) + ", "
+		query += "full_name = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.FullName)
 		argCount++
 	}
 	if req.Birthday != nil {
 		parsedDate, err := time.Parse("2006-01-02", *req.Birthday)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid birthday format. Use YYYY-MM-DD"})
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid birthday format. Use YYYY-MM-DD")
 			return
 		}
-		query += "birthday = $" + string(rune(argCount+'0')) + ", "
+		query += "birthday = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, parsedDate)
 		argCount++
 	}
 	if req.Gender != nil {
-		query += "gender = $" + string(rune(argCount+'0')) + ", "
+		query += "gender = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.Gender)
 		argCount++
 	}
 	if req.MothersMaidenName != nil {
-		query += "mothers_maiden_name = $" + string(rune(argCount+'0')) + ", "
+		query += "mothers_maiden_name = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.MothersMaidenName)
 		argCount++
 	}
 	if req.PhoneNumber != nil {
-		query += "phone_number = $" + string(rune(argCount+'0')) + ", "
+		query += "phone_number = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.PhoneNumber)
 		argCount++
 	}
 	if req.AdditionalEmails != nil {
-		query += "additional_emails = $" + string(rune(argCount+'0')) + ", "
+		query += "additional_emails = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, pq.Array(req.AdditionalEmails))
 		argCount++
 	}
 
 	if len(args) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
 	// Remove trailing comma and space
 	query = query[:len(query)-2]
-	query += " WHERE email = $" + string(rune(argCount+'0')) + " RETURNING user_id, email, full_name, birthday, gender, mothers_maiden_name, phone_number, additional_emails, created_at, updated_at"
+	query += " WHERE email = $" + strconv.Itoa(argCount) + " RETURNING user_id, email, full_name, birthday, gender, mothers_maiden_name, phone_number, additional_emails, created_at, updated_at"
 	args = append(args, email)
 
 	var profile models.UserProfile
@@ -200,10 +359,10 @@ func (h *ProfileHandler) UpdateUserProfile(c *gin.Context) {
 		&profile.AdditionalEmails, &profile.CreatedAt, &profile.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Profile not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating profile"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating profile")
 		return
 	}
 
@@ -213,7 +372,7 @@ func (h *ProfileHandler) UpdateUserProfile(c *gin.Context) {
 func (h *ProfileHandler) DeleteUserProfile(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -221,19 +380,19 @@ func (h *ProfileHandler) DeleteUserProfile(c *gin.Context) {
 	var email string
 	err := h.db.QueryRow("SELECT email FROM users WHERE id = $1", userID).Scan(&email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM user_profiles WHERE email = $1", email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting profile"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting profile")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Profile not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Profile not found")
 		return
 	}
 
@@ -245,10 +404,25 @@ func (h *ProfileHandler) DeleteUserProfile(c *gin.Context) {
 func (h *ProfileHandler) GetUserAddress(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
+	address, err := h.loadUserAddress(userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if address == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Address not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
+
+// loadUserAddress returns the user's address, or nil if they have not saved one.
+func (h *ProfileHandler) loadUserAddress(userID interface{}) (*models.UserAddress, error) {
 	var address models.UserAddress
 	err := h.db.QueryRow(`
 		SELECT user_id, street_number, street_name, address_line_2, city, state,
@@ -258,28 +432,25 @@ func (h *ProfileHandler) GetUserAddress(c *gin.Context) {
 	).Scan(&address.UserID, &address.StreetNumber, &address.StreetName,
 		&address.AddressLine2, &address.City, &address.State, &address.ZipCode,
 		&address.CreatedAt, &address.UpdatedAt)
-
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Address not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
+		return nil, nil
 	}
-
-	c.JSON(http.StatusOK, address)
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
 }
 
 func (h *ProfileHandler) CreateUserAddress(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateUserAddressRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -287,10 +458,10 @@ func (h *ProfileHandler) CreateUserAddress(c *gin.Context) {
 	var existingAddress models.UserAddress
 	err := h.db.QueryRow("SELECT user_id FROM user_addresses WHERE user_id = $1", userID).Scan(&existingAddress.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Address already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Address already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -307,7 +478,7 @@ func (h *ProfileHandler) CreateUserAddress(c *gin.Context) {
 		&address.CreatedAt, &address.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating address"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating address")
 		return
 	}
 
@@ -317,13 +488,13 @@ func (h *ProfileHandler) CreateUserAddress(c *gin.Context) {
 func (h *ProfileHandler) UpdateUserAddress(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateUserAddressRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -333,44 +504,44 @@ func (h *ProfileHandler) UpdateUserAddress(c *gin.Context) {
 	argCount := 1
 
 	if req.StreetNumber != nil {
-		query += "street_number = $" + string(rune(argCount+'0')) + ", "
+		query += "street_number = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.StreetNumber)
 		argCount++
 	}
 	if req.StreetName != nil {
-		query += "street_name = $" + string(rune(argCount+'0')) + ", "
+		query += "street_name = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.StreetName)
 		argCount++
 	}
 	if req.AddressLine2 != nil {
-		query += "address_line_2 = $" + string(rune(argCount+'0')) + ", "
+		query += "address_line_2 = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.AddressLine2)
 		argCount++
 	}
 	if req.City != nil {
-		query += "city = $" + string(rune(argCount+'0')) + ", "
+		query += "city = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.City)
 		argCount++
 	}
 	if req.State != nil {
-		query += "state = $" + string(rune(argCount+'0')) + ", "
+		query += "state = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.State)
 		argCount++
 	}
 	if req.ZipCode != nil {
-		query += "zip_code = $" + string(rune(argCount+'0')) + ", "
+		query += "zip_code = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.ZipCode)
 		argCount++
 	}
 
 	if len(args) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
 	// Remove trailing comma and space
 	query = query[:len(query)-2]
-	query += " WHERE user_id = $" + string(rune(argCount+'0')) + " RETURNING user_id, street_number, street_name, address_line_2, city, state, zip_code, created_at, updated_at"
+	query += " WHERE user_id = $" + strconv.Itoa(argCount) + " RETURNING user_id, street_number, street_name, address_line_2, city, state, zip_code, created_at, updated_at"
 	args = append(args, userID)
 
 	var address models.UserAddress
@@ -380,10 +551,10 @@ func (h *ProfileHandler) UpdateUserAddress(c *gin.Context) {
 		&address.CreatedAt, &address.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Address not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Address not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating address"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating address")
 		return
 	}
 
@@ -393,19 +564,19 @@ func (h *ProfileHandler) UpdateUserAddress(c *gin.Context) {
 func (h *ProfileHandler) DeleteUserAddress(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM user_addresses WHERE user_id = $1", userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting address"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting address")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Address not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Address not found")
 		return
 	}
 
@@ -417,10 +588,25 @@ func (h *ProfileHandler) DeleteUserAddress(c *gin.Context) {
 func (h *ProfileHandler) GetUserPoliticalAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
+	affiliation, err := h.loadPoliticalAffiliation(userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if affiliation == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Political affiliation not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, affiliation)
+}
+
+// loadPoliticalAffiliation returns nil when the user has not set a party.
+func (h *ProfileHandler) loadPoliticalAffiliation(userID interface{}) (*models.UserPoliticalAffiliation, error) {
 	var affiliation models.UserPoliticalAffiliation
 	err := h.db.QueryRow(`
 		SELECT user_id, party_affiliation, created_at, updated_at
@@ -428,28 +614,25 @@ func (h *ProfileHandler) GetUserPoliticalAffiliation(c *gin.Context) {
 		userID,
 	).Scan(&affiliation.UserID, &affiliation.PartyAffiliation,
 		&affiliation.CreatedAt, &affiliation.UpdatedAt)
-
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Political affiliation not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
+		return nil, nil
 	}
-
-	c.JSON(http.StatusOK, affiliation)
+	if err != nil {
+		return nil, err
+	}
+	return &affiliation, nil
 }
 
 func (h *ProfileHandler) CreateUserPoliticalAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateUserPoliticalAffiliationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -457,10 +640,10 @@ func (h *ProfileHandler) CreateUserPoliticalAffiliation(c *gin.Context) {
 	var existingAffiliation models.UserPoliticalAffiliation
 	err := h.db.QueryRow("SELECT user_id FROM user_political_affiliations WHERE user_id = $1", userID).Scan(&existingAffiliation.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Political affiliation already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Political affiliation already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -474,7 +657,7 @@ func (h *ProfileHandler) CreateUserPoliticalAffiliation(c *gin.Context) {
 		&affiliation.CreatedAt, &affiliation.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating political affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating political affiliation")
 		return
 	}
 
@@ -484,18 +667,18 @@ func (h *ProfileHandler) CreateUserPoliticalAffiliation(c *gin.Context) {
 func (h *ProfileHandler) UpdateUserPoliticalAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateUserPoliticalAffiliationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
 	if req.PartyAffiliation == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
@@ -510,10 +693,10 @@ func (h *ProfileHandler) UpdateUserPoliticalAffiliation(c *gin.Context) {
 		&affiliation.CreatedAt, &affiliation.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Political affiliation not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Political affiliation not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating political affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating political affiliation")
 		return
 	}
 
@@ -523,19 +706,19 @@ func (h *ProfileHandler) UpdateUserPoliticalAffiliation(c *gin.Context) {
 func (h *ProfileHandler) DeleteUserPoliticalAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM user_political_affiliations WHERE user_id = $1", userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting political affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting political affiliation")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Political affiliation not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Political affiliation not found")
 		return
 	}
 
@@ -547,10 +730,25 @@ func (h *ProfileHandler) DeleteUserPoliticalAffiliation(c *gin.Context) {
 func (h *ProfileHandler) GetUserReligiousAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
+	affiliation, err := h.loadReligiousAffiliation(userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if affiliation == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Religious affiliation not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, affiliation)
+}
+
+// loadReligiousAffiliation returns nil when the user has not filled in religion.
+func (h *ProfileHandler) loadReligiousAffiliation(userID interface{}) (*models.UserReligiousAffiliation, error) {
 	var affiliation models.UserReligiousAffiliation
 	err := h.db.QueryRow(`
 		SELECT user_id, religion, supporting_religion, religious_services_types,
@@ -559,34 +757,31 @@ func (h *ProfileHandler) GetUserReligiousAffiliation(c *gin.Context) {
 		userID,
 	).Scan(&affiliation.UserID, &affiliation.Religion, &affiliation.SupportingReligion,
 		&affiliation.ReligiousServicesTypes, &affiliation.CreatedAt, &affiliation.UpdatedAt)
-
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Religious affiliation not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
+		return nil, nil
 	}
-
-	c.JSON(http.StatusOK, affiliation)
+	if err != nil {
+		return nil, err
+	}
+	return &affiliation, nil
 }
 
 func (h *ProfileHandler) CreateUserReligiousAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateUserReligiousAffiliationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
 	// Validate supporting_religion is between 0-10
 	if req.SupportingReligion != nil && (*req.SupportingReligion < 0 || *req.SupportingReligion > 10) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "supporting_religion must be between 0 and 10"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "supporting_religion must be between 0 and 10")
 		return
 	}
 
@@ -594,10 +789,10 @@ func (h *ProfileHandler) CreateUserReligiousAffiliation(c *gin.Context) {
 	var existingAffiliation models.UserReligiousAffiliation
 	err := h.db.QueryRow("SELECT user_id FROM user_religious_affiliations WHERE user_id = $1", userID).Scan(&existingAffiliation.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Religious affiliation already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Religious affiliation already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -613,7 +808,7 @@ func (h *ProfileHandler) CreateUserReligiousAffiliation(c *gin.Context) {
 		&affiliation.ReligiousServicesTypes, &affiliation.CreatedAt, &affiliation.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating religious affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating religious affiliation")
 		return
 	}
 
@@ -623,19 +818,19 @@ func (h *ProfileHandler) CreateUserReligiousAffiliation(c *gin.Context) {
 func (h *ProfileHandler) UpdateUserReligiousAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateUserReligiousAffiliationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
 	// Validate supporting_religion is between 0-10
 	if req.SupportingReligion != nil && (*req.SupportingReligion < 0 || *req.SupportingReligion > 10) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "supporting_religion must be between 0 and 10"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "supporting_religion must be between 0 and 10")
 		return
 	}
 
@@ -645,29 +840,29 @@ func (h *ProfileHandler) UpdateUserReligiousAffiliation(c *gin.Context) {
 	argCount := 1
 
 	if req.Religion != nil {
-		query += "religion = $" + string(rune(argCount+'0')) + ", "
+		query += "religion = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.Religion)
 		argCount++
 	}
 	if req.SupportingReligion != nil {
-		query += "supporting_religion = $" + string(rune(argCount+'0')) + ", "
+		query += "supporting_religion = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.SupportingReligion)
 		argCount++
 	}
 	if req.ReligiousServicesTypes != nil {
-		query += "religious_services_types = $" + string(rune(argCount+'0')) + ", "
+		query += "religious_services_types = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, pq.Array(req.ReligiousServicesTypes))
 		argCount++
 	}
 
 	if len(args) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
 	// Remove trailing comma and space
 	query = query[:len(query)-2]
-	query += " WHERE user_id = $" + string(rune(argCount+'0')) + " RETURNING user_id, religion, supporting_religion, religious_services_types, created_at, updated_at"
+	query += " WHERE user_id = $" + strconv.Itoa(argCount) + " RETURNING user_id, religion, supporting_religion, religious_services_types, created_at, updated_at"
 	args = append(args, userID)
 
 	var affiliation models.UserReligiousAffiliation
@@ -676,10 +871,10 @@ func (h *ProfileHandler) UpdateUserReligiousAffiliation(c *gin.Context) {
 		&affiliation.ReligiousServicesTypes, &affiliation.CreatedAt, &affiliation.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Religious affiliation not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Religious affiliation not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating religious affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating religious affiliation")
 		return
 	}
 
@@ -689,19 +884,19 @@ func (h *ProfileHandler) UpdateUserReligiousAffiliation(c *gin.Context) {
 func (h *ProfileHandler) DeleteUserReligiousAffiliation(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM user_religious_affiliations WHERE user_id = $1", userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting religious affiliation"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting religious affiliation")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Religious affiliation not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Religious affiliation not found")
 		return
 	}
 
@@ -713,10 +908,25 @@ func (h *ProfileHandler) DeleteUserReligiousAffiliation(c *gin.Context) {
 func (h *ProfileHandler) GetUserRaceEthnicity(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	raceEthnicity, err := h.loadRaceEthnicity(userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if raceEthnicity == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Race/ethnicity not found")
 		return
 	}
 
+	c.JSON(http.StatusOK, raceEthnicity)
+}
+
+// loadRaceEthnicity returns nil when the user has not filled in race/ethnicity.
+func (h *ProfileHandler) loadRaceEthnicity(userID interface{}) (*models.UserRaceEthnicity, error) {
 	var raceEthnicity models.UserRaceEthnicity
 	err := h.db.QueryRow(`
 		SELECT user_id, race, created_at, updated_at
@@ -724,28 +934,25 @@ func (h *ProfileHandler) GetUserRaceEthnicity(c *gin.Context) {
 		userID,
 	).Scan(&raceEthnicity.UserID, &raceEthnicity.Race,
 		&raceEthnicity.CreatedAt, &raceEthnicity.UpdatedAt)
-
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Race/ethnicity not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
+		return nil, nil
 	}
-
-	c.JSON(http.StatusOK, raceEthnicity)
+	if err != nil {
+		return nil, err
+	}
+	return &raceEthnicity, nil
 }
 
 func (h *ProfileHandler) CreateUserRaceEthnicity(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateUserRaceEthnicityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -753,10 +960,10 @@ func (h *ProfileHandler) CreateUserRaceEthnicity(c *gin.Context) {
 	var existingRaceEthnicity models.UserRaceEthnicity
 	err := h.db.QueryRow("SELECT user_id FROM user_race_ethnicity WHERE user_id = $1", userID).Scan(&existingRaceEthnicity.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Race/ethnicity already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Race/ethnicity already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -770,7 +977,7 @@ func (h *ProfileHandler) CreateUserRaceEthnicity(c *gin.Context) {
 		&raceEthnicity.CreatedAt, &raceEthnicity.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating race/ethnicity"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating race/ethnicity")
 		return
 	}
 
@@ -780,18 +987,18 @@ func (h *ProfileHandler) CreateUserRaceEthnicity(c *gin.Context) {
 func (h *ProfileHandler) UpdateUserRaceEthnicity(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateUserRaceEthnicityRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
 	if req.Race == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
@@ -806,10 +1013,10 @@ func (h *ProfileHandler) UpdateUserRaceEthnicity(c *gin.Context) {
 		&raceEthnicity.CreatedAt, &raceEthnicity.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Race/ethnicity not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Race/ethnicity not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating race/ethnicity"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating race/ethnicity")
 		return
 	}
 
@@ -819,19 +1026,19 @@ func (h *ProfileHandler) UpdateUserRaceEthnicity(c *gin.Context) {
 func (h *ProfileHandler) DeleteUserRaceEthnicity(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM user_race_ethnicity WHERE user_id = $1", userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting race/ethnicity"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting race/ethnicity")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Race/ethnicity not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Race/ethnicity not found")
 		return
 	}
 
@@ -843,10 +1050,25 @@ func (h *ProfileHandler) DeleteUserRaceEthnicity(c *gin.Context) {
 func (h *ProfileHandler) GetEconomicInfo(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	economicInfo, err := h.loadEconomicInfo(userID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	if economicInfo == nil {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Economic info not found")
 		return
 	}
 
+	c.JSON(http.StatusOK, economicInfo)
+}
+
+// loadEconomicInfo returns nil when the user has not filled in economic views.
+func (h *ProfileHandler) loadEconomicInfo(userID interface{}) (*models.EconomicInfo, error) {
 	var economicInfo models.EconomicInfo
 	err := h.db.QueryRow(`
 		SELECT user_id, for_current_political_structure, for_capitalism, for_laws,
@@ -858,28 +1080,25 @@ func (h *ProfileHandler) GetEconomicInfo(c *gin.Context) {
 		&economicInfo.ForCapitalism, &economicInfo.ForLaws, &economicInfo.GoodsServices,
 		&economicInfo.Affiliations, &economicInfo.SupportOfAltEcon, &economicInfo.SupportAltComm,
 		&economicInfo.AdditionalText, &economicInfo.CreatedAt, &economicInfo.UpdatedAt)
-
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Economic info not found"})
-		return
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
-		return
+		return nil, nil
 	}
-
-	c.JSON(http.StatusOK, economicInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &economicInfo, nil
 }
 
 func (h *ProfileHandler) CreateEconomicInfo(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.CreateEconomicInfoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -887,10 +1106,10 @@ func (h *ProfileHandler) CreateEconomicInfo(c *gin.Context) {
 	var existingEconomicInfo models.EconomicInfo
 	err := h.db.QueryRow("SELECT user_id FROM economic_info WHERE user_id = $1", userID).Scan(&existingEconomicInfo.UserID)
 	if err == nil {
-		c.JSON(http.StatusConflict, gin.H{"error": "Economic info already exists"})
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Economic info already exists")
 		return
 	} else if err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
@@ -912,7 +1131,7 @@ func (h *ProfileHandler) CreateEconomicInfo(c *gin.Context) {
 		&economicInfo.AdditionalText, &economicInfo.CreatedAt, &economicInfo.UpdatedAt)
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating economic info"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating economic info")
 		return
 	}
 
@@ -922,13 +1141,13 @@ func (h *ProfileHandler) CreateEconomicInfo(c *gin.Context) {
 func (h *ProfileHandler) UpdateEconomicInfo(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req models.UpdateEconomicInfoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -938,54 +1157,54 @@ func (h *ProfileHandler) UpdateEconomicInfo(c *gin.Context) {
 	argCount := 1
 
 	if req.ForCurrentPoliticalStructure != nil {
-		query += "for_current_political_structure = $" + string(rune(argCount+'0')) + ", "
+		query += "for_current_political_structure = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.ForCurrentPoliticalStructure)
 		argCount++
 	}
 	if req.ForCapitalism != nil {
-		query += "for_capitalism = $" + string(rune(argCount+'0')) + ", "
+		query += "for_capitalism = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.ForCapitalism)
 		argCount++
 	}
 	if req.ForLaws != nil {
-		query += "for_laws = $" + string(rune(argCount+'0')) + ", "
+		query += "for_laws = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.ForLaws)
 		argCount++
 	}
 	if req.GoodsServices != nil {
-		query += "goods_services = $" + string(rune(argCount+'0')) + ", "
+		query += "goods_services = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, pq.Array(req.GoodsServices))
 		argCount++
 	}
 	if req.Affiliations != nil {
-		query += "affiliations = $" + string(rune(argCount+'0')) + ", "
+		query += "affiliations = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, pq.Array(req.Affiliations))
 		argCount++
 	}
 	if req.SupportOfAltEcon != nil {
-		query += "support_of_alt_econ = $" + string(rune(argCount+'0')) + ", "
+		query += "support_of_alt_econ = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.SupportOfAltEcon)
 		argCount++
 	}
 	if req.SupportAltComm != nil {
-		query += "support_alt_comm = $" + string(rune(argCount+'0')) + ", "
+		query += "support_alt_comm = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.SupportAltComm)
 		argCount++
 	}
 	if req.AdditionalText != nil {
-		query += "additional_text = $" + string(rune(argCount+'0')) + ", "
+		query += "additional_text = $" + strconv.Itoa(argCount) + ", "
 		args = append(args, *req.AdditionalText)
 		argCount++
 	}
 
 	if len(args) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "No fields to update")
 		return
 	}
 
 	// Remove trailing comma and space
 	query = query[:len(query)-2]
-	query += " WHERE user_id = $" + string(rune(argCount+'0')) + " RETURNING user_id, for_current_political_structure, for_capitalism, for_laws, goods_services, affiliations, support_of_alt_econ, support_alt_comm, additional_text, created_at, updated_at"
+	query += " WHERE user_id = $" + strconv.Itoa(argCount) + " RETURNING user_id, for_current_political_structure, for_capitalism, for_laws, goods_services, affiliations, support_of_alt_econ, support_alt_comm, additional_text, created_at, updated_at"
 	args = append(args, userID)
 
 	var economicInfo models.EconomicInfo
@@ -996,10 +1215,10 @@ func (h *ProfileHandler) UpdateEconomicInfo(c *gin.Context) {
 		&economicInfo.AdditionalText, &economicInfo.CreatedAt, &economicInfo.UpdatedAt)
 
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Economic info not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Economic info not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating economic info"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating economic info")
 		return
 	}
 
@@ -1009,19 +1228,19 @@ func (h *ProfileHandler) UpdateEconomicInfo(c *gin.Context) {
 func (h *ProfileHandler) DeleteEconomicInfo(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
 	result, err := h.db.Exec("DELETE FROM economic_info WHERE user_id = $1", userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error deleting economic info"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error deleting economic info")
 		return
 	}
 
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Economic info not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Economic info not found")
 		return
 	}
 
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"voting-api/apierrors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// demographicDimension is one breakdown included in an admin's PDF export. The query
+// takes the ballot ID and returns (group, ballot_item_id, votes) rows.
+type demographicDimension struct {
+	heading string
+	query   string
+}
+
+var demographicDimensions = []demographicDimension{
+	{
+		heading: "Results by party affiliation",
+		query: `
+			SELECT COALESCE(NULLIF(upa.party_affiliation, ''), 'Not specified') AS demographic_group, v.ballot_item_id, COUNT(*) AS votes
+			FROM votes v
+			LEFT JOIN user_political_affiliations upa ON upa.user_id = v.user_id
+			WHERE v.ballot_id = $1
+			GROUP BY demographic_group, v.ballot_item_id
+		`,
+	},
+	{
+		heading: "Results by religion",
+		query: `
+			SELECT COALESCE(NULLIF(ura.religion, ''), 'Not specified') AS demographic_group, v.ballot_item_id, COUNT(*) AS votes
+			FROM votes v
+			LEFT JOIN user_religious_affiliations ura ON ura.user_id = v.user_id
+			WHERE v.ballot_id = $1
+			GROUP BY demographic_group, v.ballot_item_id
+		`,
+	},
+}
+
+// demographicGroup holds one group's votes per ballot item.
+type demographicGroup struct {
+	name   string
+	total  int
+	counts map[int]int
+}
+
+// ExportBallotResultsPDF renders a ballot's results as a PDF report for election
+// officials. Admins may add ?include_demographic_breakdown=true to append results
+// broken down by voter demographics; groups smaller than kAnonymityThreshold are
+// left out.
+func (h *VoteHandler) ExportBallotResultsPDF(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	includeDemographics := c.Query("include_demographic_breakdown") == "true"
+	if includeDemographics {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+			return
+		}
+
+		var role string
+		err := h.db.QueryRow("SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+		if err == sql.ErrNoRows || (err == nil && role != "admin") {
+			apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Admin access required")
+			return
+		} else if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+	}
+
+	var title, description string
+	var createdAt time.Time
+	var closesAt *time.Time
+	err = h.db.QueryRow(
+		"SELECT title, COALESCE(description, ''), created_at, closes_at FROM ballots WHERE id = $1 AND deleted_at IS NULL",
+		ballotID,
+	).Scan(&title, &description, &createdAt, &closesAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	results, totalVotes, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(tr(title), false)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.MultiCell(0, 9, tr(title), "", "L", false)
+	if description != "" {
+		pdf.SetFont("Helvetica", "", 11)
+		pdf.MultiCell(0, 6, tr(description), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	closes := "Open"
+	if closesAt != nil {
+		closes = closesAt.UTC().Format("January 2, 2006")
+	}
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Voting period: %s - %s", createdAt.UTC().Format("January 2, 2006"), closes), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Total voters: %d", totalVotes), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(70, 8, "Option", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(20, 8, "Votes", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(25, 8, "Percentage", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(65, 8, "", "1", 1, "L", true, 0, "")
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.SetFillColor(60, 110, 180)
+	for _, result := range results {
+		percentage := 0.0
+		if totalVotes > 0 {
+			percentage = float64(result.VoteCount) / float64(totalVotes) * 100
+		}
+
+		x, y := pdf.GetXY()
+		pdf.CellFormat(70, 8, tr(result.Title), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(20, 8, strconv.Itoa(result.VoteCount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 8, fmt.Sprintf("%.1f%%", percentage), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(65, 8, "", "1", 1, "L", false, 0, "")
+		if percentage > 0 {
+			pdf.Rect(x+117, y+2, 61*percentage/100, 4, "F")
+		}
+	}
+
+	if includeDemographics {
+		titles := make(map[int]string, len(results))
+		for _, result := range results {
+			titles[result.ID] = result.Title
+		}
+
+		for _, dimension := range demographicDimensions {
+			groups, err := h.fetchDemographicGroups(ballotID, dimension.query)
+			if err != nil {
+				log.Printf("Error fetching demographic breakdown for ballot %d: %v", ballotID, err)
+				apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+				return
+			}
+
+			pdf.Ln(8)
+			pdf.SetFont("Helvetica", "B", 13)
+			pdf.CellFormat(0, 8, dimension.heading, "", 1, "L", false, 0, "")
+			if len(groups) == 0 {
+				pdf.SetFont("Helvetica", "I", 10)
+				pdf.CellFormat(0, 6, fmt.Sprintf("No group has at least %d voters.", kAnonymityThreshold), "", 1, "L", false, 0, "")
+				continue
+			}
+
+			for _, group := range groups {
+				pdf.SetFont("Helvetica", "B", 11)
+				pdf.CellFormat(0, 7, tr(fmt.Sprintf("%s (%d voters)", group.name, group.total)), "", 1, "L", false, 0, "")
+				pdf.SetFont("Helvetica", "", 10)
+				for _, result := range results {
+					count := group.counts[result.ID]
+					pdf.CellFormat(10, 6, "", "", 0, "L", false, 0, "")
+					pdf.CellFormat(100, 6, tr(titles[result.ID]), "", 0, "L", false, 0, "")
+					pdf.CellFormat(40, 6, fmt.Sprintf("%d (%.1f%%)", count, float64(count)/float64(group.total)*100), "", 1, "R", false, 0, "")
+				}
+			}
+		}
+	}
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "I", 9)
+	pdf.CellFormat(0, 5, "Generated at "+time.Now().UTC().Format(time.RFC3339), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		log.Printf("Error rendering results PDF for ballot %d: %v", ballotID, err)
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating PDF")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ballot_%d_results.pdf"`, ballotID))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// fetchDemographicGroups runs a demographic breakdown query and returns the groups
+// with at least kAnonymityThreshold voters, largest first.
+func (h *VoteHandler) fetchDemographicGroups(ballotID int, query string) ([]demographicGroup, error) {
+	rows, err := h.db.Query(query, ballotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byName := make(map[string]*demographicGroup)
+	for rows.Next() {
+		var name string
+		var itemID, votes int
+		if err := rows.Scan(&name, &itemID, &votes); err != nil {
+			return nil, err
+		}
+		group, ok := byName[name]
+		if !ok {
+			group = &demographicGroup{name: name, counts: make(map[int]int)}
+			byName[name] = group
+		}
+		group.counts[itemID] += votes
+		group.total += votes
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	groups := make([]demographicGroup, 0, len(byName))
+	for _, group := range byName {
+		if group.total >= kAnonymityThreshold {
+			groups = append(groups, *group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].total != groups[j].total {
+			return groups[i].total > groups[j].total
+		}
+		return groups[i].name < groups[j].name
+	})
+
+	return groups, nil
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"voting-api/apierrors"
+	"voting-api/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxSitemapURLs is the sitemap protocol's limit on URLs per file
+	maxSitemapURLs  = 50000
+	sitemapCacheTTL = time.Hour
+	sitemapXMLNS    = "http://www.sitemaps.org/schemas/sitemap/0.9"
+)
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod"`
+	ChangeFreq string `xml:"changefreq"`
+	Priority   string `xml:"priority"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	XMLNS    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// GetSitemapXML lists active ballots for search engines. When there are more than
+// maxSitemapURLs ballots, the bare request returns a sitemap index and each page
+// is served with ?page=N.
+func (h *BallotHandler) GetSitemapXML(c *gin.Context) {
+	pageStr := c.Query("page")
+	page := 1
+	if pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid page")
+			return
+		}
+	}
+
+	cacheKey := "sitemap:" + pageStr
+	if cached, err := h.cache.Get(cacheKey); err == nil {
+		writeSitemap(c, cached)
+		return
+	} else if err != cache.ErrMiss {
+		log.Printf("Error reading cached sitemap: %v", err)
+	}
+
+	var ballotCount int
+	if err := h.db.QueryRow("SELECT COUNT(*) FROM ballots WHERE is_active = true").Scan(&ballotCount); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	var document interface{}
+	if pageStr == "" && ballotCount > maxSitemapURLs {
+		index := sitemapIndex{XMLNS: sitemapXMLNS}
+		pages := (ballotCount + maxSitemapURLs - 1) / maxSitemapURLs
+		for p := 1; p <= pages; p++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapEntry{Loc: fmt.Sprintf("%s/sitemap.xml?page=%d", requestBaseURL(c), p)})
+		}
+		document = index
+	} else {
+		rows, err := h.db.Query(
+			"SELECT id, updated_at FROM ballots WHERE is_active = true ORDER BY id LIMIT $1 OFFSET $2",
+			maxSitemapURLs, (page-1)*maxSitemapURLs,
+		)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		defer rows.Close()
+
+		urlSet := sitemapURLSet{XMLNS: sitemapXMLNS, URLs: []sitemapURL{}}
+		for rows.Next() {
+			var ballotID int
+			var updatedAt time.Time
+			if err := rows.Scan(&ballotID, &updatedAt); err != nil {
+				apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+				return
+			}
+			urlSet.URLs = append(urlSet.URLs, sitemapURL{
+				Loc:        ballotPageURL(ballotID),
+				LastMod:    updatedAt.UTC().Format(time.RFC3339),
+				ChangeFreq: "daily",
+				Priority:   "0.8",
+			})
+		}
+		if err := rows.Err(); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		document = urlSet
+	}
+
+	body, err := xml.Marshal(document)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error generating sitemap")
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+
+	if err := h.cache.Set(cacheKey, body, sitemapCacheTTL); err != nil {
+		log.Printf("Error caching sitemap: %v", err)
+	}
+
+	writeSitemap(c, body)
+}
+
+func writeSitemap(c *gin.Context, body []byte) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.Data(http.StatusOK, "application/xml", body)
+}
+
+// requestBaseURL is the scheme and host the request was made to, honouring
+// X-Forwarded-Proto from a TLS-terminating proxy.
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
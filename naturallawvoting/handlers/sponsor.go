@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"voting-api/apierrors"
+	"voting-api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SponsorBallot records an organization's official endorsement of a ballot.
+// Admins add sponsors on an organization's behalf.
+func (h *BallotHandler) SponsorBallot(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.SponsorBallotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	sponsor := models.BallotSponsor{BallotID: ballotID, OrganizationName: req.OrganizationName, SponsorURL: req.SponsorURL}
+	err = h.db.QueryRow(
+		"INSERT INTO ballot_sponsors (ballot_id, organization_name, sponsor_url) VALUES ($1, $2, NULLIF($3, '')) ON CONFLICT (ballot_id, organization_name) DO NOTHING RETURNING sponsored_at",
+		ballotID, req.OrganizationName, req.SponsorURL,
+	).Scan(&sponsor.SponsoredAt)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusConflict, apierrors.ErrCodeConflict, "Organization already sponsors this ballot")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error adding sponsor")
+		return
+	}
+
+	// The cached ballot detail carries the sponsor count
+	h.invalidateBallot(ballotID)
+
+	c.JSON(http.StatusCreated, sponsor)
+}
+
+// GetBallotSponsors lists the organizations sponsoring a ballot, earliest first.
+func (h *BallotHandler) GetBallotSponsors(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	rows, err := h.db.Query(
+		"SELECT ballot_id, organization_name, COALESCE(sponsor_url, ''), sponsored_at FROM ballot_sponsors WHERE ballot_id = $1 ORDER BY sponsored_at ASC, organization_name ASC",
+		ballotID,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	sponsors := make([]models.BallotSponsor, 0)
+	for rows.Next() {
+		var sponsor models.BallotSponsor
+		if err := rows.Scan(&sponsor.BallotID, &sponsor.OrganizationName, &sponsor.SponsorURL, &sponsor.SponsoredAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		sponsors = append(sponsors, sponsor)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, sponsors)
+}
@@ -2,39 +2,84 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"time"
+	"voting-api/apierrors"
+	"voting-api/cache"
 	"voting-api/database"
 	"voting-api/models"
+	"voting-api/utils"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultLongPollTimeoutSeconds = 20
+	maxLongPollTimeoutSeconds     = 60
+
+	// resultStreamHeartbeat keeps idle result streams from being cut by proxies
+	resultStreamHeartbeat = 30 * time.Second
+
+	// Tallies for the most requested ballots are kept in memory briefly. Votes
+	// evict their ballot's entry, so the TTL only bounds staleness from changes
+	// made outside this handler, such as admin edits or another API instance.
+	resultsCacheCapacity = 500
+	resultsCacheTTL      = 10 * time.Second
+
+	// heatmapWindowDays bounds the activity heatmap to recent votes so long-running
+	// ballots reflect current voter habits.
+	heatmapWindowDays = 30
+
+	// kAnonymityThreshold is the smallest group of voters an aggregate may describe;
+	// smaller groups are withheld so individual choices cannot be inferred.
+	kAnonymityThreshold = 5
 )
 
 type VoteHandler struct {
-	db *database.DB
+	db       *database.DB
+	notifier *resultNotifier
+	updates  *resultStream
+	results  cache.Cacher
 }
 
 func NewVoteHandler(db *database.DB) *VoteHandler {
-	return &VoteHandler{db: db}
+	return &VoteHandler{
+		db:       db,
+		notifier: newResultNotifier(),
+		updates:  newResultStream(),
+		results:  cache.NewLRU(resultsCacheCapacity, resultsCacheTTL),
+	}
 }
 
 func (h *VoteHandler) Vote(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot vote while impersonating")
 		return
 	}
 
 	ballotIDStr := c.Param("ballot_id")
 	ballotID, err := strconv.Atoi(ballotIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ballot ID"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
 		return
 	}
 
 	var req models.VoteRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
 		return
 	}
 
@@ -45,23 +90,23 @@ func (h *VoteHandler) Vote(c *gin.Context) {
 	}
 
 	if ballotItemID == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "option_id or ballot_item_id is required"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "option_id or ballot_item_id is required")
 		return
 	}
 
 	// Check if ballot exists and is active
 	var ballotExists bool
-	err = h.db.QueryRow("SELECT is_active FROM ballots WHERE id = $1", ballotID).Scan(&ballotExists)
+	err = h.db.QueryRow("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&ballotExists)
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Ballot not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	if !ballotExists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Ballot is not active"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is not active")
 		return
 	}
 
@@ -69,22 +114,22 @@ func (h *VoteHandler) Vote(c *gin.Context) {
 	var itemBallotID int
 	err = h.db.QueryRow("SELECT ballot_id FROM ballot_items WHERE id = $1", ballotItemID).Scan(&itemBallotID)
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Ballot item not found"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot item not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	if itemBallotID != ballotID {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Ballot item does not belong to this ballot"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot item does not belong to this ballot")
 		return
 	}
 
 	// Start transaction
 	tx, err := h.db.Begin()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer tx.Rollback()
@@ -93,157 +138,2144 @@ func (h *VoteHandler) Vote(c *gin.Context) {
 	var existingVoteID int
 	var existingBallotItemID int
 	err = tx.QueryRow("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2", userID, ballotID).Scan(&existingVoteID, &existingBallotItemID)
-	
+
 	if err == nil {
 		// User has already voted, update their vote
 		// First decrease vote count for previous choice
-		_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count - 1 WHERE id = $1", existingBallotItemID)
+		_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1", existingBallotItemID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating vote count"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote count")
 			return
 		}
 
 		// Update the vote record
-		_, err = tx.Exec("UPDATE votes SET ballot_item_id = $1 WHERE id = $2", ballotItemID, existingVoteID)
+		_, err = tx.Exec("UPDATE votes SET previous_ballot_item_id = ballot_item_id, ballot_item_id = $1 WHERE id = $2", ballotItemID, existingVoteID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating vote"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote")
 			return
 		}
 	} else if err == sql.ErrNoRows {
 		// User hasn't voted yet, create new vote
 		_, err = tx.Exec("INSERT INTO votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)", userID, ballotID, ballotItemID)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error creating vote"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating vote")
 			return
 		}
 	} else {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
 	// Increase vote count for chosen item
-	_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count + 1 WHERE id = $1", ballotItemID)
+	_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1", ballotItemID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error updating vote count"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote count")
 		return
 	}
 
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error committing transaction"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
 		return
 	}
 
+	h.invalidateResults(ballotID)
+	h.notifier.Publish(ballotID)
+	h.publishResultUpdate(ballotID, ballotItemID)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Vote recorded successfully"})
 }
 
-func (h *VoteHandler) GetUserVote(c *gin.Context) {
+// publishResultUpdate sends the item's new count to clients streaming the ballot's
+// results. The vote is already committed, so a failure here is only logged.
+func (h *VoteHandler) publishResultUpdate(ballotID, itemID int) {
+	if !h.updates.HasSubscribers(ballotID) {
+		return
+	}
+
+	update := models.ResultUpdate{BallotID: ballotID, ItemID: itemID}
+	err := h.db.QueryRow(
+		"SELECT COALESCE((SELECT vote_count FROM ballot_item_vote_counts WHERE ballot_item_id = $1), 0), (SELECT COUNT(*) FROM votes WHERE ballot_id = $2)",
+		itemID, ballotID,
+	).Scan(&update.NewVoteCount, &update.TotalVotes)
+	if err != nil {
+		log.Printf("Error loading result update for ballot %d: %v", ballotID, err)
+		return
+	}
+
+	h.updates.Publish(update)
+}
+
+// MultiVote records the set of items a user selects on a ballot that accepts several
+// selections, replacing any selections they made before.
+func (h *VoteHandler) MultiVote(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
 		return
 	}
 
-	ballotIDStr := c.Param("ballot_id")
-	ballotID, err := strconv.Atoi(ballotIDStr)
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot vote while impersonating")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ballot ID"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
 		return
 	}
 
-	var vote models.Vote
-	err = h.db.QueryRow(
-		"SELECT id, user_id, ballot_id, ballot_item_id, created_at FROM votes WHERE user_id = $1 AND ballot_id = $2",
-		userID, ballotID,
-	).Scan(&vote.ID, &vote.UserID, &vote.BallotID, &vote.BallotItemID, &vote.CreatedAt)
+	var req models.MultiVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
 
+	var isActive bool
+	var ballotType string
+	err = h.db.QueryRow("SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&isActive, &ballotType)
 	if err == sql.ErrNoRows {
-		c.JSON(http.StatusNotFound, gin.H{"error": "No vote found for this ballot"})
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
 		return
 	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 
-	// Return response with both option_id and ballot_item_id for compatibility
-	c.JSON(http.StatusOK, gin.H{
-		"id":              vote.ID,
-		"user_id":         vote.UserID,
-		"ballot_id":       vote.BallotID,
-		"ballot_item_id":  vote.BallotItemID,
-		"option_id":       vote.BallotItemID, // Frontend expects option_id
-		"created_at":      vote.CreatedAt,
-	})
-}
+	if !isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is not active")
+		return
+	}
+	if !acceptsMultipleSelections(ballotType) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "This ballot does not accept multiple selections")
+		return
+	}
 
-func (h *VoteHandler) GetBallotResults(c *gin.Context) {
-	ballotIDStr := c.Param("id")
-	ballotID, err := strconv.Atoi(ballotIDStr)
+	rows, err := h.db.Query("SELECT id FROM ballot_items WHERE ballot_id = $1", ballotID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ballot ID"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
+	defer rows.Close()
 
-	// Check if ballot exists
-	var ballotExists bool
-	err = h.db.QueryRow("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1)", ballotID).Scan(&ballotExists)
+	ballotItems := make(map[int]bool)
+	for rows.Next() {
+		var itemID int
+		if err := rows.Scan(&itemID); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		ballotItems[itemID] = true
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	selected := make([]int, 0, len(req.BallotItemIDs))
+	seen := make(map[int]bool, len(req.BallotItemIDs))
+	for _, itemID := range req.BallotItemIDs {
+		if !ballotItems[itemID] {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot item does not belong to this ballot")
+			return
+		}
+		if !seen[itemID] {
+			seen[itemID] = true
+			selected = append(selected, itemID)
+		}
+	}
+
+	tx, err := h.db.Begin()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
+	defer tx.Rollback()
 
-	if !ballotExists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Ballot not found"})
+	// Clear any previous selections so the request replaces them
+	_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id IN (SELECT ballot_item_id FROM multi_votes WHERE user_id = $1 AND ballot_id = $2)", userID, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote count")
 		return
 	}
 
-	// Get ballot items with vote counts
-	rows, err := h.db.Query(`
-		SELECT id, ballot_id, title, description, vote_count
-		FROM ballot_items 
-		WHERE ballot_id = $1 
-		ORDER BY vote_count DESC, id ASC
-	`, ballotID)
+	_, err = tx.Exec("DELETE FROM multi_votes WHERE user_id = $1 AND ballot_id = $2", userID, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote")
+		return
+	}
+
+	for _, itemID := range selected {
+		_, err = tx.Exec("INSERT INTO multi_votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)", userID, ballotID, itemID)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating vote")
+			return
+		}
+
+		_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1", itemID)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote count")
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	h.invalidateResults(ballotID)
+	h.notifier.Publish(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote recorded successfully", "ballot_item_ids": selected})
+}
+
+// acceptsMultipleSelections reports whether votes on the ballot type are stored in
+// multi_votes.
+func acceptsMultipleSelections(ballotType string) bool {
+	return ballotType == models.BallotTypeApproval || ballotType == models.BallotTypeMultiSelect
+}
+
+// ScoreVote records a user's 0-10 score for every item on a score ballot, replacing
+// any scores they gave before. Partial submissions are rejected.
+func (h *VoteHandler) ScoreVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot vote while impersonating")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error fetching results"})
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req []models.ScoreVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	var isActive bool
+	var ballotType string
+	err = h.db.QueryRow("SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&isActive, &ballotType)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is not active")
+		return
+	}
+	if ballotType != models.BallotTypeScore {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "This ballot does not accept scores")
+		return
+	}
+
+	rows, err := h.db.Query("SELECT id FROM ballot_items WHERE ballot_id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
 		return
 	}
 	defer rows.Close()
 
-	type ResultItem struct {
-		ID          int    `json:"id"`
-		OptionID    int    `json:"option_id"` // Frontend expects option_id
-		BallotID    int    `json:"ballot_id"`
-		Title       string `json:"title"`
-		OptionTitle string `json:"option_title"` // Alias for title
-		Description string `json:"description"`
-		VoteCount   int    `json:"vote_count"`
+	ballotItems := make(map[int]bool)
+	for rows.Next() {
+		var itemID int
+		if err := rows.Scan(&itemID); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		ballotItems[itemID] = true
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
 	}
 
-	results := make([]ResultItem, 0)
-	totalVotes := 0
+	scored := make(map[int]bool, len(req))
+	for _, entry := range req {
+		if !ballotItems[entry.BallotItemID] {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot item does not belong to this ballot")
+			return
+		}
+		if scored[entry.BallotItemID] {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Each ballot item can only be scored once")
+			return
+		}
+		scored[entry.BallotItemID] = true
+	}
+	if len(scored) != len(ballotItems) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Every ballot item must be scored")
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM score_votes WHERE user_id = $1 AND ballot_id = $2", userID, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote")
+		return
+	}
+
+	for _, entry := range req {
+		_, err = tx.Exec("INSERT INTO score_votes (user_id, ballot_id, ballot_item_id, score) VALUES ($1, $2, $3, $4)", userID, ballotID, entry.BallotItemID, *entry.Score)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating vote")
+			return
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	h.invalidateResults(ballotID)
+	h.notifier.Publish(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote recorded successfully"})
+}
+
+// RankedVote records a user's preference order on a ranked ballot, replacing any
+// ranking they submitted before.
+func (h *VoteHandler) RankedVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot vote while impersonating")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var req models.RankedVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeValidation, err.Error())
+		return
+	}
+
+	var isActive bool
+	var ballotType string
+	err = h.db.QueryRow("SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&isActive, &ballotType)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is not active")
+		return
+	}
+	if ballotType != models.BallotTypeRanked {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "This ballot does not accept rankings")
+		return
+	}
+
+	rows, err := h.db.Query("SELECT id FROM ballot_items WHERE ballot_id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	ballotItems := make(map[int]bool)
 	for rows.Next() {
-		var item models.BallotItem
-		err := rows.Scan(&item.ID, &item.BallotID, &item.Title, &item.Description, &item.VoteCount)
+		var itemID int
+		if err := rows.Scan(&itemID); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		ballotItems[itemID] = true
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	ranked := make(map[int]bool, len(req.Rankings))
+	for _, choice := range req.Rankings {
+		if !ballotItems[choice.BallotItemID] {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot item does not belong to this ballot")
+			return
+		}
+		if ranked[choice.BallotItemID] {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Each ballot item can only be ranked once")
+			return
+		}
+		ranked[choice.BallotItemID] = true
+	}
+
+	// Ranks must be exactly 1..n, so after sorting each sits at its own position
+	rankings := append([]models.RankedChoice(nil), req.Rankings...)
+	sort.Slice(rankings, func(i, j int) bool { return rankings[i].Rank < rankings[j].Rank })
+	for i, choice := range rankings {
+		if choice.Rank != i+1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ranks must run from 1 without gaps or repeats")
+			return
+		}
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM ranked_votes WHERE user_id = $1 AND ballot_id = $2", userID, ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote")
+		return
+	}
+
+	for _, choice := range rankings {
+		_, err = tx.Exec("INSERT INTO ranked_votes (user_id, ballot_id, ballot_item_id, rank) VALUES ($1, $2, $3, $4)", userID, ballotID, choice.BallotItemID, choice.Rank)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error scanning result"})
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error creating vote")
 			return
 		}
-		results = append(results, ResultItem{
-			ID:          item.ID,
-			OptionID:    item.ID,
-			BallotID:    item.BallotID,
-			Title:       item.Title,
-			OptionTitle: item.Title,
-			Description: item.Description,
-			VoteCount:   item.VoteCount,
-		})
-		totalVotes += item.VoteCount
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"ballot_id":   ballotID,
-		"results":     results,
-		"total_votes": totalVotes,
-	})
-}
\ No newline at end of file
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	h.invalidateResults(ballotID)
+	h.notifier.Publish(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote recorded successfully", "rankings": rankings})
+}
+
+// RetractVote removes the user's vote from a ballot, if the ballot allows it.
+func (h *VoteHandler) RetractVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, impersonating := c.Get("impersonated_by"); impersonating {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Cannot vote while impersonating")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var isActive, allowRetraction bool
+	err = h.db.QueryRow("SELECT is_active, COALESCE(allow_vote_retraction, true) FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&isActive, &allowRetraction)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !isActive {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot is not active")
+		return
+	}
+	if !allowRetraction {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "This ballot does not allow vote retraction")
+		return
+	}
+
+	tx, err := h.db.Begin()
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer tx.Rollback()
+
+	var voteID, ballotItemID int
+	err = tx.QueryRow("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2", userID, ballotID).Scan(&voteID, &ballotItemID)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "No vote found for this ballot")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	_, err = tx.Exec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1", ballotItemID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error updating vote count")
+		return
+	}
+
+	_, err = tx.Exec("DELETE FROM votes WHERE id = $1", voteID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error retracting vote")
+		return
+	}
+
+	if err = tx.Commit(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error committing transaction")
+		return
+	}
+
+	h.invalidateResults(ballotID)
+	h.notifier.Publish(ballotID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Vote retracted successfully"})
+}
+
+func (h *VoteHandler) GetUserVote(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotIDStr := c.Param("ballot_id")
+	ballotID, err := strconv.Atoi(ballotIDStr)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var vote models.Vote
+	err = h.db.QueryRow(
+		"SELECT id, user_id, ballot_id, ballot_item_id, created_at FROM votes WHERE user_id = $1 AND ballot_id = $2",
+		userID, ballotID,
+	).Scan(&vote.ID, &vote.UserID, &vote.BallotID, &vote.BallotItemID, &vote.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "No vote found for this ballot")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	// Return response with both option_id and ballot_item_id for compatibility
+	c.JSON(http.StatusOK, gin.H{
+		"id":             vote.ID,
+		"user_id":        vote.UserID,
+		"ballot_id":      vote.BallotID,
+		"ballot_item_id": vote.BallotItemID,
+		"option_id":      vote.BallotItemID, // Frontend expects option_id
+		"created_at":     vote.CreatedAt,
+	})
+}
+
+// GetUserVoteHistory lists every vote the current user has cast, newest first,
+// paginated with limit and offset.
+func (h *VoteHandler) GetUserVoteHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, offset, ok := parseLimitOffset(c, defaultBallotPageLimit, maxBallotPageLimit)
+	if !ok {
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT v.id, v.ballot_id, b.title, v.ballot_item_id, bi.title, v.created_at
+		FROM votes v
+		JOIN ballots b ON b.id = v.ballot_id
+		JOIN ballot_items bi ON bi.id = v.ballot_item_id
+		WHERE v.user_id = $1
+		ORDER BY v.created_at DESC, v.id DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	history := []models.VoteHistoryEntry{}
+	for rows.Next() {
+		var entry models.VoteHistoryEntry
+		if err := rows.Scan(&entry.VoteID, &entry.BallotID, &entry.BallotTitle, &entry.BallotItemID, &entry.ChosenOptionTitle, &entry.VotedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// CountUserVotes returns how many votes the current user has cast, for dashboard
+// badges that don't need the full history.
+func (h *VoteHandler) CountUserVotes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	var count int
+	if err := h.db.QueryRow("SELECT COUNT(*) FROM votes WHERE user_id = $1", userID).Scan(&count); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// GetItemVotes lets a ballot's creators audit which users voted for one of its
+// items, oldest vote first. Single-choice and multi-select votes are both listed;
+// votes detached from deleted accounts are not.
+func (h *VoteHandler) GetItemVotes(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+		return
+	}
+
+	ballotID, err := strconv.Atoi(c.Param("ballot_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	itemID, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid item ID")
+		return
+	}
+
+	limit, offset, ok := parseLimitOffset(c, defaultBallotPageLimit, maxBallotPageLimit)
+	if !ok {
+		return
+	}
+
+	var creatorID int
+	var isCoCreator, itemExists bool
+	err = h.db.QueryRow(`
+		SELECT creator_id,
+		       EXISTS(SELECT 1 FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2),
+		       EXISTS(SELECT 1 FROM ballot_items WHERE id = $3 AND ballot_id = $1)
+		FROM ballots WHERE id = $1 AND deleted_at IS NULL
+	`, ballotID, userID, itemID).Scan(&creatorID, &isCoCreator, &itemExists)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if creatorID != userID.(int) && !isCoCreator {
+		apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Only the ballot creator can view item votes")
+		return
+	}
+
+	if !itemExists {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot item not found")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT user_id, created_at FROM (
+			SELECT user_id, created_at FROM votes WHERE ballot_item_id = $1 AND user_id IS NOT NULL
+			UNION ALL
+			SELECT user_id, created_at FROM multi_votes WHERE ballot_item_id = $1
+		) item_votes
+		ORDER BY created_at ASC, user_id ASC
+		LIMIT $2 OFFSET $3
+	`, itemID, limit, offset)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	result := models.ItemVotes{ItemID: itemID, Voters: []models.ItemVoter{}}
+	for rows.Next() {
+		var voter models.ItemVoter
+		if err := rows.Scan(&voter.UserID, &voter.VotedAt); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		result.Voters = append(result.Voters, voter)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetItemCorrelation counts how many voters selected each pair of items together on
+// a multi-select ballot. Pairs chosen by fewer than kAnonymityThreshold voters are
+// left out.
+func (h *VoteHandler) GetItemCorrelation(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var ballotType string
+	err = h.db.QueryRow("SELECT COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&ballotType)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if !acceptsMultipleSelections(ballotType) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Item correlation is only available for multi-select ballots")
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT a.ballot_item_id, b.ballot_item_id, COUNT(*)
+		FROM multi_votes a
+		JOIN multi_votes b ON a.user_id = b.user_id AND a.ballot_id = b.ballot_id
+		WHERE a.ballot_id = $1 AND a.ballot_item_id < b.ballot_item_id
+		GROUP BY a.ballot_item_id, b.ballot_item_id
+		HAVING COUNT(*) >= $2
+		ORDER BY COUNT(*) DESC
+	`, ballotID, kAnonymityThreshold)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	pairs := []models.ItemCorrelation{}
+	for rows.Next() {
+		var pair models.ItemCorrelation
+		if err := rows.Scan(&pair.ItemAID, &pair.ItemBID, &pair.Count); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id": ballotID,
+		"pairs":     pairs,
+	})
+}
+
+// GetActivityHeatmap buckets a ballot's votes from the last heatmapWindowDays days
+// into a 7x24 matrix of day of week (Sunday first) by hour of day.
+func (h *VoteHandler) GetActivityHeatmap(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	rows, err := h.db.Query(`
+		SELECT EXTRACT(DOW FROM created_at) AS day_of_week, EXTRACT(HOUR FROM created_at) AS hour_of_day, COUNT(*) AS votes
+		FROM votes
+		WHERE ballot_id = $1 AND created_at > NOW() - make_interval(days => $2)
+		GROUP BY day_of_week, hour_of_day
+	`, ballotID, heatmapWindowDays)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	heatmap := make([][]int, 7)
+	for day := range heatmap {
+		heatmap[day] = make([]int, 24)
+	}
+	for rows.Next() {
+		var day, hour float64
+		var votes int
+		if err := rows.Scan(&day, &hour, &votes); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		heatmap[int(day)][int(hour)] = votes
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	dayLabels := make([]string, 7)
+	for day := range dayLabels {
+		dayLabels[day] = time.Weekday(day).String()
+	}
+	hourLabels := make([]string, 24)
+	for hour := range hourLabels {
+		hourLabels[hour] = fmt.Sprintf("%02d:00", hour)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":   ballotID,
+		"heatmap":     heatmap,
+		"day_labels":  dayLabels,
+		"hour_labels": hourLabels,
+	})
+}
+
+// GetVotersMap reports which states a ballot's voters live in, based on their
+// profile addresses. States with fewer than kAnonymityThreshold voters are withheld
+// but still count toward the total, and voters without an address are reported as
+// unknown. ?superstate= limits the breakdown to that superstate's states.
+func (h *VoteHandler) GetVotersMap(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	superstate := c.Query("superstate")
+	var inScope map[string]bool
+	if superstate != "" {
+		states, ok := utils.StatesInSuperstate(superstate)
+		if !ok {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Unknown superstate")
+			return
+		}
+		inScope = make(map[string]bool, len(states))
+		for _, state := range states {
+			inScope[state] = true
+		}
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	stateCounts, unknown, err := h.voterStateCounts(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	states := []models.StateVoterCount{}
+	totalVoters := unknown
+	for state, count := range stateCounts {
+		if inScope != nil && !inScope[state] {
+			continue
+		}
+		totalVoters += count
+		if count >= kAnonymityThreshold {
+			states = append(states, models.StateVoterCount{State: state, VoterCount: count})
+		}
+	}
+
+	for i := range states {
+		states[i].Percentage = math.Round(float64(states[i].VoterCount)/float64(totalVoters)*10000) / 100
+	}
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].VoterCount != states[j].VoterCount {
+			return states[i].VoterCount > states[j].VoterCount
+		}
+		return states[i].State < states[j].State
+	})
+
+	response := gin.H{
+		"ballot_id":    ballotID,
+		"states":       states,
+		"unknown":      unknown,
+		"total_voters": totalVoters,
+	}
+	if superstate != "" {
+		response["superstate"] = superstate
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// voterStateCounts counts a ballot's distinct voters by the state in their profile
+// address. Voters without a state are returned as unknown.
+func (h *VoteHandler) voterStateCounts(ballotID int) (map[string]int, int, error) {
+	rows, err := h.db.Query(`
+		SELECT LOWER(NULLIF(ua.state, '')) AS state, COUNT(DISTINCT v.user_id) AS voter_count
+		FROM votes v
+		LEFT JOIN user_addresses ua ON ua.user_id = v.user_id
+		WHERE v.ballot_id = $1
+		GROUP BY LOWER(NULLIF(ua.state, ''))
+	`, ballotID)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	unknown := 0
+	for rows.Next() {
+		var state sql.NullString
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, 0, err
+		}
+		if !state.Valid {
+			unknown += count
+			continue
+		}
+		counts[state.String] = count
+	}
+	return counts, unknown, rows.Err()
+}
+
+// GetParticipantsCount reports how many distinct users have voted on a ballot and
+// nothing else, so it is safe to serve without authentication. Votes detached from
+// deleted accounts have no user and are not counted.
+func (h *VoteHandler) GetParticipantsCount(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	var participantCount int
+	err = h.db.QueryRow("SELECT COUNT(DISTINCT user_id) FROM votes WHERE ballot_id = $1", ballotID).Scan(&participantCount)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ballot_id": ballotID, "participant_count": participantCount})
+}
+
+// superstateResultsSQL counts recorded votes per item across every ballot in a
+// superstate. Items nobody has voted for are still listed with a zero total.
+const superstateResultsSQL = `
+	WITH superstate_items AS (
+		SELECT bi.id AS item_id, bi.ballot_id, bi.title
+		FROM ballot_items bi
+		JOIN ballots b ON b.id = bi.ballot_id
+		WHERE b.superstate = $1 AND b.deleted_at IS NULL
+	),
+	item_votes AS (
+		SELECT v.ballot_item_id, COUNT(*) AS total_votes
+		FROM votes v
+		JOIN superstate_items si ON si.item_id = v.ballot_item_id
+		GROUP BY v.ballot_item_id
+	)
+	SELECT si.ballot_id, si.item_id, si.title, COALESCE(iv.total_votes, 0) AS total_votes
+	FROM superstate_items si
+	LEFT JOIN item_votes iv ON iv.ballot_item_id = si.item_id
+	ORDER BY total_votes DESC, si.item_id ASC
+`
+
+// GetSuperstateResults shows which options are winning across all the ballots of
+// a superstate, ranked by total votes.
+func (h *VoteHandler) GetSuperstateResults(c *gin.Context) {
+	superstate := c.Param("superstate")
+	if _, ok := utils.StatesInSuperstate(superstate); !ok {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Unknown superstate")
+		return
+	}
+
+	rows, err := h.db.Query(superstateResultsSQL, superstate)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	results := models.SuperstateResults{Superstate: superstate, Items: []models.SuperstateResultItem{}}
+	for rows.Next() {
+		var item models.SuperstateResultItem
+		if err := rows.Scan(&item.BallotID, &item.ItemID, &item.Title, &item.TotalVotes); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+		results.Items = append(results.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetVoterStats reports how many people voted on a ballot and which superstates
+// they live in. Superstates with fewer than kAnonymityThreshold voters are
+// withheld, and no user IDs are exposed.
+func (h *VoteHandler) GetVoterStats(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	stateCounts, unknown, err := h.voterStateCounts(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	stats := models.VoterStats{BallotID: ballotID, BySuperstate: map[string]int{}, UniqueVoters: unknown, Unknown: unknown}
+	superstateCounts := make(map[string]int)
+	for state, count := range stateCounts {
+		stats.UniqueVoters += count
+		if superstate, ok := utils.SuperstateOfState(state); ok {
+			superstateCounts[superstate] += count
+		} else {
+			stats.Unknown += count
+		}
+	}
+	for superstate, count := range superstateCounts {
+		if count >= kAnonymityThreshold {
+			stats.BySuperstate[superstate] = count
+		}
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+type resultItem struct {
+	ID          int    `json:"id"`
+	OptionID    int    `json:"option_id"` // Frontend expects option_id
+	BallotID    int    `json:"ballot_id"`
+	Title       string `json:"title"`
+	OptionTitle string `json:"option_title"` // Alias for title
+	Description string `json:"description"`
+	VoteCount   int    `json:"vote_count"`
+}
+
+// fetchBallotResults loads the ballot items ordered by vote count along with the total vote count.
+// Counts come from the ballot_item_vote_counts view rather than the deprecated
+// ballot_items.vote_count column.
+func (h *VoteHandler) fetchBallotResults(ballotID int) ([]resultItem, int, error) {
+	rows, err := h.db.Query(`
+		SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0) AS vote_count
+		FROM ballot_items bi
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		WHERE bi.ballot_id = $1
+		ORDER BY vote_count DESC, bi.id ASC
+	`, ballotID)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := make([]resultItem, 0)
+	totalVotes := 0
+	for rows.Next() {
+		var item models.BallotItem
+		if err := rows.Scan(&item.ID, &item.BallotID, &item.Title, &item.Description, &item.VoteCount); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, resultItem{
+			ID:          item.ID,
+			OptionID:    item.ID,
+			BallotID:    item.BallotID,
+			Title:       item.Title,
+			OptionTitle: item.Title,
+			Description: item.Description,
+			VoteCount:   item.VoteCount,
+		})
+		totalVotes += item.VoteCount
+	}
+
+	return results, totalVotes, rows.Err()
+}
+
+// cachedResults is the cached form of a fetchBallotResults tally.
+type cachedResults struct {
+	Results    []resultItem `json:"results"`
+	TotalVotes int          `json:"total_votes"`
+}
+
+func resultsCacheKey(ballotID int) string {
+	return "results:" + strconv.Itoa(ballotID)
+}
+
+// cachedBallotResults is fetchBallotResults served from the results cache when the
+// ballot was tallied recently.
+func (h *VoteHandler) cachedBallotResults(ballotID int) ([]resultItem, int, error) {
+	var cached cachedResults
+	if encoded, err := h.results.Get(resultsCacheKey(ballotID)); err == nil {
+		if err := json.Unmarshal(encoded, &cached); err == nil {
+			return cached.Results, cached.TotalVotes, nil
+		}
+	}
+
+	results, totalVotes, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if encoded, err := json.Marshal(cachedResults{Results: results, TotalVotes: totalVotes}); err == nil {
+		h.results.Set(resultsCacheKey(ballotID), encoded, resultsCacheTTL)
+	}
+	return results, totalVotes, nil
+}
+
+// invalidateResults drops the cached tally for a ballot once a vote on it commits.
+func (h *VoteHandler) invalidateResults(ballotID int) {
+	h.results.Delete(resultsCacheKey(ballotID))
+}
+
+func (h *VoteHandler) GetBallotResults(c *gin.Context) {
+	ballotIDStr := c.Param("id")
+	ballotID, err := strconv.Atoi(ballotIDStr)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	// Check if ballot exists; the type decides how results are tallied
+	var ballotType string
+	var minimumQuorum *int
+	err = h.db.QueryRow("SELECT COALESCE(ballot_type, 'plurality'), minimum_quorum FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&ballotType, &minimumQuorum)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if c.Query("long_poll") == "true" {
+		h.longPollBallotResults(c, ballotID)
+		return
+	}
+
+	if c.Query("replay") == "true" {
+		h.replayBallotResults(c, ballotID)
+		return
+	}
+
+	if c.Query("simulate_irv") == "true" {
+		h.irvBallotResults(c, ballotID, ballotType)
+		return
+	}
+
+	if scoring := c.Query("scoring"); scoring != "" {
+		if scoring != "borda" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "scoring must be borda")
+			return
+		}
+		h.bordaBallotResults(c, ballotID, ballotType)
+		return
+	}
+
+	if ballotType == models.BallotTypeApproval {
+		h.approvalBallotResults(c, ballotID)
+		return
+	}
+
+	if ballotType == models.BallotTypeScore {
+		h.scoreBallotResults(c, ballotID)
+		return
+	}
+
+	mode := c.DefaultQuery("mode", "cached")
+	if mode != "cached" && mode != "live" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "mode must be live or cached")
+		return
+	}
+
+	includeParticipation := c.Query("include_participation_rate") == "true"
+	asPercentageOfEligible := c.Query("as_percentage_of_eligible") == "true"
+	scope := c.Query("scope")
+	if includeParticipation && scope != "" && scope != "state" && scope != "superstate" {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "scope must be state or superstate")
+		return
+	}
+
+	normalize := c.Query("normalize") == "true"
+	threshold := 0
+	if thresholdStr := c.Query("threshold"); thresholdStr != "" {
+		threshold, err = strconv.Atoi(thresholdStr)
+		if err != nil || threshold < 1 || threshold > 100 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "threshold must be an integer from 1 to 100")
+			return
+		}
+	}
+
+	var decayFactor *float64
+	if factorStr := c.Query("time_decay_factor"); factorStr != "" {
+		factor, err := strconv.ParseFloat(factorStr, 64)
+		if err != nil || factor < 0 || factor > 1 {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "time_decay_factor must be a number from 0 to 1")
+			return
+		}
+		decayFactor = &factor
+	}
+
+	// Live mode recounts from the votes table instead of trusting vote_count
+	fetchResults := h.cachedBallotResults
+	if mode == "live" {
+		fetchResults = h.fetchLiveBallotResults
+	}
+
+	start := time.Now()
+	results, totalVotes, err := fetchResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	response := gin.H{
+		"ballot_id":                  ballotID,
+		"results":                    results,
+		"total_votes":                totalVotes,
+		"margin_of_victory":          marginOfVictory(results, totalVotes),
+		"declared_at":                time.Now().UTC(),
+		"confidence_level":           utils.ConfidenceLevel(totalVotes),
+		"margin_of_error_95_percent": utils.MarginOfError95(totalVotes),
+	}
+	declareWinner(response, results, totalVotes, minimumQuorum)
+	addBinaryBreakdown(response, results, totalVotes)
+	if mode == "live" {
+		response["computation_time_ms"] = time.Since(start).Milliseconds()
+	}
+
+	if includeParticipation && !h.addParticipationRate(c, response, ballotID, scope, totalVotes) {
+		return
+	}
+
+	if asPercentageOfEligible && !h.addEligibleTurnout(c, response, ballotID, totalVotes) {
+		return
+	}
+
+	if normalize || threshold > 0 || decayFactor != nil {
+		annotated := make([]annotatedResultItem, len(results))
+		for i, item := range results {
+			annotated[i].resultItem = item
+		}
+
+		if normalize {
+			// Every votes row is a distinct voter, including votes detached from deleted
+			// accounts, so this counts voters even where vote_count has drifted
+			var effectiveN int
+			err := h.db.QueryRow("SELECT COUNT(*) FROM votes WHERE ballot_id = $1", ballotID).Scan(&effectiveN)
+			if err != nil {
+				apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+				return
+			}
+			normalizeResults(annotated, totalVotes)
+			response["effective_n"] = effectiveN
+		}
+
+		if threshold > 0 {
+			response["threshold_applied"] = threshold
+			response["options_above_threshold"] = applyThreshold(annotated, totalVotes, threshold)
+		}
+
+		if decayFactor != nil {
+			votes, err := h.fetchTimedVotes(ballotID)
+			if err != nil {
+				apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+				return
+			}
+			applyTimeDecay(annotated, votes, *decayFactor)
+			response["time_decay_factor"] = *decayFactor
+		}
+
+		response["results"] = annotated
+	}
+
+	if c.Query("analyze_write_ins") == "true" {
+		sentiment, err := h.writeInSentiment(ballotID)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+			return
+		}
+		response["write_in_sentiment"] = sentiment
+	}
+
+	c.Header("X-Result-Mode", mode)
+	c.JSON(http.StatusOK, response)
+}
+
+// annotatedResultItem is a result item with the optional fields GetBallotResults
+// adds on request. Each annotation is a pointer so it is left out of the JSON
+// entirely unless it was asked for.
+type annotatedResultItem struct {
+	resultItem
+	*normalization
+	*thresholdCheck
+	*timeDecay
+}
+
+type normalization struct {
+	NormalizedScore *float64 `json:"normalized_score"`
+}
+
+type thresholdCheck struct {
+	PassedThreshold bool `json:"passed_threshold"`
+}
+
+type timeDecay struct {
+	WeightedScore float64 `json:"weighted_score"`
+}
+
+// normalizeResults adds each item's share of the total votes, as a proportion from
+// 0 to 1 rounded to four decimal places, so results can be compared across ballots
+// of different sizes. With no votes there is no share and the score is null.
+func normalizeResults(results []annotatedResultItem, totalVotes int) {
+	for i := range results {
+		results[i].normalization = &normalization{}
+		if totalVotes > 0 {
+			score := math.Round(float64(results[i].VoteCount)/float64(totalVotes)*10000) / 10000
+			results[i].NormalizedScore = &score
+		}
+	}
+}
+
+// applyTimeDecay adds each item's time-decayed score alongside its raw vote count.
+func applyTimeDecay(results []annotatedResultItem, votes []utils.TimedVote, factor float64) {
+	itemIDs := make([]int, len(results))
+	for i := range results {
+		itemIDs[i] = results[i].ID
+	}
+
+	scores := utils.TimeDecayScores(itemIDs, votes, factor, time.Now())
+	for i := range results {
+		results[i].timeDecay = &timeDecay{WeightedScore: scores[results[i].ID]}
+	}
+}
+
+// applyThreshold marks the items that received at least threshold percent of the
+// votes and returns how many did. Nothing passes when no votes have been cast.
+func applyThreshold(results []annotatedResultItem, totalVotes, threshold int) int {
+	passed := 0
+	for i := range results {
+		results[i].thresholdCheck = &thresholdCheck{}
+		if totalVotes > 0 && float64(results[i].VoteCount)/float64(totalVotes)*100 >= float64(threshold) {
+			results[i].PassedThreshold = true
+			passed++
+		}
+	}
+	return passed
+}
+
+// writeInSentiment summarises the sentiment of a ballot's write-in item titles.
+func (h *VoteHandler) writeInSentiment(ballotID int) (models.WriteInSentiment, error) {
+	rows, err := h.db.Query("SELECT title FROM ballot_items WHERE ballot_id = $1 AND is_write_in = true", ballotID)
+	if err != nil {
+		return models.WriteInSentiment{}, err
+	}
+	defer rows.Close()
+
+	var titles []string
+	for rows.Next() {
+		var title string
+		if err := rows.Scan(&title); err != nil {
+			return models.WriteInSentiment{}, err
+		}
+		titles = append(titles, title)
+	}
+	if err := rows.Err(); err != nil {
+		return models.WriteInSentiment{}, err
+	}
+
+	summary := utils.AnalyzeSentiment(titles)
+	return models.WriteInSentiment{
+		PositiveCount:   summary.PositiveCount,
+		NegativeCount:   summary.NegativeCount,
+		NeutralCount:    summary.NeutralCount,
+		MostCommonWords: summary.MostCommonWords,
+	}, nil
+}
+
+// addParticipationRate adds the ballot's voters as a percentage of registered users
+// to response. With a state or superstate scope only users whose address is in the
+// ballot's state or superstate count as eligible. It writes the error response and
+// returns false when the rate cannot be computed.
+func (h *VoteHandler) addParticipationRate(c *gin.Context, response gin.H, ballotID int, scope string, voterCount int) bool {
+	var eligibleVoters int
+	var scopeValue string
+	switch scope {
+	case "":
+		scope = "national"
+		err := h.db.QueryRow("SELECT COUNT(*) FROM users WHERE deleted_at IS NULL").Scan(&eligibleVoters)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return false
+		}
+	default:
+		var state, superstate string
+		err := h.db.QueryRow("SELECT COALESCE(state, ''), COALESCE(superstate, '') FROM ballots WHERE id = $1", ballotID).Scan(&state, &superstate)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return false
+		}
+
+		states := []string{state}
+		scopeValue = state
+		if scope == "superstate" {
+			var ok bool
+			states, ok = utils.StatesInSuperstate(superstate)
+			scopeValue = superstate
+			if !ok {
+				apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot has no recognised superstate")
+				return false
+			}
+		} else if state == "" {
+			apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ballot has no state")
+			return false
+		}
+
+		err = h.db.QueryRow(`
+			SELECT COUNT(*)
+			FROM users u
+			JOIN user_addresses ua ON ua.user_id = u.id
+			WHERE u.deleted_at IS NULL AND LOWER(ua.state) = ANY($1)
+		`, pq.Array(states)).Scan(&eligibleVoters)
+		if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return false
+		}
+	}
+
+	rate := 0.0
+	if eligibleVoters > 0 {
+		rate = math.Round(float64(voterCount)/float64(eligibleVoters)*10000) / 100
+	}
+
+	response["participation_rate"] = rate
+	response["scope"] = scope
+	response["eligible_voters"] = eligibleVoters
+	if scopeValue != "" {
+		response["scope_value"] = scopeValue
+	}
+	return true
+}
+
+// addEligibleTurnout compares a state ballot's voters with the state's census
+// population and with the registered users who live there. Nothing is added when
+// the ballot has no state or no population is seeded for it. It reports whether
+// the response should continue; on false an error has already been written.
+func (h *VoteHandler) addEligibleTurnout(c *gin.Context, response gin.H, ballotID int, voterCount int) bool {
+	var state string
+	var population int64
+	err := h.db.QueryRow(`
+		SELECT b.state, sp.population
+		FROM ballots b
+		JOIN state_populations sp ON sp.state_code = b.state
+		WHERE b.id = $1
+	`, ballotID).Scan(&state, &population)
+	if err == sql.ErrNoRows {
+		return true
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return false
+	}
+
+	var registeredUsers int
+	err = h.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM users u
+		JOIN user_addresses ua ON ua.user_id = u.id
+		WHERE u.deleted_at IS NULL AND LOWER(ua.state) = $1
+	`, state).Scan(&registeredUsers)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return false
+	}
+
+	// Population turnout is tiny, so it keeps more precision than the registered rate
+	response["turnout_rate_vs_population"] = math.Round(float64(voterCount)/float64(population)*100*1e6) / 1e6
+	registeredRate := 0.0
+	if registeredUsers > 0 {
+		registeredRate = math.Round(float64(voterCount)/float64(registeredUsers)*10000) / 100
+	}
+	response["turnout_rate_vs_registered_users"] = registeredRate
+	return true
+}
+
+// replayBallotResults reports the results as they stood at the as_of timestamp.
+func (h *VoteHandler) replayBallotResults(c *gin.Context, ballotID int) {
+	asOf, err := time.Parse(time.RFC3339, c.Query("as_of"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "as_of must be an RFC3339 timestamp")
+		return
+	}
+	if asOf.After(time.Now()) {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "as_of cannot be in the future")
+		return
+	}
+
+	results, totalVotes, err := h.fetchBallotResultsAsOf(ballotID, asOf)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":   ballotID,
+		"as_of":       asOf.Format(time.RFC3339),
+		"results":     results,
+		"total_votes": totalVotes,
+	})
+}
+
+// snapshotItem is one entry of a ballot_result_snapshots results array.
+type snapshotItem struct {
+	ItemID    int    `json:"item_id"`
+	Title     string `json:"title"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// GetLeadingItemTimeline reports which item led each stored results snapshot, oldest
+// first, flagging the snapshots where the lead changed hands. Ties for first go to the
+// lower item ID, matching the order of the results endpoint.
+func (h *VoteHandler) GetLeadingItemTimeline(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	rows, err := h.db.Query(
+		"SELECT snapshotted_at, results FROM ballot_result_snapshots WHERE ballot_id = $1 ORDER BY snapshotted_at",
+		ballotID,
+	)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+	defer rows.Close()
+
+	timeline := make([]models.LeadingItemSnapshot, 0)
+	for rows.Next() {
+		var snapshottedAt time.Time
+		var raw []byte
+		if err := rows.Scan(&snapshottedAt, &raw); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			return
+		}
+
+		var items []snapshotItem
+		if err := json.Unmarshal(raw, &items); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error reading snapshot")
+			return
+		}
+		if len(items) == 0 {
+			continue
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].VoteCount != items[j].VoteCount {
+				return items[i].VoteCount > items[j].VoteCount
+			}
+			return items[i].ItemID < items[j].ItemID
+		})
+
+		entry := models.LeadingItemSnapshot{
+			SnapshottedAt:    snapshottedAt,
+			LeadingItemID:    items[0].ItemID,
+			LeadingItemTitle: items[0].Title,
+			VoteCount:        items[0].VoteCount,
+			LeadOverSecond:   items[0].VoteCount,
+		}
+		if len(items) > 1 {
+			entry.LeadOverSecond = items[0].VoteCount - items[1].VoteCount
+		}
+		if len(timeline) > 0 && timeline[len(timeline)-1].LeadingItemID != entry.LeadingItemID {
+			entry.WasLeadChange = true
+		}
+		timeline = append(timeline, entry)
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// marginOfVictory compares the top two entries of results, which must already be
+// sorted by vote count descending. It returns nil when there is nothing to compare.
+func marginOfVictory(results []resultItem, totalVotes int) *models.MarginOfVictory {
+	if totalVotes == 0 || len(results) < 2 {
+		return nil
+	}
+
+	leader, runnerUp := results[0], results[1]
+	if leader.VoteCount == runnerUp.VoteCount {
+		return &models.MarginOfVictory{Tied: true}
+	}
+
+	difference := leader.VoteCount - runnerUp.VoteCount
+	return &models.MarginOfVictory{
+		LeaderID:             &leader.ID,
+		RunnerUpID:           &runnerUp.ID,
+		VoteDifference:       difference,
+		PercentageDifference: math.Round(float64(difference)/float64(totalVotes)*10000) / 100,
+	}
+}
+
+// declareWinner adds the first-past-the-post winner to a results response: the
+// item with the most votes, which must come first in results. There is no winner
+// before any votes are cast or while the ballot's minimum quorum is unmet; on a tie
+// for first the tied items are listed instead.
+func declareWinner(response gin.H, results []resultItem, totalVotes int, minimumQuorum *int) {
+	response["winner"] = nil
+	if totalVotes == 0 || len(results) == 0 {
+		return
+	}
+	if minimumQuorum != nil && totalVotes < *minimumQuorum {
+		response["winner_pending_quorum"] = true
+		return
+	}
+
+	leaders := make([]models.ResultWinner, 0, 1)
+	for _, item := range results {
+		if item.VoteCount != results[0].VoteCount {
+			break
+		}
+		leaders = append(leaders, models.ResultWinner{
+			ItemID:     item.ID,
+			Title:      item.Title,
+			VoteCount:  item.VoteCount,
+			Percentage: math.Round(float64(item.VoteCount)/float64(totalVotes)*10000) / 100,
+		})
+	}
+
+	if len(leaders) > 1 {
+		response["tied_items"] = leaders
+		return
+	}
+	response["winner"] = leaders[0]
+}
+
+// addBinaryBreakdown marks a results response as a yes/no ballot when its two
+// items read as approval and rejection, and reports the net approval in
+// percentage points.
+func addBinaryBreakdown(response gin.H, results []resultItem, totalVotes int) {
+	titles := make([]string, len(results))
+	for i, item := range results {
+		titles[i] = item.Title
+	}
+
+	approval, rejection, ok := utils.BinaryOptions(titles)
+	response["is_binary_ballot"] = ok
+	if !ok {
+		return
+	}
+
+	netApproval := 0.0
+	if totalVotes > 0 {
+		netApproval = math.Round(float64(results[approval].VoteCount-results[rejection].VoteCount)/float64(totalVotes)*10000) / 100
+	}
+	response["approval_item_id"] = results[approval].ID
+	response["rejection_item_id"] = results[rejection].ID
+	response["net_approval_percentage"] = netApproval
+}
+
+// bordaBallotResults scores a ranked ballot with the Borda count.
+func (h *VoteHandler) bordaBallotResults(c *gin.Context, ballotID int, ballotType string) {
+	if ballotType != models.BallotTypeRanked {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Borda scoring is only available for ranked ballots")
+		return
+	}
+
+	items, _, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	rankings, err := h.fetchRankings(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	itemIDs := make([]int, len(items))
+	titles := make(map[int]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+		titles[item.ID] = item.Title
+	}
+
+	results := make([]models.BordaResult, 0, len(items))
+	for _, score := range utils.BordaCount(itemIDs, rankings) {
+		results = append(results, models.BordaResult{
+			ItemID:     score.ItemID,
+			Title:      titles[score.ItemID],
+			BordaScore: score.Score,
+			Rank:       score.Rank,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":    ballotID,
+		"scoring":      "borda",
+		"results":      results,
+		"total_voters": len(rankings),
+	})
+}
+
+// irvBallotResults simulates an instant-runoff count of a ranked ballot from the
+// rankings cast so far.
+func (h *VoteHandler) irvBallotResults(c *gin.Context, ballotID int, ballotType string) {
+	if ballotType != models.BallotTypeRanked {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "IRV simulation is only available for ranked ballots")
+		return
+	}
+
+	outcome, err := h.instantRunoff(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":          ballotID,
+		"winner_id":          outcome.WinnerID,
+		"elimination_rounds": outcome.EliminationRounds,
+		"total_voters":       outcome.TotalVoters,
+		"simulation_note":    "Results may change as more votes are cast",
+	})
+}
+
+// instantRunoff counts the rankings cast on a ballot by instant runoff.
+func (h *VoteHandler) instantRunoff(ballotID int) (models.RankedResults, error) {
+	outcome := models.RankedResults{BallotID: ballotID, EliminationRounds: []models.IRVEliminationRound{}}
+
+	items, _, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		return outcome, err
+	}
+
+	rankings, err := h.fetchRankings(ballotID)
+	if err != nil {
+		return outcome, err
+	}
+
+	// Eliminate in ballot order on ties, matching the order items were created
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	itemIDs := make([]int, len(items))
+	titles := make(map[int]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+		titles[item.ID] = item.Title
+	}
+
+	count := utils.InstantRunoff(itemIDs, rankings)
+	for _, round := range count.Rounds {
+		outcome.EliminationRounds = append(outcome.EliminationRounds, models.IRVEliminationRound{
+			Round:               round.Round,
+			EliminatedItemID:    round.EliminatedItemID,
+			EliminatedItemTitle: titles[round.EliminatedItemID],
+			RedistributedVotes:  round.RedistributedVotes,
+		})
+	}
+
+	if count.WinnerID != 0 {
+		winnerID, winnerTitle := count.WinnerID, titles[count.WinnerID]
+		outcome.WinnerID, outcome.WinnerTitle = &winnerID, &winnerTitle
+	}
+	outcome.TotalVoters = len(rankings)
+	return outcome, nil
+}
+
+// GetRankedResults runs an instant-runoff count over a ranked ballot's rankings.
+func (h *VoteHandler) GetRankedResults(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	var ballotType string
+	err = h.db.QueryRow("SELECT COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL", ballotID).Scan(&ballotType)
+	if err == sql.ErrNoRows {
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+		return
+	} else if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+		return
+	}
+
+	if ballotType != models.BallotTypeRanked {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Ranked results are only available for ranked ballots")
+		return
+	}
+
+	outcome, err := h.instantRunoff(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	c.JSON(http.StatusOK, outcome)
+}
+
+type approvalResultItem struct {
+	resultItem
+	ApprovalRate     float64 `json:"approval_rate"`
+	DisapprovalCount int     `json:"disapproval_count"`
+	NetApproval      int     `json:"net_approval"`
+}
+
+// approvalBallotResults reports approval ballot results. A voter who approved some
+// items but not others counts as disapproving the rest, so every item is measured
+// against the number of distinct voters.
+func (h *VoteHandler) approvalBallotResults(c *gin.Context, ballotID int) {
+	items, totalVotes, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	var uniqueVoters int
+	err = h.db.QueryRow("SELECT COUNT(DISTINCT user_id) FROM multi_votes WHERE ballot_id = $1", ballotID).Scan(&uniqueVoters)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	results := make([]approvalResultItem, len(items))
+	for i, item := range items {
+		disapprovals := uniqueVoters - item.VoteCount
+		rate := 0.0
+		if uniqueVoters > 0 {
+			rate = math.Round(float64(item.VoteCount)/float64(uniqueVoters)*10000) / 100
+		}
+		results[i] = approvalResultItem{
+			resultItem:       item,
+			ApprovalRate:     rate,
+			DisapprovalCount: disapprovals,
+			NetApproval:      item.VoteCount - disapprovals,
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].NetApproval > results[j].NetApproval
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":         ballotID,
+		"results":           results,
+		"total_votes":       totalVotes,
+		"unique_voters":     uniqueVoters,
+		"margin_of_victory": marginOfVictory(items, totalVotes),
+	})
+}
+
+// scoreBallotResults reports each item's average score and how many voters gave it
+// each score. The item with the highest average wins.
+func (h *VoteHandler) scoreBallotResults(c *gin.Context, ballotID int) {
+	items, _, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	rows, err := h.db.Query("SELECT user_id, ballot_item_id, score FROM score_votes WHERE ballot_id = $1", ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+	defer rows.Close()
+
+	var scores []utils.ItemScore
+	voters := make(map[int]bool)
+	for rows.Next() {
+		var userID int
+		var score utils.ItemScore
+		if err := rows.Scan(&userID, &score.ItemID, &score.Score); err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+			return
+		}
+		scores = append(scores, score)
+		voters[userID] = true
+	}
+	if err := rows.Err(); err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	itemIDs := make([]int, len(items))
+	titles := make(map[int]string, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+		titles[item.ID] = item.Title
+	}
+
+	results := make([]models.ScoreResult, 0, len(items))
+	for _, tally := range utils.ScoreVoting(itemIDs, scores) {
+		results = append(results, models.ScoreResult{
+			ItemID:            tally.ItemID,
+			Title:             titles[tally.ItemID],
+			AverageScore:      tally.AverageScore,
+			ScoreDistribution: tally.Distribution,
+			Rank:              tally.Rank,
+		})
+	}
+
+	var winnerID *int
+	if len(voters) > 0 && len(results) > 0 {
+		winnerID = &results[0].ItemID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":    ballotID,
+		"scoring":      "score",
+		"results":      results,
+		"winner_id":    winnerID,
+		"total_voters": len(voters),
+	})
+}
+
+// fetchRankings loads every voter's ranked preferences for a ballot, each as a list
+// of item IDs from first to last preference.
+func (h *VoteHandler) fetchRankings(ballotID int) ([][]int, error) {
+	rows, err := h.db.Query("SELECT user_id, ballot_item_id FROM ranked_votes WHERE ballot_id = $1 ORDER BY user_id, rank", ballotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rankings [][]int
+	lastUserID := 0
+	for rows.Next() {
+		var userID, itemID int
+		if err := rows.Scan(&userID, &itemID); err != nil {
+			return nil, err
+		}
+		if len(rankings) == 0 || userID != lastUserID {
+			rankings = append(rankings, nil)
+			lastUserID = userID
+		}
+		rankings[len(rankings)-1] = append(rankings[len(rankings)-1], itemID)
+	}
+
+	return rankings, rows.Err()
+}
+
+// fetchLiveBallotResults counts votes straight from the votes table instead of
+// trusting the denormalized vote_count, so the two can be compared for integrity.
+func (h *VoteHandler) fetchLiveBallotResults(ballotID int) ([]resultItem, int, error) {
+	return h.recountBallotResults(ballotID, "SELECT ballot_item_id, COUNT(*) FROM votes WHERE ballot_id = $1 GROUP BY ballot_item_id", ballotID)
+}
+
+// fetchTimedVotes loads when each vote on a ballot was cast, for time-decay weighting.
+func (h *VoteHandler) fetchTimedVotes(ballotID int) ([]utils.TimedVote, error) {
+	rows, err := h.db.Query("SELECT ballot_item_id, created_at FROM votes WHERE ballot_id = $1", ballotID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []utils.TimedVote
+	for rows.Next() {
+		var vote utils.TimedVote
+		if err := rows.Scan(&vote.ItemID, &vote.VotedAt); err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
+
+// fetchBallotResultsAsOf counts only the votes cast up to asOf, for replaying how
+// the results accumulated over time.
+func (h *VoteHandler) fetchBallotResultsAsOf(ballotID int, asOf time.Time) ([]resultItem, int, error) {
+	return h.recountBallotResults(ballotID, "SELECT ballot_item_id, COUNT(*) FROM votes WHERE ballot_id = $1 AND created_at <= $2 GROUP BY ballot_item_id", ballotID, asOf)
+}
+
+// recountBallotResults replaces each item's vote_count with the per-item counts
+// returned by countQuery and re-sorts the results.
+func (h *VoteHandler) recountBallotResults(ballotID int, countQuery string, args ...interface{}) ([]resultItem, int, error) {
+	results, _, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := h.db.Query(countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var itemID, count int
+		if err := rows.Scan(&itemID, &count); err != nil {
+			return nil, 0, err
+		}
+		counts[itemID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	totalVotes := 0
+	for i := range results {
+		results[i].VoteCount = counts[results[i].ID]
+		totalVotes += results[i].VoteCount
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].VoteCount != results[j].VoteCount {
+			return results[i].VoteCount > results[j].VoteCount
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	return results, totalVotes, nil
+}
+
+// longPollBallotResults blocks until the ballot has more than last_vote_count votes
+// or timeout_seconds elapses, for clients that cannot use server-sent events.
+func (h *VoteHandler) longPollBallotResults(c *gin.Context, ballotID int) {
+	lastVoteCount, err := strconv.Atoi(c.DefaultQuery("last_vote_count", "0"))
+	if err != nil || lastVoteCount < 0 {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid last_vote_count")
+		return
+	}
+
+	timeoutSeconds, err := strconv.Atoi(c.DefaultQuery("timeout_seconds", strconv.Itoa(defaultLongPollTimeoutSeconds)))
+	if err != nil || timeoutSeconds < 1 {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid timeout_seconds")
+		return
+	}
+	if timeoutSeconds > maxLongPollTimeoutSeconds {
+		timeoutSeconds = maxLongPollTimeoutSeconds
+	}
+
+	pollingSince := time.Now().UTC()
+
+	// Subscribe before reading the current count so a vote landing in between is not missed
+	notifyChan, unsubscribe := h.notifier.Subscribe(ballotID)
+	defer unsubscribe()
+
+	results, totalVotes, err := h.fetchBallotResults(ballotID)
+	if err != nil {
+		apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+		return
+	}
+
+	// A notification does not always raise the total (a retracted or changed vote
+	// also wakes us), so keep waiting on the same deadline until it does
+	deadline := time.NewTimer(time.Duration(timeoutSeconds) * time.Second)
+	defer deadline.Stop()
+
+	timedOut := false
+	for !timedOut && totalVotes <= lastVoteCount {
+		select {
+		case <-notifyChan:
+			results, totalVotes, err = h.fetchBallotResults(ballotID)
+			if err != nil {
+				apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Error fetching results")
+				return
+			}
+		case <-deadline.C:
+			timedOut = true
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ballot_id":     ballotID,
+		"results":       results,
+		"total_votes":   totalVotes,
+		"polling_since": pollingSince.Format(time.RFC3339),
+		"timed_out":     timedOut,
+	})
+}
+
+// StreamBallotResults pushes a server-sent event to the client each time a vote is
+// recorded on the ballot, carrying the chosen item's new count and the ballot
+// total. A comment line is sent every resultStreamHeartbeat while it is quiet.
+func (h *VoteHandler) StreamBallotResults(c *gin.Context) {
+	ballotID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		apierrors.RespondError(c, http.StatusBadRequest, apierrors.ErrCodeBadRequest, "Invalid ballot ID")
+		return
+	}
+
+	if !requireBallot(c, h.db, ballotID) {
+		return
+	}
+
+	updates, unsubscribe := h.updates.Subscribe(ballotID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(resultStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case update := <-updates:
+			data, err := json.Marshal(update)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(c.Writer, "data: %s\n\n", data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+		c.Writer.Flush()
+	}
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"crypto/tls"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"time"
+	"voting-api/cache"
 	"voting-api/database"
 	"voting-api/routes"
+	"voting-api/scheduler"
 
 	"github.com/joho/godotenv"
 )
@@ -27,8 +33,24 @@ func main() {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
+	// Start background jobs for scheduled ballot activation/deactivation, closing expired ballots and result snapshots
+	stopScheduler := scheduler.Start(db, time.Minute)
+	defer stopScheduler()
+
+	// Use Redis for caching when configured, otherwise run without a cache
+	var ballotCache cache.Cacher = cache.NoOpCache{}
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		redisCache, err := cache.NewRedisCache(redisURL)
+		if err != nil {
+			log.Println("Redis unavailable, continuing without cache:", err)
+		} else {
+			defer redisCache.Close()
+			ballotCache = redisCache
+		}
+	}
+
 	// Setup routes
-	router := routes.SetupRoutes(db)
+	router := routes.SetupRoutes(db, ballotCache)
 
 	// Get port from environment or default to 8080
 	port := os.Getenv("PORT")
@@ -36,6 +58,42 @@ func main() {
 		port = "8080"
 	}
 
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(router.Run(":" + port))
-}
\ No newline at end of file
+	log.Fatal(startServer(router, port, os.Getenv("TLS_CERT_FILE"), os.Getenv("TLS_KEY_FILE")))
+}
+
+// startServer serves router on port, over HTTPS when both certFile and keyFile
+// are set. It only returns when the server stops.
+func startServer(router http.Handler, port, certFile, keyFile string) error {
+	tlsConfig, err := serverTLSConfig(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Addr: ":" + port, Handler: router, TLSConfig: tlsConfig}
+	if tlsConfig == nil {
+		log.Printf("Server starting on port %s", port)
+		return server.ListenAndServe()
+	}
+
+	log.Printf("Server starting with TLS on port %s", port)
+	// The certificate is already loaded into TLSConfig
+	return server.ListenAndServeTLS("", "")
+}
+
+// serverTLSConfig loads the certificate pair, returning nil when TLS is not
+// configured. Setting only one of the two paths is an error rather than a silent
+// fallback to plain HTTP.
+func serverTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading TLS certificate: %w", err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
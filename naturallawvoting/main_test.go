@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert writes a certificate for 127.0.0.1 and its key to dir.
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{Organization: []string{"Voting API Test"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	t.Run("Disabled Without Paths", func(t *testing.T) {
+		config, err := serverTLSConfig("", "")
+		require.NoError(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("Only One Path Set", func(t *testing.T) {
+		_, err := serverTLSConfig("cert.pem", "")
+		assert.Error(t, err)
+
+		// startServer fails before it tries to listen
+		err = startServer(http.NotFoundHandler(), "0", "", "key.pem")
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing Files", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := serverTLSConfig(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Serves HTTPS With Self-Signed Certificate", func(t *testing.T) {
+		certFile, keyFile := writeSelfSignedCert(t, t.TempDir())
+
+		config, err := serverTLSConfig(certFile, keyFile)
+		require.NoError(t, err)
+		require.NotNil(t, config)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/health", func(c *gin.Context) {
+			c.String(http.StatusOK, "ok")
+		})
+
+		server := httptest.NewUnstartedServer(router)
+		server.TLS = config
+		server.StartTLS()
+		defer server.Close()
+
+		// Trust the generated certificate rather than skipping verification
+		pool := x509.NewCertPool()
+		pemBytes, err := os.ReadFile(certFile)
+		require.NoError(t, err)
+		require.True(t, pool.AppendCertsFromPEM(pemBytes))
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+
+		resp, err := client.Get(server.URL + "/health")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "ok", string(body))
+		require.NotNil(t, resp.TLS)
+	})
+}
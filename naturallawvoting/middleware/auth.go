@@ -1,8 +1,11 @@
 package middleware
 
 import (
+	"database/sql"
 	"net/http"
 	"strings"
+	"voting-api/apierrors"
+	"voting-api/database"
 	"voting-api/utils"
 
 	"github.com/gin-gonic/gin"
@@ -12,21 +15,21 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Authorization header required")
 			c.Abort()
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Bearer token required"})
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Bearer token required")
 			c.Abort()
 			return
 		}
 
 		claims, err := utils.ValidateJWT(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Invalid token")
 			c.Abort()
 			return
 		}
@@ -34,7 +37,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// Extract user ID from claims
 		userIDFloat, ok := claims["user_id"].(float64)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Invalid token claims")
 			c.Abort()
 			return
 		}
@@ -42,7 +45,80 @@ func AuthMiddleware() gin.HandlerFunc {
 		userID := int(userIDFloat)
 		c.Set("user_id", userID)
 		c.Set("user_email", claims["email"])
-		
+		c.Set("user_role", roleClaim(claims))
+
+		if adminIDFloat, ok := claims["impersonated_by"].(float64); ok {
+			c.Set("impersonated_by", int(adminIDFloat))
+		}
+
+		c.Next()
+	}
+}
+
+// AuthMiddlewareOptional populates the user context when a valid bearer token is
+// supplied but lets anonymous requests through, for public routes that can
+// personalise their response.
+func AuthMiddlewareOptional() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if tokenString == "" || tokenString == c.GetHeader("Authorization") {
+			c.Next()
+			return
+		}
+
+		claims, err := utils.ValidateJWT(tokenString)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if userIDFloat, ok := claims["user_id"].(float64); ok {
+			c.Set("user_id", int(userIDFloat))
+			c.Set("user_email", claims["email"])
+			c.Set("user_role", roleClaim(claims))
+			if adminIDFloat, ok := claims["impersonated_by"].(float64); ok {
+				c.Set("impersonated_by", int(adminIDFloat))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// roleClaim returns the role recorded in a token, treating tokens issued before
+// roles were added as ordinary users.
+func roleClaim(claims map[string]interface{}) string {
+	if role, ok := claims["role"].(string); ok && role != "" {
+		return role
+	}
+	return "user"
+}
+
+// AdminRequired must run after AuthMiddleware. The role is read from the database
+// on every request so that revoking admin access takes effect immediately, rather
+// than trusting the role claim, which lasts as long as the token.
+func AdminRequired(db *database.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("user_id")
+		if !exists {
+			apierrors.RespondError(c, http.StatusUnauthorized, apierrors.ErrCodeUnauthorized, "Unauthorized")
+			c.Abort()
+			return
+		}
+
+		var role string
+		err := db.QueryRow("SELECT role FROM users WHERE id = $1", userID).Scan(&role)
+		if err == sql.ErrNoRows || (err == nil && role != "admin") {
+			apierrors.RespondError(c, http.StatusForbidden, apierrors.ErrCodeForbidden, "Admin access required")
+			c.Abort()
+			return
+		} else if err != nil {
+			apierrors.RespondError(c, http.StatusInternalServerError, apierrors.ErrCodeInternal, "Database error")
+			c.Abort()
+			return
+		}
+
+		c.Set("user_role", role)
 		c.Next()
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"voting-api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireJSON rejects POST, PUT and PATCH requests whose body is not declared as
+// JSON, since ShouldBindJSON would otherwise fail on them with a misleading error.
+// Requests without a body, such as POST /ballots/:ballot_id/lock, are let through.
+func RequireJSON() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		contentType := strings.ToLower(c.GetHeader("Content-Type"))
+		if !strings.HasPrefix(contentType, "application/json") {
+			apierrors.RespondError(c, http.StatusUnsupportedMediaType, apierrors.ErrCodeUnsupportedMediaType, "Unsupported Media Type: Content-Type must be application/json")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParseAllowedOrigins splits the comma-separated ALLOWED_ORIGINS value, dropping
+// blanks and trailing slashes so "https://app.example.com/" still matches the
+// Origin header browsers send.
+func ParseAllowedOrigins(value string) []string {
+	var origins []string
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// CORS answers cross-origin requests from allowedOrigins, echoing the caller's
+// origin back so credentialed requests work. Requests from any other origin get
+// no CORS headers and are blocked by the browser. With no allowed origins every
+// origin is accepted through a wildcard, which is only meant for development.
+func CORS(allowedOrigins []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = true
+	}
+
+	return func(c *gin.Context) {
+		if len(allowed) == 0 {
+			c.Header("Access-Control-Allow-Origin", "*")
+			setCORSHeaders(c)
+		} else {
+			// The response depends on Origin, so shared caches must key on it
+			c.Writer.Header().Add("Vary", "Origin")
+			if origin := c.GetHeader("Origin"); allowed[origin] {
+				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Access-Control-Allow-Credentials", "true")
+				setCORSHeaders(c)
+			}
+		}
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func setCORSHeaders(c *gin.Context) {
+	c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+	c.Header("Access-Control-Expose-Headers", RequestIDHeader)
+}
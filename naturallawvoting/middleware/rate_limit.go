@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"sync"
+	"time"
+	"voting-api/apierrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clientWindow holds the times of a client's requests within the current window.
+type clientWindow struct {
+	mu   sync.Mutex
+	hits []time.Time
+}
+
+// RateLimitMiddleware allows each client IP at most maxRequests requests in any
+// windowDuration, answering 429 once the limit is reached. State is kept in
+// memory, so the limit applies per server instance.
+func RateLimitMiddleware(maxRequests int, windowDuration time.Duration) gin.HandlerFunc {
+	var clients sync.Map
+	var sweepMu sync.Mutex
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		cutoff := now.Add(-windowDuration)
+
+		// Drop clients that have been idle for a whole window so the map does not
+		// grow without bound
+		sweepMu.Lock()
+		if now.Sub(lastSweep) >= windowDuration {
+			lastSweep = now
+			clients.Range(func(key, value interface{}) bool {
+				window := value.(*clientWindow)
+				window.mu.Lock()
+				if len(window.hits) == 0 || !window.hits[len(window.hits)-1].After(cutoff) {
+					clients.Delete(key)
+				}
+				window.mu.Unlock()
+				return true
+			})
+		}
+		sweepMu.Unlock()
+
+		value, _ := clients.LoadOrStore(c.ClientIP(), &clientWindow{})
+		window := value.(*clientWindow)
+
+		window.mu.Lock()
+		recent := window.hits[:0]
+		for _, hit := range window.hits {
+			if hit.After(cutoff) {
+				recent = append(recent, hit)
+			}
+		}
+		window.hits = recent
+		allowed := len(window.hits) < maxRequests
+		if allowed {
+			window.hits = append(window.hits, now)
+		}
+		window.mu.Unlock()
+
+		if !allowed {
+			apierrors.RespondError(c, http.StatusTooManyRequests, apierrors.ErrCodeRateLimited, "Too many requests")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
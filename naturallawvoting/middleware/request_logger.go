@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the correlation ID assigned to each request.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLogger assigns every request a correlation ID and logs one JSON line
+// per request to stdout once it completes.
+func RequestLogger() gin.HandlerFunc {
+	return NewRequestLogger(os.Stdout)
+}
+
+// NewRequestLogger is RequestLogger writing to w instead of stdout.
+func NewRequestLogger(w io.Writer) gin.HandlerFunc {
+	logger := slog.New(slog.NewJSONHandler(w, nil))
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		requestID := newRequestID()
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+
+		logger.Info("request",
+			slog.String("request_id", requestID),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// newRequestID returns a random (version 4) UUID.
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseTransformer rewrites a decoded JSON response body. Numbers arrive as
+// json.Number so IDs survive the round trip unchanged.
+type ResponseTransformer func(body interface{}) interface{}
+
+// TransformJSONResponse runs every JSON response through transform before it is
+// sent. Other content types, including streamed CSV and binary downloads, are
+// written through untouched.
+func TransformJSONResponse(transform ResponseTransformer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writer := &transformWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+		if !writer.buffering || writer.body.Len() == 0 {
+			return
+		}
+
+		body := writer.body.Bytes()
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var decoded interface{}
+		if err := decoder.Decode(&decoded); err == nil {
+			if transformed, err := json.Marshal(transform(decoded)); err == nil {
+				body = transformed
+			}
+		}
+		c.Writer.Write(body)
+	}
+}
+
+// transformWriter holds back JSON output until the handler finishes. Whether to
+// buffer is decided on the first write, once the handler has set Content-Type.
+type transformWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+func (w *transformWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		w.buffering = strings.HasPrefix(w.Header().Get("Content-Type"), gin.MIMEJSON)
+	}
+	if !w.buffering {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *transformWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogEntry struct {
+	ID          int64           `json:"id" db:"id"`
+	AdminUserID *int            `json:"admin_user_id" db:"admin_user_id"`
+	Action      string          `json:"action" db:"action"`
+	TargetType  *string         `json:"target_type" db:"target_type"`
+	TargetID    *int            `json:"target_id" db:"target_id"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	IPAddress   *string         `json:"ip_address" db:"ip_address"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
@@ -1,29 +1,269 @@
 package models
 
 import (
+	"encoding/xml"
 	"time"
 )
 
+// Ballot types. Plurality ballots take a single choice per voter; ranked ballots
+// take an ordered preference list stored in ranked_votes; approval and multi-select
+// ballots let a voter select any number of items, stored in multi_votes; score
+// ballots have voters rate every item from 0 to 10, stored in score_votes.
+const (
+	BallotTypePlurality   = "plurality"
+	BallotTypeRanked      = "ranked"
+	BallotTypeApproval    = "approval"
+	BallotTypeMultiSelect = "multi_select"
+	BallotTypeScore       = "score"
+)
+
 type Ballot struct {
-	ID          int       `json:"id" db:"id"`
-	Title       string    `json:"title" db:"title"`
-	Description string    `json:"description" db:"description"`
-	Category    string    `json:"category" db:"category"`
-	Superstate  string    `json:"superstate" db:"superstate"`
-	State       string    `json:"state" db:"state"`
-	CreatorID   int       `json:"creator_id" db:"creator_id"`
-	IsActive    bool      `json:"is_active" db:"is_active"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
-	Items       []BallotItem `json:"options,omitempty"` // Frontend expects "options"
+	XMLName     xml.Name `json:"-" xml:"ballot"`
+	ID          int      `json:"id" xml:"id" db:"id"`
+	Title       string   `json:"title" xml:"title" db:"title"`
+	Description string   `json:"description" xml:"description" db:"description"`
+	Category    string   `json:"category" xml:"category" db:"category"`
+	Superstate  string   `json:"superstate" xml:"superstate" db:"superstate"`
+	State       string   `json:"state" xml:"state" db:"state"`
+	CreatorID   int      `json:"creator_id" xml:"creator_id" db:"creator_id"`
+	IsActive    bool     `json:"is_active" xml:"is_active" db:"is_active"`
+	BallotType  string   `json:"ballot_type,omitempty" xml:"ballot_type,omitempty" db:"ballot_type"`
+	Locked      bool     `json:"locked" xml:"locked" db:"locked"`
+	// Only populated on creation; other responses omit it
+	AllowVoteRetraction bool       `json:"allow_vote_retraction,omitempty" xml:"allow_vote_retraction,omitempty" db:"allow_vote_retraction"`
+	CreatedAt           time.Time  `json:"created_at" xml:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" xml:"updated_at" db:"updated_at"`
+	ActivateAt          *time.Time `json:"activate_at,omitempty" xml:"activate_at,omitempty" db:"activate_at"`
+	DeactivateAt        *time.Time `json:"deactivate_at,omitempty" xml:"deactivate_at,omitempty" db:"deactivate_at"`
+	ClosesAt            *time.Time `json:"closes_at,omitempty" xml:"closes_at,omitempty" db:"closes_at"`
+	MinimumQuorum       *int       `json:"minimum_quorum,omitempty" xml:"minimum_quorum,omitempty" db:"minimum_quorum"`
+	// Only populated on archived ballots, which have been deleted by their creator
+	DeletedAt *time.Time `json:"deleted_at,omitempty" xml:"deleted_at,omitempty" db:"deleted_at"`
+	// Hours until closes_at; only populated in ballot listings
+	HoursRemaining *float64 `json:"hours_remaining,omitempty" xml:"hours_remaining,omitempty"`
+	TotalVotes     int      `json:"total_votes" xml:"total_votes"`
+	ItemCount      int      `json:"item_count" xml:"item_count"`
+	// Only populated by GetBallot; other responses omit it
+	SponsorCount *int `json:"sponsor_count,omitempty" xml:"sponsor_count,omitempty"`
+	// ballot_item_id the requesting user voted for; only populated by
+	// GetBallotWithUserVote, which renders it as null when there is no vote
+	UserVote *int         `json:"user_vote,omitempty" xml:"user_vote,omitempty"`
+	Items    []BallotItem `json:"options,omitempty" xml:"options>item,omitempty"` // Frontend expects "options"
+}
+
+// VoteEligibility tells an authenticated caller whether they could vote on a ballot
+// right now, so the vote UI can be hidden with an explanation instead.
+type VoteEligibility struct {
+	CanVote          bool   `json:"can_vote" xml:"can_vote"`
+	CannotVoteReason string `json:"cannot_vote_reason,omitempty" xml:"cannot_vote_reason,omitempty"`
 }
 
 type BallotItem struct {
-	ID          int    `json:"id" db:"id"`
-	BallotID    int    `json:"ballot_id" db:"ballot_id"`
-	Title       string `json:"title" db:"title"`
-	Description string `json:"description" db:"description"`
-	VoteCount   int    `json:"vote_count" db:"vote_count"`
+	XMLName     xml.Name `json:"-" xml:"item"`
+	ID          int      `json:"id" xml:"id" db:"id"`
+	BallotID    int      `json:"ballot_id" xml:"ballot_id" db:"ballot_id"`
+	Title       string   `json:"title" xml:"title" db:"title"`
+	Description string   `json:"description" xml:"description" db:"description"`
+	// Derived from the ballot_item_vote_counts view; the ballot_items.vote_count
+	// column it used to be read from is deprecated.
+	VoteCount int `json:"vote_count" xml:"vote_count" db:"vote_count"`
+	// Only populated by GetBallot; other responses omit it
+	UpdatedAt *time.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty" db:"updated_at"`
+}
+
+type PartyPopularItem struct {
+	ItemID    int    `json:"item_id" xml:"item_id"`
+	Title     string `json:"title" xml:"title"`
+	VoteCount int    `json:"vote_count" xml:"vote_count"`
+}
+
+type BallotSearchResult struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	CreatedAt   time.Time `json:"created_at"`
+	Rank        float64   `json:"rank"`
+	Headline    *string   `json:"headline,omitempty"`
+}
+
+type CheckDuplicateRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=200"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+type SimilarBallot struct {
+	ID              int     `json:"id"`
+	Title           string  `json:"title"`
+	SimilarityScore float64 `json:"similarity_score"`
+}
+
+type BallotAnnouncement struct {
+	ID        int       `json:"id" db:"id"`
+	BallotID  int       `json:"ballot_id" db:"ballot_id"`
+	CreatorID int       `json:"creator_id" db:"creator_id"`
+	Message   string    `json:"message" db:"message"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type CreateAnnouncementRequest struct {
+	Message string `json:"message" binding:"required,min=1,max=500"`
+}
+
+type BallotChangelogEntry struct {
+	// Only included in the admin view
+	ChangedBy *int      `json:"changed_by,omitempty" db:"changed_by"`
+	Field     string    `json:"field" db:"field"`
+	OldValue  string    `json:"old_value" db:"old_value"`
+	NewValue  string    `json:"new_value" db:"new_value"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
+
+type BordaResult struct {
+	ItemID     int    `json:"item_id"`
+	Title      string `json:"title"`
+	BordaScore int    `json:"borda_score"`
+	Rank       int    `json:"rank"`
+}
+
+type IRVEliminationRound struct {
+	Round               int    `json:"round"`
+	EliminatedItemID    int    `json:"eliminated_item_id"`
+	EliminatedItemTitle string `json:"eliminated_item_title"`
+	RedistributedVotes  int    `json:"redistributed_votes"`
+}
+
+type BallotCreationPeriod struct {
+	Period         time.Time `json:"period"`
+	BallotsCreated int       `json:"ballots_created"`
+	UniqueCreators int       `json:"unique_creators"`
+	// Change in ballots created from the previous period; null for the first period
+	// and after a period with none
+	GrowthRatePercent *float64 `json:"growth_rate_percent"`
+}
+
+type BallotDiagnosticItem struct {
+	ItemID          int `json:"item_id"`
+	StoredVoteCount int `json:"stored_vote_count"`
+	ActualVoteCount int `json:"actual_vote_count"`
+	Discrepancy     int `json:"discrepancy"`
+}
+
+type ScoreResult struct {
+	ItemID            int     `json:"item_id"`
+	Title             string  `json:"title"`
+	AverageScore      float64 `json:"average_score"`
+	ScoreDistribution [11]int `json:"score_distribution"`
+	Rank              int     `json:"rank"`
+}
+
+type ItemCorrelation struct {
+	ItemAID int `json:"item_a_id"`
+	ItemBID int `json:"item_b_id"`
+	Count   int `json:"count"`
+}
+
+type WriteInSentiment struct {
+	PositiveCount   int      `json:"positive_count"`
+	NegativeCount   int      `json:"negative_count"`
+	NeutralCount    int      `json:"neutral_count"`
+	MostCommonWords []string `json:"most_common_words"`
+}
+
+// SuperstateAnalytics summarises ballot activity across one superstate.
+// MostVotedBallot is null when the superstate has no ballots.
+type SuperstateAnalytics struct {
+	Superstate           string           `json:"superstate"`
+	TotalBallots         int              `json:"total_ballots"`
+	TotalVotes           int              `json:"total_votes"`
+	MostVotedBallot      *BallotVoteTotal `json:"most_voted_ballot"`
+	ParticipationRatePct float64          `json:"participation_rate_pct"`
+}
+
+type BallotVoteTotal struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	TotalVotes int    `json:"total_votes"`
+}
+
+// SuperstateResults lists every item on a superstate's ballots with its vote
+// total, most votes first.
+type SuperstateResults struct {
+	Superstate string                 `json:"superstate"`
+	Items      []SuperstateResultItem `json:"items"`
+}
+
+type SuperstateResultItem struct {
+	BallotID   int    `json:"ballot_id"`
+	ItemID     int    `json:"item_id"`
+	Title      string `json:"title"`
+	TotalVotes int    `json:"total_votes"`
+}
+
+// CategorySummary is a ballot category and how many active ballots use it.
+type CategorySummary struct {
+	Category    string `json:"category"`
+	BallotCount int    `json:"ballot_count"`
+}
+
+type StateVoterCount struct {
+	State      string  `json:"state"`
+	VoterCount int     `json:"voter_count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// VoterStats summarises who took part in a ballot without identifying anyone.
+// Superstates with too few voters to stay anonymous are left out of BySuperstate
+// but still counted in UniqueVoters.
+type VoterStats struct {
+	BallotID     int            `json:"ballot_id"`
+	UniqueVoters int            `json:"unique_voters"`
+	BySuperstate map[string]int `json:"by_superstate"`
+	// Voters with no address, or an address outside any superstate
+	Unknown int `json:"unknown"`
+}
+
+type ResultWinner struct {
+	ItemID     int     `json:"item_id"`
+	Title      string  `json:"title"`
+	VoteCount  int     `json:"vote_count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// LeadingItemSnapshot is the leader of one ballot_result_snapshots row.
+// WasLeadChange is set when the leader differs from the previous snapshot's.
+type LeadingItemSnapshot struct {
+	SnapshottedAt    time.Time `json:"snapshotted_at"`
+	LeadingItemID    int       `json:"leading_item_id"`
+	LeadingItemTitle string    `json:"leading_item_title"`
+	VoteCount        int       `json:"vote_count"`
+	LeadOverSecond   int       `json:"lead_over_second"`
+	WasLeadChange    bool      `json:"was_lead_change,omitempty"`
+}
+
+type MarginOfVictory struct {
+	LeaderID             *int    `json:"leader_id"`
+	RunnerUpID           *int    `json:"runner_up_id"`
+	VoteDifference       int     `json:"vote_difference"`
+	PercentageDifference float64 `json:"percentage_difference"`
+	Tied                 bool    `json:"tied,omitempty"`
+}
+
+type BallotAccessibility struct {
+	BallotID     int                    `json:"ballot_id"`
+	Title        string                 `json:"title"`
+	AriaLabel    string                 `json:"aria_label"`
+	ItemCount    int                    `json:"item_count"`
+	Items        []AccessibleBallotItem `json:"items"`
+	Instructions string                 `json:"instructions"`
+	Language     string                 `json:"language"`
+}
+
+type AccessibleBallotItem struct {
+	ID              int    `json:"id"`
+	Title           string `json:"title"`
+	AriaDescription string `json:"aria_description"`
+	Position        int    `json:"position"`
 }
 
 type Vote struct {
@@ -34,13 +274,54 @@ type Vote struct {
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 }
 
+// VoteHistoryEntry is one vote in a user's voting history, with enough of the
+// ballot and chosen option to display without further lookups.
+type VoteHistoryEntry struct {
+	VoteID            int       `json:"vote_id"`
+	BallotID          int       `json:"ballot_id"`
+	BallotTitle       string    `json:"ballot_title"`
+	BallotItemID      int       `json:"ballot_item_id"`
+	ChosenOptionTitle string    `json:"chosen_option_title"`
+	VotedAt           time.Time `json:"voted_at"`
+}
+
+// ResultUpdate is one server-sent event on a ballot's results stream, sent after
+// a vote for ItemID is recorded.
+type ResultUpdate struct {
+	BallotID     int `json:"ballot_id"`
+	ItemID       int `json:"item_id"`
+	NewVoteCount int `json:"new_vote_count"`
+	TotalVotes   int `json:"total_votes"`
+}
+
+// ItemVotes lists who voted for a ballot item, for the ballot creator to audit.
+// Voters are identified by user ID only.
+type ItemVotes struct {
+	ItemID int         `json:"item_id"`
+	Voters []ItemVoter `json:"voters"`
+}
+
+type ItemVoter struct {
+	UserID  int       `json:"user_id"`
+	VotedAt time.Time `json:"voted_at"`
+}
+
 type CreateBallotRequest struct {
-	Title       string                   `json:"title" binding:"required,min=1,max=200"`
-	Description string                   `json:"description" binding:"max=1000"`
-	Category    string                   `json:"category" binding:"max=100"`
-	Superstate  string                   `json:"superstate" binding:"max=100"`
-	State       string                   `json:"state" binding:"max=100"`
-	Items       []CreateBallotItemRequest `json:"items" binding:"required,min=2"`
+	Title       string `json:"title" binding:"required,min=1,max=200"`
+	Description string `json:"description" binding:"max=1000"`
+	Category    string `json:"category" binding:"max=100"`
+	Superstate  string `json:"superstate" binding:"max=100"`
+	State       string `json:"state" binding:"max=100"`
+	BallotType  string `json:"ballot_type" binding:"omitempty,oneof=plurality ranked approval multi_select score"`
+	// Defaults to true when omitted
+	AllowVoteRetraction *bool `json:"allow_vote_retraction"`
+	// Votes needed before results declare a winner; no quorum when omitted
+	MinimumQuorum *int `json:"minimum_quorum" binding:"omitempty,min=1"`
+	// Ballot is closed automatically once this time passes; open-ended when omitted
+	ClosesAt *time.Time `json:"closes_at"`
+	// Creates the ballot inactive so it can be published later with activate_at
+	Draft bool                      `json:"draft"`
+	Items []CreateBallotItemRequest `json:"items" binding:"required,min=2"`
 }
 
 type CreateBallotItemRequest struct {
@@ -48,7 +329,77 @@ type CreateBallotItemRequest struct {
 	Description string `json:"description" binding:"max=500"`
 }
 
+type MultiVoteRequest struct {
+	BallotItemIDs []int `json:"ballot_item_ids" binding:"required,min=1"`
+}
+
+// ScoreVoteRequest is one item's score in a score vote submission, which is a JSON
+// array with an entry for every item on the ballot.
+type ScoreVoteRequest struct {
+	BallotItemID int  `json:"ballot_item_id" binding:"required"`
+	Score        *int `json:"score" binding:"required,min=0,max=10"`
+}
+
+// RankedVoteRequest is a voter's preference order on a ranked ballot, rank 1 being
+// their first choice. Voters may leave items unranked, but the ranks they give must
+// run 1, 2, 3 without gaps.
+type RankedVoteRequest struct {
+	Rankings []RankedChoice `json:"rankings" binding:"required,min=1,dive"`
+}
+
+type RankedChoice struct {
+	BallotItemID int `json:"ballot_item_id" binding:"required"`
+	Rank         int `json:"rank" binding:"required,min=1"`
+}
+
+// RankedResults is the instant-runoff count of a ranked ballot. WinnerID is nil
+// until a ranking has been cast.
+type RankedResults struct {
+	BallotID          int                   `json:"ballot_id"`
+	WinnerID          *int                  `json:"winner_id"`
+	WinnerTitle       *string               `json:"winner_title"`
+	EliminationRounds []IRVEliminationRound `json:"elimination_rounds"`
+	TotalVoters       int                   `json:"total_voters"`
+}
+
+type UpdateBallotRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type BallotCoCreator struct {
+	BallotID int       `json:"ballot_id" db:"ballot_id"`
+	UserID   int       `json:"user_id" db:"user_id"`
+	AddedBy  int       `json:"added_by" db:"added_by"`
+	AddedAt  time.Time `json:"added_at" db:"added_at"`
+}
+
+type AddCoCreatorRequest struct {
+	UserID int `json:"user_id" binding:"required"`
+}
+
+type BallotSponsor struct {
+	BallotID         int       `json:"ballot_id" db:"ballot_id"`
+	OrganizationName string    `json:"organization_name" db:"organization_name"`
+	SponsorURL       string    `json:"sponsor_url" db:"sponsor_url"`
+	SponsoredAt      time.Time `json:"sponsored_at" db:"sponsored_at"`
+}
+
+type SponsorBallotRequest struct {
+	OrganizationName string `json:"organization_name" binding:"required,max=200"`
+	SponsorURL       string `json:"sponsor_url" binding:"omitempty,url,max=2048"`
+}
+
+type ScheduleActivationRequest struct {
+	ActivateAt time.Time `json:"activate_at" binding:"required"`
+}
+
+type ScheduleDeactivationRequest struct {
+	DeactivateAt time.Time `json:"deactivate_at" binding:"required"`
+}
+
 type VoteRequest struct {
 	BallotItemID int `json:"ballot_item_id"`
 	OptionID     int `json:"option_id"` // Frontend sends "option_id"
-}
\ No newline at end of file
+}
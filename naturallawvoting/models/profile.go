@@ -61,7 +61,7 @@ type CreateUserProfileRequest struct {
 	Gender            string   `json:"gender"`
 	MothersMaidenName string   `json:"mothers_maiden_name"`
 	PhoneNumber       string   `json:"phone_number"`
-	AdditionalEmails  []string `json:"additional_emails"`
+	AdditionalEmails  []string `json:"additional_emails" binding:"dive,email"`
 }
 
 type UpdateUserProfileRequest struct {
@@ -70,7 +70,7 @@ type UpdateUserProfileRequest struct {
 	Gender            *string  `json:"gender"`
 	MothersMaidenName *string  `json:"mothers_maiden_name"`
 	PhoneNumber       *string  `json:"phone_number"`
-	AdditionalEmails  []string `json:"additional_emails"`
+	AdditionalEmails  []string `json:"additional_emails" binding:"dive,email"`
 }
 
 type CreateUserAddressRequest struct {
@@ -154,3 +154,42 @@ type UpdateEconomicInfoRequest struct {
 	SupportAltComm               *string  `json:"support_alt_comm"`
 	AdditionalText               *string  `json:"additional_text"`
 }
+
+// FullUserProfile combines the account with every profile section. Sections the
+// user has not filled in are null.
+type FullUserProfile struct {
+	User                 User                      `json:"user"`
+	Profile              *UserProfile              `json:"profile"`
+	Address              *UserAddress              `json:"address"`
+	PoliticalAffiliation *UserPoliticalAffiliation `json:"political_affiliation"`
+	ReligiousAffiliation *UserReligiousAffiliation `json:"religious_affiliation"`
+	RaceEthnicity        *UserRaceEthnicity        `json:"race_ethnicity"`
+	EconomicInfo         *EconomicInfo             `json:"economic_info"`
+}
+
+// ProfileCompleteness reports which profile sections the user has filled in.
+// Section names match the /profile/<section> routes.
+type ProfileCompleteness struct {
+	ScorePct  int      `json:"score_pct"`
+	Missing   []string `json:"missing"`
+	Completed []string `json:"completed"`
+}
+
+// UserDataExport is everything stored about a user, as downloaded from
+// /profile/export. Profile sections the user has not filled in are null.
+type UserDataExport struct {
+	FullUserProfile
+	Votes      []ExportedVote `json:"votes"`
+	ExportedAt time.Time      `json:"exported_at"`
+}
+
+// ExportedVote is one ballot item the user voted for. Multi-select, score and
+// ranked ballots contribute one entry per item; Score and Rank are only set for
+// score and ranked ballots.
+type ExportedVote struct {
+	BallotID     int       `json:"ballot_id"`
+	BallotItemID int       `json:"ballot_item_id"`
+	Score        *int      `json:"score,omitempty"`
+	Rank         *int      `json:"rank,omitempty"`
+	VotedAt      time.Time `json:"voted_at"`
+}
@@ -9,6 +9,7 @@ type User struct {
 	Username  string    `json:"username" db:"username"`
 	Email     string    `json:"email" db:"email"`
 	Password  string    `json:"-" db:"password_hash"`
+	Role      string    `json:"role,omitempty" db:"role"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -24,7 +25,87 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+type DeleteAccountRequest struct {
+	ConfirmPassword string `json:"confirm_password" binding:"required"`
+}
+
+type ImpersonateRequest struct {
+	UserID int `json:"user_id" binding:"required"`
+}
+
+type ImpersonateResponse struct {
+	Token     string    `json:"token"`
+	UserID    int       `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ImpersonationSession is one row of impersonation_audit with both usernames resolved.
+type ImpersonationSession struct {
+	AdminID        int       `json:"admin_id"`
+	AdminUsername  string    `json:"admin_username"`
+	TargetUserID   int       `json:"target_user_id"`
+	TargetUsername string    `json:"target_username"`
+	StartedAt      time.Time `json:"started_at"`
+	TokenExpiry    time.Time `json:"token_expiry"`
+}
+
+// AdminDeleteUserRequest must repeat the target as DELETE_USER_<id> so an account
+// cannot be deleted by a mistyped ID.
+type AdminDeleteUserRequest struct {
+	ConfirmDelete string `json:"CONFIRM_DELETE" binding:"required"`
+}
+
+// AdminUserSummary is one row of the admin user listing.
+type AdminUserSummary struct {
+	ID        int        `json:"id"`
+	Username  string     `json:"username"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type TopVoter struct {
+	UserID       int       `json:"user_id"`
+	Username     string    `json:"username"`
+	Email        string    `json:"email"`
+	FullName     *string   `json:"full_name"`
+	VoteCount    int       `json:"vote_count"`
+	BallotsVoted int       `json:"ballots_voted"`
+	LastVote     time.Time `json:"last_vote"`
+}
+
+// VoteExportRow is one vote in an admin vote export. It carries no user PII; the
+// demographic buckets are only filled in when a user segment is requested.
+type VoteExportRow struct {
+	VoteID         int       `json:"vote_id"`
+	BallotID       int       `json:"ballot_id"`
+	BallotItemID   int       `json:"ballot_item_id"`
+	ItemTitle      string    `json:"item_title"`
+	VotedAt        time.Time `json:"voted_at"`
+	WasChanged     bool      `json:"was_changed"`
+	PreviousItemID *int      `json:"previous_item_id"`
+	AgeRange       string    `json:"age_range,omitempty"`
+	Gender         string    `json:"gender,omitempty"`
+	PoliticalParty string    `json:"political_party,omitempty"`
+}
+
 type AuthResponse struct {
-	Token string `json:"token"`
-	User  User   `json:"user"`
-}
\ No newline at end of file
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	User         User   `json:"user"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type RefreshTokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"net/mail"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Request structs are bound through Gin, so its validator needs the custom rules
+// registered before any request is handled.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("email", validEmail)
+	}
+}
+
+// validEmail replaces the validator's built-in email rule with net/mail parsing,
+// accepting only a bare address. Display-name forms like "Ann <ann@example.com>"
+// parse as valid mail headers but are not something we can send to.
+func validEmail(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	addr, err := mail.ParseAddress(value)
+	return err == nil && addr.Address == value
+}
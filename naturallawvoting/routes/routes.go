@@ -1,118 +1,207 @@
 package routes
 
 import (
+	"os"
+	"time"
+	"voting-api/cache"
 	"voting-api/database"
 	"voting-api/handlers"
 	"voting-api/middleware"
+	"voting-api/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(db *database.DB) *gin.Engine {
-	r := gin.Default()
-
-	// CORS middleware
-	r.Use(func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
-		
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-		
-		c.Next()
-	})
+// Login and registration are limited per client IP to slow down brute-force attempts.
+// Username checks fire as the user types, so they get a separate, larger budget.
+const (
+	authRateLimit          = 10
+	authRateWindow         = time.Minute
+	usernameCheckRateLimit = 60
+)
 
-	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(db)
-	ballotHandler := handlers.NewBallotHandler(db)
-	voteHandler := handlers.NewVoteHandler(db)
-	profileHandler := handlers.NewProfileHandler(db)
+func SetupRoutes(db *database.DB, ballotCache cache.Cacher) *gin.Engine {
+	r := gin.New()
 
-	// Health check
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	// Structured request logging runs first so every request gets a correlation ID
+	r.Use(middleware.RequestLogger(), gin.Recovery())
 
-	// API routes
-	api := r.Group("/api/v1")
-	{
-		// Public routes (no authentication required)
-		auth := api.Group("/auth")
-		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-		}
+	// CORS, limited to ALLOWED_ORIGINS when it is set
+	r.Use(middleware.CORS(middleware.ParseAllowedOrigins(os.Getenv("ALLOWED_ORIGINS"))))
 
-		// Public ballot routes (read-only)
-		public := api.Group("/public")
-		{
-			public.GET("/ballots", ballotHandler.GetAllBallots)
-			public.GET("/ballots/:id", ballotHandler.GetBallot)
-			public.GET("/ballots/:id/results", voteHandler.GetBallotResults)
-
-			// Superstate and state routes for local civil government
-			public.GET("/superstates", ballotHandler.GetSuperstates)
-			public.GET("/superstates/:superstate/states", ballotHandler.GetStates)
-		}
+	// Request bodies must be JSON
+	r.Use(middleware.RequireJSON())
 
-		// Protected routes (authentication required)
-		protected := api.Group("/")
-		protected.Use(middleware.AuthMiddleware())
+	// Initialize handlers
+	authHandler := handlers.NewAuthHandler(db)
+	ballotHandler := handlers.NewBallotHandler(db, ballotCache)
+	voteHandler := handlers.NewVoteHandler(db)
+	profileHandler := handlers.NewProfileHandler(db)
+	analyticsHandler := handlers.NewAnalyticsHandler(db)
+	healthHandler := handlers.NewHealthHandler(db)
+	adminHandler := handlers.NewAdminHandler(db, ballotCache, services.NewAuditLogger(db))
+
+	// Health check, including database reachability
+	r.GET("/health", healthHandler.Check)
+
+	// Sitemap for search engines, served at the conventional root path
+	r.GET("/sitemap.xml", ballotHandler.GetSitemapXML)
+
+	// Every API version serves the same routes and handlers; see apiVersions.
+	// The auth rate limiters are shared so each version doesn't get its own budget.
+	authRateLimiter := middleware.RateLimitMiddleware(authRateLimit, authRateWindow)
+	usernameCheckRateLimiter := middleware.RateLimitMiddleware(usernameCheckRateLimit, authRateWindow)
+	for _, version := range apiVersions {
+		api := apiGroup(r, version)
 		{
-			// User profile
-			protected.GET("/profile", authHandler.GetProfile)
-
-			// User's ballots
-			protected.GET("/my-ballots", ballotHandler.GetUserBallots)
-
-			// Ballot management
-			protected.POST("/ballots", ballotHandler.CreateBallot)
-
-			// Voting
-			protected.POST("/ballots/:ballot_id/vote", voteHandler.Vote)
-			protected.GET("/ballots/:ballot_id/my-vote", voteHandler.GetUserVote)
-
-			// Profile information routes
-			// User Profile
-			protected.GET("/profile/info", profileHandler.GetUserProfile)
-			protected.POST("/profile/info", profileHandler.CreateUserProfile)
-			protected.PUT("/profile/info", profileHandler.UpdateUserProfile)
-			protected.DELETE("/profile/info", profileHandler.DeleteUserProfile)
-
-			// User Address
-			protected.GET("/profile/address", profileHandler.GetUserAddress)
-			protected.POST("/profile/address", profileHandler.CreateUserAddress)
-			protected.PUT("/profile/address", profileHandler.UpdateUserAddress)
-			protected.DELETE("/profile/address", profileHandler.DeleteUserAddress)
-
-			// User Political Affiliation
-			protected.GET("/profile/political", profileHandler.GetUserPoliticalAffiliation)
-			protected.POST("/profile/political", profileHandler.CreateUserPoliticalAffiliation)
-			protected.PUT("/profile/political", profileHandler.UpdateUserPoliticalAffiliation)
-			protected.DELETE("/profile/political", profileHandler.DeleteUserPoliticalAffiliation)
-
-			// User Religious Affiliation
-			protected.GET("/profile/religious", profileHandler.GetUserReligiousAffiliation)
-			protected.POST("/profile/religious", profileHandler.CreateUserReligiousAffiliation)
-			protected.PUT("/profile/religious", profileHandler.UpdateUserReligiousAffiliation)
-			protected.DELETE("/profile/religious", profileHandler.DeleteUserReligiousAffiliation)
-
-			// User Race/Ethnicity
-			protected.GET("/profile/race-ethnicity", profileHandler.GetUserRaceEthnicity)
-			protected.POST("/profile/race-ethnicity", profileHandler.CreateUserRaceEthnicity)
-			protected.PUT("/profile/race-ethnicity", profileHandler.UpdateUserRaceEthnicity)
-			protected.DELETE("/profile/race-ethnicity", profileHandler.DeleteUserRaceEthnicity)
-
-			// Economic Info
-			protected.GET("/profile/economic", profileHandler.GetEconomicInfo)
-			protected.POST("/profile/economic", profileHandler.CreateEconomicInfo)
-			protected.PUT("/profile/economic", profileHandler.UpdateEconomicInfo)
-			protected.DELETE("/profile/economic", profileHandler.DeleteEconomicInfo)
+			// Public routes (no authentication required)
+			auth := api.Group("/auth")
+			{
+				auth.POST("/register", authRateLimiter, authHandler.Register)
+				auth.POST("/login", authRateLimiter, authHandler.Login)
+				auth.POST("/refresh", authRateLimiter, authHandler.RefreshToken)
+				auth.GET("/check-username", usernameCheckRateLimiter, authHandler.CheckUsername)
+			}
+
+			// Public ballot routes (read-only)
+			public := api.Group("/public")
+			{
+				public.GET("/ballots", middleware.AuthMiddlewareOptional(), ballotHandler.GetAllBallots)
+				public.GET("/ballots/search", ballotHandler.SearchBallots)
+				public.GET("/ballots/count", ballotHandler.CountBallots)
+				public.GET("/categories", ballotHandler.GetCategories)
+				public.GET("/ballots/:id", middleware.AuthMiddlewareOptional(), ballotHandler.GetBallot)
+				public.GET("/ballots/:id/results", voteHandler.GetBallotResults)
+				public.GET("/ballots/:id/results/stream", voteHandler.StreamBallotResults)
+				public.GET("/ballots/:id/ranked-results", voteHandler.GetRankedResults)
+				public.GET("/ballots/:id/results/export-pdf", middleware.AuthMiddlewareOptional(), voteHandler.ExportBallotResultsPDF)
+				public.GET("/ballots/:id/qr-code", ballotHandler.GetBallotQRCode)
+				public.GET("/ballots/:id/accessibility", ballotHandler.GetBallotAccessibility)
+				public.GET("/ballots/:id/announcements", ballotHandler.GetAnnouncements)
+				public.GET("/ballots/:id/feed.rss", ballotHandler.GetBallotFeed)
+				public.GET("/ballots/:id/feed.atom", ballotHandler.GetBallotFeed)
+				public.GET("/ballots/:id/item-correlation", voteHandler.GetItemCorrelation)
+				public.GET("/ballots/:id/activity-heatmap", voteHandler.GetActivityHeatmap)
+				public.GET("/ballots/:id/leading-item-timeline", voteHandler.GetLeadingItemTimeline)
+				public.GET("/ballots/:id/voters-map", voteHandler.GetVotersMap)
+				public.GET("/ballots/:id/voters", voteHandler.GetVoterStats)
+				public.GET("/ballots/:id/participants-count", voteHandler.GetParticipantsCount)
+				public.GET("/ballots/:id/changelog", ballotHandler.GetChangelog)
+				public.GET("/ballots/:id/sponsors", ballotHandler.GetBallotSponsors)
+
+				// Superstate and state routes for local civil government
+				public.GET("/superstates", ballotHandler.GetSuperstates)
+				public.GET("/superstates/:superstate/states", ballotHandler.GetStates)
+				public.GET("/superstates/:superstate/ballots", ballotHandler.GetSuperstateBallots)
+				public.GET("/superstates/:superstate/analytics", analyticsHandler.GetSuperstateAnalytics)
+				public.GET("/superstates/:superstate/results", voteHandler.GetSuperstateResults)
+				public.GET("/states/:state/ballots", ballotHandler.GetBallotsByState)
+			}
+
+			// Protected routes (authentication required)
+			protected := api.Group("/")
+			protected.Use(middleware.AuthMiddleware())
+			{
+				// User profile
+				protected.GET("/profile", authHandler.GetProfile)
+				protected.PUT("/profile/password", authHandler.ChangePassword)
+				protected.DELETE("/profile/account", authHandler.DeleteAccount)
+
+				// User's ballots
+				protected.GET("/my-ballots", ballotHandler.GetUserBallots)
+				protected.GET("/my-votes", voteHandler.GetUserVoteHistory)
+				protected.GET("/my-votes/count", voteHandler.CountUserVotes)
+
+				// Ballot management
+				protected.POST("/ballots", ballotHandler.CreateBallot)
+				protected.POST("/ballots/check-duplicate", ballotHandler.CheckDuplicateBallots)
+				protected.PATCH("/ballots/:id", ballotHandler.UpdateBallot)
+				protected.PUT("/ballots/:id/activate-at", ballotHandler.ScheduleActivation)
+				protected.PUT("/ballots/:id/deactivate-at", ballotHandler.ScheduleDeactivation)
+				protected.POST("/ballots/:ballot_id/announcements", ballotHandler.CreateAnnouncement)
+				protected.GET("/ballots/:ballot_id", ballotHandler.GetBallotWithUserVote)
+				protected.DELETE("/ballots/:ballot_id", ballotHandler.DeleteBallot)
+				protected.GET("/ballots/:ballot_id/archived", ballotHandler.GetArchivedBallot)
+				protected.POST("/ballots/:ballot_id/lock", ballotHandler.LockBallot)
+				protected.POST("/ballots/:ballot_id/unlock", ballotHandler.UnlockBallot)
+				protected.POST("/ballots/:ballot_id/close", ballotHandler.CloseBallot)
+				protected.POST("/ballots/:ballot_id/reopen", ballotHandler.ReopenBallot)
+				protected.POST("/ballots/:ballot_id/add-co-creator", ballotHandler.AddCoCreator)
+				protected.DELETE("/ballots/:ballot_id/remove-co-creator/:user_id", ballotHandler.RemoveCoCreator)
+				protected.POST("/ballots/:ballot_id/sponsor", middleware.AdminRequired(db), ballotHandler.SponsorBallot)
+
+				// Voting
+				protected.POST("/ballots/:ballot_id/vote", voteHandler.Vote)
+				protected.POST("/ballots/:ballot_id/multi-vote", voteHandler.MultiVote)
+				protected.POST("/ballots/:ballot_id/score-vote", voteHandler.ScoreVote)
+				protected.POST("/ballots/:ballot_id/ranked-vote", voteHandler.RankedVote)
+				protected.GET("/ballots/:ballot_id/my-vote", voteHandler.GetUserVote)
+				protected.GET("/ballots/:ballot_id/items/:item_id/votes", voteHandler.GetItemVotes)
+				protected.DELETE("/ballots/:ballot_id/my-vote", voteHandler.RetractVote)
+				protected.DELETE("/ballots/:ballot_id/vote", voteHandler.RetractVote)
+
+				// Profile information routes
+				// User Profile
+				protected.GET("/profile/full", profileHandler.GetFullProfile)
+				protected.GET("/profile/completeness", profileHandler.GetProfileCompleteness)
+				protected.GET("/profile/export", profileHandler.ExportUserData)
+				protected.GET("/profile/info", profileHandler.GetUserProfile)
+				protected.POST("/profile/info", profileHandler.CreateUserProfile)
+				protected.PUT("/profile/info", profileHandler.UpdateUserProfile)
+				protected.DELETE("/profile/info", profileHandler.DeleteUserProfile)
+
+				// User Address
+				protected.GET("/profile/address", profileHandler.GetUserAddress)
+				protected.POST("/profile/address", profileHandler.CreateUserAddress)
+				protected.PUT("/profile/address", profileHandler.UpdateUserAddress)
+				protected.DELETE("/profile/address", profileHandler.DeleteUserAddress)
+
+				// User Political Affiliation
+				protected.GET("/profile/political", profileHandler.GetUserPoliticalAffiliation)
+				protected.POST("/profile/political", profileHandler.CreateUserPoliticalAffiliation)
+				protected.PUT("/profile/political", profileHandler.UpdateUserPoliticalAffiliation)
+				protected.DELETE("/profile/political", profileHandler.DeleteUserPoliticalAffiliation)
+
+				// User Religious Affiliation
+				protected.GET("/profile/religious", profileHandler.GetUserReligiousAffiliation)
+				protected.POST("/profile/religious", profileHandler.CreateUserReligiousAffiliation)
+				protected.PUT("/profile/religious", profileHandler.UpdateUserReligiousAffiliation)
+				protected.DELETE("/profile/religious", profileHandler.DeleteUserReligiousAffiliation)
+
+				// User Race/Ethnicity
+				protected.GET("/profile/race-ethnicity", profileHandler.GetUserRaceEthnicity)
+				protected.POST("/profile/race-ethnicity", profileHandler.CreateUserRaceEthnicity)
+				protected.PUT("/profile/race-ethnicity", profileHandler.UpdateUserRaceEthnicity)
+				protected.DELETE("/profile/race-ethnicity", profileHandler.DeleteUserRaceEthnicity)
+
+				// Economic Info
+				protected.GET("/profile/economic", profileHandler.GetEconomicInfo)
+				protected.POST("/profile/economic", profileHandler.CreateEconomicInfo)
+				protected.PUT("/profile/economic", profileHandler.UpdateEconomicInfo)
+				protected.DELETE("/profile/economic", profileHandler.DeleteEconomicInfo)
+			}
+
+			// Admin routes (authentication and admin role required)
+			admin := api.Group("/admin")
+			admin.Use(middleware.AuthMiddleware(), middleware.AdminRequired(db))
+			{
+				admin.GET("/schema/validate", adminHandler.ValidateSchema)
+				admin.POST("/impersonate", adminHandler.Impersonate)
+				admin.GET("/users", adminHandler.ListUsers)
+				admin.DELETE("/users/:id", adminHandler.AdminDeleteUser)
+				admin.GET("/users/:id/impersonation-log", adminHandler.GetUserImpersonationLog)
+				admin.GET("/impersonations/my-log", adminHandler.GetMyImpersonationLog)
+				admin.GET("/impersonations/active", adminHandler.GetActiveImpersonations)
+				admin.GET("/reports/top-voters", adminHandler.GetTopVoters)
+				admin.GET("/reports/ballot-creation-rate", adminHandler.GetBallotCreationRate)
+				admin.GET("/audit-log", adminHandler.GetAuditLog)
+				admin.GET("/votes/export", adminHandler.GetVoteExport)
+				admin.GET("/ballots/:id/changelog", ballotHandler.GetChangelogWithEditors)
+				admin.GET("/ballots/:id/diagnostic", adminHandler.GetBallotDiagnostic)
+				admin.PUT("/ballots/:id/deactivate", adminHandler.DeactivateBallot)
+			}
 		}
 	}
 
 	return r
-}
\ No newline at end of file
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"voting-api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versions are mounted side by side under /api/<version> and share one set of
+// handlers, which always produce the v1 response shape. A newer version differs
+// only through the response transformer listed for it in versionTransformers, so
+// a breaking change to a response belongs in a transformer rather than a handler.
+// Request bodies are the same across versions.
+var apiVersions = []string{"v1", "v2"}
+
+var versionTransformers = map[string]middleware.ResponseTransformer{
+	"v2": renameBallotOptions,
+}
+
+// apiGroup returns the route group for version with its response transformer, if
+// any, attached.
+func apiGroup(r *gin.Engine, version string) *gin.RouterGroup {
+	api := r.Group("/api/" + version)
+	if transform, ok := versionTransformers[version]; ok {
+		api.Use(middleware.TransformJSONResponse(transform))
+	}
+	return api
+}
+
+// renameBallotOptions serves ballot items under "items" instead of "options", the
+// key v1 keeps for the existing frontend. It applies at any depth, so ballots
+// nested in listings are renamed too.
+func renameBallotOptions(body interface{}) interface{} {
+	switch value := body.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			if key == "options" {
+				key = "items"
+			}
+			renamed[key] = renameBallotOptions(field)
+		}
+		return renamed
+	case []interface{}:
+		for i := range value {
+			value[i] = renameBallotOptions(value[i])
+		}
+		return value
+	}
+	return body
+}
@@ -0,0 +1,46 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// StripHTML returns only the text content of s. Markup is parsed rather than
+// pattern-matched, so malformed tags and attributes cannot slip through;
+// entities are decoded, script and style bodies are dropped entirely, and
+// control characters other than newlines and tabs are removed.
+func StripHTML(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		// The html parser only fails on reader errors, which a strings.Reader never returns
+		return stripControl(s)
+	}
+
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+			return
+		}
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	return strings.TrimSpace(stripControl(b.String()))
+}
+
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+	"voting-api/database"
+)
+
+// ActivateScheduledBallots publishes draft ballots whose activate_at time has passed.
+// activate_at is cleared in the same statement so a second run never re-activates a
+// ballot that has since been deactivated by hand. Deleted ballots stay archived and
+// ballots deactivated by an admin stay offline.
+func ActivateScheduledBallots(db *database.DB) (int64, error) {
+	result, err := db.Exec(`
+		UPDATE ballots SET is_active = true, activate_at = NULL
+		WHERE is_active = false AND deleted_at IS NULL AND moderated_at IS NULL
+		  AND activate_at IS NOT NULL AND activate_at <= NOW()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("error activating scheduled ballots: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// DeactivateScheduledBallots closes active ballots whose deactivate_at time has passed.
+func DeactivateScheduledBallots(db *database.DB) (int64, error) {
+	result, err := db.Exec(`
+		UPDATE ballots SET is_active = false, deactivate_at = NULL
+		WHERE is_active = true AND deactivate_at IS NOT NULL AND deactivate_at <= NOW()
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("error deactivating scheduled ballots: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// CloseExpiredBallots deactivates ballots whose closes_at time has passed. Unlike
+// deactivate_at, closes_at is kept afterwards so responses still show when voting ended.
+func CloseExpiredBallots(db *database.DB) (int64, error) {
+	result, err := db.Exec(`
+		UPDATE ballots SET is_active = false
+		WHERE closes_at IS NOT NULL AND closes_at <= NOW() AND is_active = true
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("error closing expired ballots: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// SnapshotBallotResults records the current standings of every active ballot that
+// has not been snapshotted in the last hour. Each snapshot is a JSON array of
+// {item_id, title, vote_count} objects.
+func SnapshotBallotResults(db *database.DB) (int64, error) {
+	result, err := db.Exec(`
+		INSERT INTO ballot_result_snapshots (ballot_id, results)
+		SELECT b.id, jsonb_agg(jsonb_build_object('item_id', bi.id, 'title', bi.title, 'vote_count', COALESCE(vc.vote_count, 0)) ORDER BY COALESCE(vc.vote_count, 0) DESC, bi.id)
+		FROM ballots b
+		JOIN ballot_items bi ON bi.ballot_id = b.id
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		WHERE b.is_active = true AND NOT EXISTS (
+			SELECT 1 FROM ballot_result_snapshots s WHERE s.ballot_id = b.id AND s.snapshotted_at > NOW() - INTERVAL '1 hour'
+		)
+		GROUP BY b.id
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("error snapshotting ballot results: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// RunOnce executes every scheduled ballot job a single time.
+func RunOnce(db *database.DB) {
+	if activated, err := ActivateScheduledBallots(db); err != nil {
+		log.Println(err)
+	} else if activated > 0 {
+		log.Printf("Activated %d scheduled ballot(s)", activated)
+	}
+
+	if deactivated, err := DeactivateScheduledBallots(db); err != nil {
+		log.Println(err)
+	} else if deactivated > 0 {
+		log.Printf("Deactivated %d scheduled ballot(s)", deactivated)
+	}
+
+	if closed, err := CloseExpiredBallots(db); err != nil {
+		log.Println(err)
+	} else if closed > 0 {
+		log.Printf("Closed %d expired ballot(s)", closed)
+	}
+
+	if _, err := SnapshotBallotResults(db); err != nil {
+		log.Println(err)
+	}
+}
+
+// Start runs the scheduled ballot jobs every interval in a background goroutine.
+// The returned function stops the goroutine.
+func Start(db *database.DB, interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				RunOnce(db)
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"voting-api/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogger records admin actions in admin_audit_log.
+type AuditLogger struct {
+	db *database.DB
+}
+
+func NewAuditLogger(db *database.DB) *AuditLogger {
+	return &AuditLogger{db: db}
+}
+
+// Log records an admin action against a target. The acting admin is read from the
+// "user_id" value on ctx, and the client IP is recorded when ctx is the request's
+// *gin.Context and known. A targetID of 0 is stored as NULL, as is a nil payload.
+func (l *AuditLogger) Log(ctx context.Context, action string, targetType string, targetID int, payload interface{}) error {
+	var adminID, target, encodedPayload, ipAddress interface{}
+	if id, ok := ctx.Value("user_id").(int); ok {
+		adminID = id
+	}
+	if targetID != 0 {
+		target = targetID
+	}
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("error encoding audit payload: %w", err)
+		}
+		encodedPayload = string(encoded)
+	}
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil && c.ClientIP() != "" {
+		ipAddress = c.ClientIP()
+	}
+
+	_, err := l.db.ExecContext(ctx,
+		"INSERT INTO admin_audit_log (admin_user_id, action, target_type, target_id, payload, ip_address) VALUES ($1, $2, $3, $4, $5, $6)",
+		adminID, action, targetType, target, encodedPayload, ipAddress,
+	)
+	if err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build ignore
+
+// repair_vote_counts recalculates ballot_items.vote_count from the recorded votes.
+// It is kept out of the setup package build so it can sit beside the seed script;
+// run it with:
+//
+//	go run repair_vote_counts.go [--dry-run]
+package main
+
+import (
+	"flag"
+	"log"
+	"voting-api/database"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print items whose vote_count has drifted without changing them")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	db, err := database.NewConnection()
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer db.Close()
+
+	if *dryRun {
+		discrepancies, err := db.VoteCountDiscrepancies()
+		if err != nil {
+			log.Fatal("Failed to compare vote counts:", err)
+		}
+		for _, d := range discrepancies {
+			log.Printf("ballot item %d: stored %d, actual %d", d.BallotItemID, d.Stored, d.Actual)
+		}
+		log.Printf("%d ballot items have drifted vote counts (dry run, nothing changed)", len(discrepancies))
+		return
+	}
+
+	updated, err := db.RepairVoteCounts()
+	if err != nil {
+		log.Fatal("Failed to repair vote counts:", err)
+	}
+	log.Printf("Repaired vote_count on %d ballot items", updated)
+}
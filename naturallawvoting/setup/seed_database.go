@@ -78,6 +78,12 @@ func main() {
 		log.Fatal("Failed to seed votes:", err)
 	}
 
+	// Seed State Populations
+	log.Println("Seeding state populations...")
+	if err := seedStatePopulations(db); err != nil {
+		log.Fatal("Failed to seed state populations:", err)
+	}
+
 	log.Println("Database seeded successfully!")
 }
 
@@ -2116,3 +2122,78 @@ func seedVotes(db *sql.DB) error {
 
 	return nil
 }
+
+func seedStatePopulations(db *sql.DB) error {
+	// 2020 US Census resident populations, keyed by the state slugs ballots use.
+	// States split into regional slugs (California, New York, Texas) have no
+	// census total per region and are left out.
+	populations := []struct {
+		stateCode  string
+		population int64
+	}{
+		{"connecticut", 3605944},
+		{"maine", 1362359},
+		{"massachusetts", 7029917},
+		{"new-hampshire", 1377529},
+		{"rhode-island", 1097379},
+		{"vermont", 643077},
+		{"delaware", 989948},
+		{"maryland", 6177224},
+		{"new-jersey", 9288994},
+		{"pennsylvania", 13002700},
+		{"washington-dc", 689545},
+		{"indiana", 6785528},
+		{"kentucky", 4505836},
+		{"michigan", 10077331},
+		{"ohio", 11799448},
+		{"north-carolina", 10439388},
+		{"south-carolina", 5118425},
+		{"virginia", 8631393},
+		{"west-virginia", 1793716},
+		{"florida", 21538187},
+		{"georgia", 10711908},
+		{"alabama", 5024279},
+		{"arkansas", 3011524},
+		{"louisiana", 4657757},
+		{"mississippi", 2961279},
+		{"missouri", 6154913},
+		{"tennessee", 6910840},
+		{"illinois", 12812508},
+		{"iowa", 3190369},
+		{"minnesota", 5706494},
+		{"north-dakota", 779094},
+		{"south-dakota", 886667},
+		{"wisconsin", 5893718},
+		{"arizona", 7151502},
+		{"colorado", 5773714},
+		{"kansas", 2937880},
+		{"nebraska", 1961504},
+		{"new-mexico", 2117522},
+		{"oklahoma", 3959353},
+		{"alaska", 733391},
+		{"hawaii", 1455271},
+		{"idaho", 1839106},
+		{"montana", 1084225},
+		{"nevada", 3104614},
+		{"oregon", 4237256},
+		{"utah", 3271616},
+		{"washington", 7705281},
+		{"wyoming", 576851},
+	}
+
+	for _, state := range populations {
+		query := `
+			INSERT INTO state_populations (state_code, population, year)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (state_code) DO UPDATE SET population = EXCLUDED.population, year = EXCLUDED.year
+		`
+
+		_, err := db.Exec(query, state.stateCode, state.population, 2020)
+		if err != nil {
+			return fmt.Errorf("failed to insert population for %s: %v", state.stateCode, err)
+		}
+	}
+
+	log.Printf("✓ State populations seeded: %d states", len(populations))
+	return nil
+}
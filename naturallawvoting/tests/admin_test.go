@@ -0,0 +1,1222 @@
+package tests
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voting-api/database"
+	"voting-api/models"
+	"voting-api/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schemaColumnsSQL = "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = 'public'"
+
+const auditLogInsertSQL = "INSERT INTO admin_audit_log (admin_user_id, action, target_type, target_id, payload, ip_address) VALUES ($1, $2, $3, $4, $5, $6)"
+
+// schemaRows builds an information_schema result matching the expected schema,
+// leaving out the given table/column pair.
+func schemaRows(skipTable, skipColumn string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"table_name", "column_name", "data_type"})
+	for table, columns := range database.ExpectedSchema() {
+		for column, dataType := range columns {
+			if table == skipTable && column == skipColumn {
+				continue
+			}
+			rows.AddRow(table, column, dataType)
+		}
+	}
+	return rows
+}
+
+func TestValidateSchema(t *testing.T) {
+	t.Run("Schema Matches", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(schemaColumnsSQL).WillReturnRows(schemaRows("", ""))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "schema.validate", "schema", nil, `{"discrepancies":0}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/schema/validate", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, true, response["valid"])
+		assert.Empty(t, response["discrepancies"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Column Reported", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(schemaColumnsSQL).WillReturnRows(schemaRows("ballots", "activate_at"))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "schema.validate", "schema", nil, `{"discrepancies":1}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/schema/validate", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Valid         bool                         `json:"valid"`
+			Discrepancies []database.SchemaDiscrepancy `json:"discrepancies"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.False(t, response.Valid)
+		assert.Equal(t, []database.SchemaDiscrepancy{
+			{Table: "ballots", Column: "activate_at", Issue: "missing"},
+		}, response.Discrepancies)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Admin Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/schema/validate", nil, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Admin access required")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestImpersonate(t *testing.T) {
+	t.Run("Successful Impersonation", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT email FROM users WHERE id = $1").
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("target@example.com"))
+		testSetup.Mock.ExpectExec("INSERT INTO impersonation_audit (admin_id, target_user_id, started_at, ended_at) VALUES ($1, $2, $3, $4)").
+			WithArgs(1, 5, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "user.impersonate", "user", 5, sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/admin/impersonate", models.ImpersonateRequest{UserID: 5}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response models.ImpersonateResponse
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+		assert.Equal(t, 5, response.UserID)
+
+		claims, err := utils.ValidateJWT(response.Token)
+		require.NoError(t, err)
+		assert.Equal(t, float64(5), claims["user_id"])
+		assert.Equal(t, "target@example.com", claims["email"])
+		assert.Equal(t, float64(1), claims["impersonated_by"])
+
+		expiresIn := time.Until(time.Unix(int64(claims["exp"].(float64)), 0))
+		assert.True(t, expiresIn > 29*time.Minute && expiresIn <= 30*time.Minute)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Target User Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT email FROM users WHERE id = $1").
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/admin/impersonate", models.ImpersonateRequest{UserID: 99}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Vote Blocked While Impersonating", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		token, err := utils.GenerateImpersonationJWT(5, "target@example.com", 1, 30*time.Minute)
+		require.NoError(t, err)
+
+		req, err := CreateTestRequest("POST", "/api/v1/ballots/1/vote", models.VoteRequest{BallotItemID: 1})
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Cannot vote while impersonating")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestImpersonationLog(t *testing.T) {
+	const impersonationLogSelect = `SELECT ia.admin_id, a.username, ia.target_user_id, t.username, ia.started_at,
+		ia.started_at + INTERVAL '30 minutes' AS token_expiry
+		FROM impersonation_audit ia
+		JOIN users a ON a.id = ia.admin_id
+		JOIN users t ON t.id = ia.target_user_id`
+	const orderBy = " ORDER BY ia.started_at DESC"
+	sessionColumns := []string{"admin_id", "username", "target_user_id", "username", "started_at", "token_expiry"}
+	startedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Sessions For Target User", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(impersonationLogSelect + " WHERE ia.target_user_id = $1" + orderBy).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(sessionColumns).
+				AddRow(1, "admin", 5, "target", startedAt, startedAt.Add(30*time.Minute)))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/users/5/impersonation-log", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response []map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		require.Len(t, response, 1)
+		assert.Equal(t, map[string]interface{}{
+			"admin_id":        float64(1),
+			"admin_username":  "admin",
+			"target_user_id":  float64(5),
+			"target_username": "target",
+			"started_at":      "2026-03-04T10:00:00Z",
+			"token_expiry":    "2026-03-04T10:30:00Z",
+		}, response[0])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid User ID", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/users/abc/impersonation-log", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid user ID")
+	})
+
+	t.Run("Own Log Is Scoped To Calling Admin", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "admin")
+		testSetup.Mock.ExpectQuery(impersonationLogSelect + " WHERE ia.admin_id = $1" + orderBy).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(sessionColumns))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/impersonations/my-log", nil, 2, "admin2@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, "[]", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Active Sessions Use Token Lifetime", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recent := time.Now().UTC().Add(-10 * time.Minute).Truncate(time.Second)
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(impersonationLogSelect + " WHERE ia.started_at + INTERVAL '30 minutes' > NOW()" + orderBy).
+			WillReturnRows(sqlmock.NewRows(sessionColumns).
+				AddRow(3, "moderator", 7, "voter", recent, recent.Add(30*time.Minute)))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/impersonations/active", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response []models.ImpersonationSession
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		require.Len(t, response, 1)
+		assert.Equal(t, 3, response[0].AdminID)
+		assert.Equal(t, 7, response[0].TargetUserID)
+		assert.True(t, response[0].TokenExpiry.After(time.Now()))
+		assert.Equal(t, 30*time.Minute, response[0].TokenExpiry.Sub(response[0].StartedAt))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Requires Admin", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(4, "user")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/impersonations/active", nil, 4, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminDeleteUser(t *testing.T) {
+	const softDeleteSQL = "UPDATE users SET deleted_at = NOW(), username = $2, email = $3, password_hash = '' WHERE id = $1 AND deleted_at IS NULL"
+
+	t.Run("Soft Deletes And Anonymizes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("DELETE FROM user_profiles WHERE user_id = $1").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec(softDeleteSQL).
+			WithArgs(5, "deleted_user_5", "deleted_5@deleted.invalid").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND revoked = false").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		testSetup.Mock.ExpectExec("UPDATE votes SET user_id = NULL WHERE user_id = $1").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		testSetup.Mock.ExpectCommit()
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "user.delete", "user", 5, `{"votes_detached":3}`, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/5", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_5"}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Refresh Fails After Delete", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		const refreshToken = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("DELETE FROM user_profiles WHERE user_id = $1").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		testSetup.Mock.ExpectExec(softDeleteSQL).
+			WithArgs(5, "deleted_user_5", "deleted_5@deleted.invalid").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND revoked = false").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE votes SET user_id = NULL WHERE user_id = $1").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		testSetup.Mock.ExpectCommit()
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "user.delete", "user", 5, `{"votes_detached":0}`, nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/5", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_5"}, 1, "admin@example.com")
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		// The lookup skips deleted accounts, so the user's token no longer matches
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT rt.id, rt.user_id, u.email, u.role, rt.expires_at, rt.revoked FROM refresh_tokens rt JOIN users u ON u.id = rt.user_id WHERE rt.token_hash = $1 AND u.deleted_at IS NULL FOR UPDATE OF rt").
+			WithArgs(utils.HashRefreshToken(refreshToken)).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectRollback()
+
+		req, err = CreateTestRequest("POST", "/api/v1/auth/refresh", models.RefreshTokenRequest{RefreshToken: refreshToken})
+		require.NoError(t, err)
+		recorder = httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Invalid refresh token")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Confirmation Mismatch", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/5", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_6"}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "CONFIRM_DELETE must be DELETE_USER_5")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Confirmation", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/5", map[string]string{}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Deleted Or Missing", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("DELETE FROM user_profiles WHERE user_id = $1").
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		testSetup.Mock.ExpectExec(softDeleteSQL).
+			WithArgs(99, "deleted_user_99", "deleted_99@deleted.invalid").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		testSetup.Mock.ExpectRollback()
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/99", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_99"}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Delete Self", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/1", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_1"}, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Cannot delete yourself")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Admin Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/admin/users/5", models.AdminDeleteUserRequest{ConfirmDelete: "DELETE_USER_5"}, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 403, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBallotDiagnostic(t *testing.T) {
+	const ballotDiagnosticSQL = `WITH ballot AS (
+			SELECT id FROM ballots WHERE id = $1
+		),
+		actual AS (
+			SELECT ballot_item_id, COUNT(*) AS vote_count
+			FROM votes
+			WHERE ballot_id = $1
+			GROUP BY ballot_item_id
+		),
+		orphaned AS (
+			SELECT COUNT(*) AS vote_count
+			FROM votes v
+			WHERE v.ballot_id = $1
+			AND NOT EXISTS (SELECT 1 FROM ballot_items bi WHERE bi.id = v.ballot_item_id AND bi.ballot_id = v.ballot_id)
+		),
+		duplicates AS (
+			SELECT COALESCE(SUM(vote_count - 1), 0) AS vote_count
+			FROM (
+				SELECT COUNT(*) AS vote_count
+				FROM votes
+				WHERE ballot_id = $1 AND user_id IS NOT NULL
+				GROUP BY user_id
+				HAVING COUNT(*) > 1
+			) repeated
+		)
+		SELECT bi.id, bi.vote_count, COALESCE(a.vote_count, 0), o.vote_count, d.vote_count
+		FROM ballot b
+		CROSS JOIN orphaned o
+		CROSS JOIN duplicates d
+		LEFT JOIN ballot_items bi ON bi.ballot_id = b.id
+		LEFT JOIN actual a ON a.ballot_item_id = bi.id
+		ORDER BY bi.id`
+	diagnosticColumns := []string{"id", "vote_count", "actual_vote_count", "orphaned_votes", "duplicate_votes"}
+
+	type diagnosticResponse struct {
+		BallotID        int                           `json:"ballot_id"`
+		VoteCountMatch  bool                          `json:"vote_count_match"`
+		Items           []models.BallotDiagnosticItem `json:"items"`
+		TotalVotesMatch bool                          `json:"total_votes_match"`
+		OrphanedVotes   int                           `json:"orphaned_votes"`
+		DuplicateVotes  int                           `json:"duplicate_votes"`
+	}
+
+	t.Run("Healthy Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(ballotDiagnosticSQL).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(diagnosticColumns).
+				AddRow(1, 4, 4, 0, 0).
+				AddRow(2, 2, 2, 0, 0))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/ballots/7/diagnostic", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response diagnosticResponse
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, diagnosticResponse{
+			BallotID:       7,
+			VoteCountMatch: true,
+			Items: []models.BallotDiagnosticItem{
+				{ItemID: 1, StoredVoteCount: 4, ActualVoteCount: 4},
+				{ItemID: 2, StoredVoteCount: 2, ActualVoteCount: 2},
+			},
+			TotalVotesMatch: true,
+		}, response)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Discrepancies Reported", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(ballotDiagnosticSQL).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(diagnosticColumns).
+				AddRow(1, 5, 3, 1, 1).
+				AddRow(2, 2, 2, 1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/ballots/7/diagnostic", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response diagnosticResponse
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.False(t, response.VoteCountMatch)
+		assert.False(t, response.TotalVotesMatch)
+		assert.Equal(t, 1, response.OrphanedVotes)
+		assert.Equal(t, 1, response.DuplicateVotes)
+		require.Len(t, response.Items, 2)
+		assert.Equal(t, models.BallotDiagnosticItem{ItemID: 1, StoredVoteCount: 5, ActualVoteCount: 3, Discrepancy: 2}, response.Items[0])
+		assert.Equal(t, 0, response.Items[1].Discrepancy)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(ballotDiagnosticSQL).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(diagnosticColumns))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/ballots/7/diagnostic", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTopVoters(t *testing.T) {
+	const topVotersSQL = `SELECT u.id, u.username, u.email, up.full_name, COUNT(v.id) as vote_count, COUNT(DISTINCT v.ballot_id) as ballots_voted, MAX(v.created_at) as last_vote
+		FROM votes v
+		JOIN users u ON v.user_id = u.id
+		LEFT JOIN user_profiles up ON up.user_id = u.id
+		WHERE v.created_at BETWEEN $1 AND $2
+		GROUP BY u.id, up.full_name
+		ORDER BY vote_count DESC
+		LIMIT $3`
+	topVoterColumns := []string{"id", "username", "email", "full_name", "vote_count", "ballots_voted", "last_vote"}
+
+	t.Run("Date Range And Response Shape", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+		lastVote := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(topVotersSQL).
+			WithArgs(from, to, 10).
+			WillReturnRows(sqlmock.NewRows(topVoterColumns).
+				AddRow(3, "alice", "alice@example.com", "Alice Smith", 12, 5, lastVote).
+				AddRow(4, "bob", "bob@example.com", nil, 7, 4, lastVote))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/top-voters?from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "2026-01-01T00:00:00Z", response["from"])
+		assert.Equal(t, "2026-02-01T00:00:00Z", response["to"])
+
+		voters := response["voters"].([]interface{})
+		require.Len(t, voters, 2)
+
+		first := voters[0].(map[string]interface{})
+		assert.Equal(t, float64(3), first["user_id"])
+		assert.Equal(t, "alice", first["username"])
+		assert.Equal(t, "Alice Smith", first["full_name"])
+		assert.Equal(t, float64(12), first["vote_count"])
+		assert.Equal(t, float64(5), first["ballots_voted"])
+		assert.Equal(t, "2026-01-20T12:00:00Z", first["last_vote"])
+
+		second := voters[1].(map[string]interface{})
+		assert.Contains(t, second, "full_name")
+		assert.Nil(t, second["full_name"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Limit Capped At 100", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(topVotersSQL).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 100).
+			WillReturnRows(sqlmock.NewRows(topVoterColumns))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/top-voters?limit=5000", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+		assert.Equal(t, float64(100), response["limit"])
+		assert.Empty(t, response["voters"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/top-voters?limit=0", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid limit")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Date Range", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/top-voters?from=2026-02-01T00:00:00Z&to=2026-01-01T00:00:00Z", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "from must be before to")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBallotCreationRate(t *testing.T) {
+	const creationRateSQL = `SELECT DATE_TRUNC($1, created_at) AS period, COUNT(*) AS ballots_created, COUNT(DISTINCT creator_id) AS unique_creators
+		FROM ballots
+		WHERE created_at BETWEEN $2 AND $3
+		GROUP BY period
+		ORDER BY period`
+	creationRateColumns := []string{"period", "ballots_created", "unique_creators"}
+	week := func(day int) time.Time { return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC) }
+
+	t.Run("Growth Between Periods", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(creationRateSQL).
+			WithArgs("week", from, to).
+			WillReturnRows(sqlmock.NewRows(creationRateColumns).
+				AddRow(week(2), 10, 4).
+				AddRow(week(9), 15, 6).
+				AddRow(week(16), 6, 3))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/ballot-creation-rate?bucket=week&from=2026-03-01T00:00:00Z&to=2026-04-01T00:00:00Z", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var periods []models.BallotCreationPeriod
+		err = parseJSONResponse(recorder, &periods)
+		require.NoError(t, err)
+		require.Len(t, periods, 3)
+
+		assert.Equal(t, week(2), periods[0].Period)
+		assert.Equal(t, 10, periods[0].BallotsCreated)
+		assert.Equal(t, 4, periods[0].UniqueCreators)
+		assert.Nil(t, periods[0].GrowthRatePercent)
+
+		require.NotNil(t, periods[1].GrowthRatePercent)
+		assert.Equal(t, 50.0, *periods[1].GrowthRatePercent)
+
+		require.NotNil(t, periods[2].GrowthRatePercent)
+		assert.Equal(t, -60.0, *periods[2].GrowthRatePercent)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty Periods Filled", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(creationRateSQL).
+			WithArgs("week", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(creationRateColumns).
+				AddRow(week(2), 3, 2).
+				AddRow(week(16), 4, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/ballot-creation-rate?bucket=week", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var periods []models.BallotCreationPeriod
+		err = parseJSONResponse(recorder, &periods)
+		require.NoError(t, err)
+		require.Len(t, periods, 3)
+
+		assert.Equal(t, week(9), periods[1].Period)
+		assert.Equal(t, 0, periods[1].BallotsCreated)
+		require.NotNil(t, periods[1].GrowthRatePercent)
+		assert.Equal(t, -100.0, *periods[1].GrowthRatePercent)
+		// Growth from an empty period is undefined
+		assert.Nil(t, periods[2].GrowthRatePercent)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Bucket", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/reports/ballot-creation-rate?bucket=year", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "bucket must be day, week or month")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAuditLog(t *testing.T) {
+	auditLogColumns := []string{"id", "admin_user_id", "action", "target_type", "target_id", "payload", "ip_address", "created_at"}
+
+	t.Run("Filters And Pagination", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		createdAt := time.Date(2026, 1, 2, 9, 30, 0, 0, time.UTC)
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT id, admin_user_id, action, target_type, target_id, payload, host(ip_address), created_at FROM admin_audit_log WHERE action = $1 AND admin_user_id = $2 AND created_at >= $3 ORDER BY created_at DESC, id DESC LIMIT $4 OFFSET $5").
+			WithArgs("user.impersonate", 1, from, 10, 10).
+			WillReturnRows(sqlmock.NewRows(auditLogColumns).
+				AddRow(int64(42), 1, "user.impersonate", "user", 5, []byte(`{"expires_at":"2026-01-02T10:00:00Z"}`), "192.0.2.1", createdAt))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/audit-log?action=user.impersonate&admin_id=1&from=2026-01-01T00:00:00Z&page=2&limit=10", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Page    int                    `json:"page"`
+			Limit   int                    `json:"limit"`
+			Entries []models.AuditLogEntry `json:"entries"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, response.Page)
+		assert.Equal(t, 10, response.Limit)
+		require.Len(t, response.Entries, 1)
+		entry := response.Entries[0]
+		assert.Equal(t, int64(42), entry.ID)
+		assert.Equal(t, "user.impersonate", entry.Action)
+		require.NotNil(t, entry.TargetID)
+		assert.Equal(t, 5, *entry.TargetID)
+		assert.JSONEq(t, `{"expires_at":"2026-01-02T10:00:00Z"}`, string(entry.Payload))
+		require.NotNil(t, entry.IPAddress)
+		assert.Equal(t, "192.0.2.1", *entry.IPAddress)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Page", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/audit-log?page=0", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid page")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetVoteExport(t *testing.T) {
+	const voteExportSelect = "SELECT v.id, v.ballot_id, v.ballot_item_id, bi.title, v.created_at, v.previous_ballot_item_id"
+	const voteExportFrom = " FROM votes v JOIN ballot_items bi ON bi.id = v.ballot_item_id"
+	votedAt := time.Date(2026, 4, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("CSV Format", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes v WHERE v.ballot_id = $1 AND v.created_at >= $2").
+			WithArgs(3, from).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		testSetup.Mock.ExpectQuery(voteExportSelect+voteExportFrom+" WHERE v.ballot_id = $1 AND v.created_at >= $2 ORDER BY v.id").
+			WithArgs(3, from).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "ballot_item_id", "title", "created_at", "previous_ballot_item_id"}).
+				AddRow(10, 3, 7, "Expand parks, now", votedAt, nil).
+				AddRow(11, 3, 8, "Repave roads", votedAt, 7))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "votes.export", "ballot", 3, `{"format":"csv","include_user_segment":false,"rows":2}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export?ballot_id=3&from=2026-04-01T00:00:00Z", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := NewStreamRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "vote_id,ballot_id,ballot_item_id,item_title,voted_at,was_changed,previous_item_id\n"+
+			"10,3,7,\"Expand parks, now\",2026-04-02T15:04:05Z,false,\n"+
+			"11,3,8,Repave roads,2026-04-02T15:04:05Z,true,7\n", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty CSV Still Has Header", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes v WHERE v.ballot_id = $1").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		testSetup.Mock.ExpectQuery(voteExportSelect + voteExportFrom + " WHERE v.ballot_id = $1 ORDER BY v.id").
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "ballot_item_id", "title", "created_at", "previous_ballot_item_id"}))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "votes.export", "ballot", 3, `{"format":"csv","include_user_segment":false,"rows":0}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export?ballot_id=3", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := NewStreamRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "vote_id,ballot_id,ballot_item_id,item_title,voted_at,was_changed,previous_item_id\n", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("NDJSON With User Segment", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		birthday := time.Now().AddDate(-30, 0, -1)
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes v").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		testSetup.Mock.ExpectQuery(voteExportSelect + ", up.birthday, up.gender, pa.party_affiliation" + voteExportFrom +
+			" LEFT JOIN user_profiles up ON up.user_id = v.user_id LEFT JOIN user_political_affiliations pa ON pa.user_id = v.user_id ORDER BY v.id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "ballot_item_id", "title", "created_at", "previous_ballot_item_id", "birthday", "gender", "party_affiliation"}).
+				AddRow(10, 3, 7, "Expand parks", votedAt, nil, birthday, "female", "Independent").
+				AddRow(11, 4, 9, "Yes", votedAt, nil, nil, nil, nil))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "votes.export", "ballot", nil, `{"format":"json","include_user_segment":true,"rows":2}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export?format=json&include_user_segment=true", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := NewStreamRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+
+		lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.JSONEq(t, `{"vote_id":10,"ballot_id":3,"ballot_item_id":7,"item_title":"Expand parks","voted_at":"2026-04-02T15:04:05Z","was_changed":false,"previous_item_id":null,"age_range":"25-34","gender":"female","political_party":"Independent"}`, lines[0])
+		assert.JSONEq(t, `{"vote_id":11,"ballot_id":4,"ballot_item_id":9,"item_title":"Yes","voted_at":"2026-04-02T15:04:05Z","was_changed":false,"previous_item_id":null,"age_range":"unknown","gender":"unknown","political_party":"unknown"}`, lines[1])
+		assert.NotContains(t, recorder.Body.String(), "user_id")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Row Limit Exceeded", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes v").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1000001))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 413, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, "PAYLOAD_TOO_LARGE", response["code"])
+		details, ok := response["details"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, float64(1000001), details["count"])
+		assert.Equal(t, float64(1000000), details["max_rows"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Row Limit Reached Exactly", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes v").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1000000))
+		testSetup.Mock.ExpectQuery(voteExportSelect + voteExportFrom + " ORDER BY v.id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "ballot_item_id", "title", "created_at", "previous_ballot_item_id"}))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "votes.export", "ballot", nil, `{"format":"csv","include_user_segment":false,"rows":1000000}`, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := NewStreamRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/votes/export?format=xml", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "format must be csv or json")
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	const listUsersSQL = "SELECT id, username, email, role, deleted_at, created_at FROM users ORDER BY id LIMIT $1 OFFSET $2"
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Admin Lists Users", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(listUsersSQL).
+			WithArgs(2, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "role", "deleted_at", "created_at"}).
+				AddRow(3, "carol", "carol@example.com", "user", nil, createdAt).
+				AddRow(4, "deleted_user_4", "deleted_4@deleted.invalid", "user", deletedAt, createdAt))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/users?page=2&limit=2", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		require.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Page  int                       `json:"page"`
+			Limit int                       `json:"limit"`
+			Users []models.AdminUserSummary `json:"users"`
+		}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, 2, response.Page)
+		assert.Equal(t, 2, response.Limit)
+		require.Len(t, response.Users, 2)
+		assert.Equal(t, "carol", response.Users[0].Username)
+		assert.Nil(t, response.Users[0].DeletedAt)
+		require.NotNil(t, response.Users[1].DeletedAt)
+		assert.True(t, deletedAt.Equal(*response.Users[1].DeletedAt))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Admin Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/users", nil, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Admin access required")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestAdminDeactivateBallot(t *testing.T) {
+	const deactivateSQL = "UPDATE ballots SET is_active = false, activate_at = NULL, moderated_at = NOW() WHERE id = $1"
+
+	t.Run("Admin Deactivates Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectExec(deactivateSQL).
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec(auditLogInsertSQL).
+			WithArgs(1, "ballot.deactivate", "ballot", 7, nil, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/admin/ballots/7/deactivate", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"id": float64(7), "is_active": false})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectExec(deactivateSQL).
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/admin/ballots/99/deactivate", nil, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Admin Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/admin/ballots/7/deactivate", nil, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Admin access required")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
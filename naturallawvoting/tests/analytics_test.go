@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"database/sql"
+	"net/http/httptest"
+	"testing"
+	"voting-api/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const superstateAnalyticsSQL = `WITH superstate_ballots AS (
+		SELECT id, title FROM ballots WHERE superstate = $1 AND deleted_at IS NULL
+	),
+	ballot_totals AS (
+		SELECT sb.id, sb.title, COUNT(v.id) AS total_votes
+		FROM superstate_ballots sb
+		LEFT JOIN ballot_items bi ON bi.ballot_id = sb.id
+		LEFT JOIN votes v ON v.ballot_item_id = bi.id
+		GROUP BY sb.id, sb.title
+	),
+	most_voted AS (
+		SELECT id, title, total_votes FROM ballot_totals ORDER BY total_votes DESC, id ASC LIMIT 1
+	),
+	residents AS (
+		SELECT u.id FROM users u
+		JOIN user_addresses ua ON ua.user_id = u.id
+		WHERE u.deleted_at IS NULL AND LOWER(ua.state) = ANY($2)
+	)
+	SELECT (SELECT COUNT(*) FROM ballot_totals),
+	       (SELECT COALESCE(SUM(total_votes), 0) FROM ballot_totals),
+	       mv.id, mv.title, mv.total_votes,
+	       (SELECT COUNT(DISTINCT v.user_id) FROM votes v JOIN superstate_ballots sb ON sb.id = v.ballot_id JOIN residents r ON r.id = v.user_id),
+	       (SELECT COUNT(*) FROM residents)
+	FROM (SELECT 1) AS one
+	LEFT JOIN most_voted mv ON true`
+
+var superstateAnalyticsColumns = []string{"total_ballots", "total_votes", "id", "title", "total_votes", "voters", "residents"}
+
+var newEnglandStates = []string{"connecticut", "maine", "massachusetts", "new-hampshire", "rhode-island", "vermont"}
+
+func getSuperstateAnalytics(t *testing.T, testSetup *TestSetup, superstate string) *httptest.ResponseRecorder {
+	req, err := CreateTestRequest("GET", "/api/v1/public/superstates/"+superstate+"/analytics", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGetSuperstateAnalytics(t *testing.T) {
+	t.Run("Summarises Ballots And Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateAnalyticsSQL).
+			WithArgs("new-england", pq.Array(newEnglandStates)).
+			WillReturnRows(sqlmock.NewRows(superstateAnalyticsColumns).
+				AddRow(3, 57, 8, "Harbor Dredging", 40, 12, 48))
+
+		recorder := getSuperstateAnalytics(t, testSetup, "new-england")
+		require.Equal(t, 200, recorder.Code)
+
+		var analytics models.SuperstateAnalytics
+		require.NoError(t, parseJSONResponse(recorder, &analytics))
+		assert.Equal(t, models.SuperstateAnalytics{
+			Superstate:           "new-england",
+			TotalBallots:         3,
+			TotalVotes:           57,
+			MostVotedBallot:      &models.BallotVoteTotal{ID: 8, Title: "Harbor Dredging", TotalVotes: 40},
+			ParticipationRatePct: 25,
+		}, analytics)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rounds Participation Rate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateAnalyticsSQL).
+			WithArgs("new-england", pq.Array(newEnglandStates)).
+			WillReturnRows(sqlmock.NewRows(superstateAnalyticsColumns).
+				AddRow(1, 2, 4, "Ferry Schedule", 2, 1, 3))
+
+		recorder := getSuperstateAnalytics(t, testSetup, "new-england")
+		require.Equal(t, 200, recorder.Code)
+
+		var analytics models.SuperstateAnalytics
+		require.NoError(t, parseJSONResponse(recorder, &analytics))
+		assert.Equal(t, 33.33, analytics.ParticipationRatePct)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Ballots Or Residents", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateAnalyticsSQL).
+			WithArgs("new-england", pq.Array(newEnglandStates)).
+			WillReturnRows(sqlmock.NewRows(superstateAnalyticsColumns).
+				AddRow(0, 0, nil, nil, nil, 0, 0))
+
+		recorder := getSuperstateAnalytics(t, testSetup, "new-england")
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, float64(0), response["total_ballots"])
+		assert.Equal(t, float64(0), response["total_votes"])
+		assert.Nil(t, response["most_voted_ballot"])
+		assert.Equal(t, float64(0), response["participation_rate_pct"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Superstate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := getSuperstateAnalytics(t, testSetup, "atlantis")
+
+		AssertErrorResponse(t, recorder, 404, "Unknown superstate")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateAnalyticsSQL).
+			WithArgs("new-england", pq.Array(newEnglandStates)).
+			WillReturnError(sql.ErrConnDone)
+
+		recorder := getSuperstateAnalytics(t, testSetup, "new-england")
+
+		AssertErrorResponse(t, recorder, 500, "Database error")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+const superstateResultsSQL = `WITH superstate_items AS (
+		SELECT bi.id AS item_id, bi.ballot_id, bi.title
+		FROM ballot_items bi
+		JOIN ballots b ON b.id = bi.ballot_id
+		WHERE b.superstate = $1 AND b.deleted_at IS NULL
+	),
+	item_votes AS (
+		SELECT v.ballot_item_id, COUNT(*) AS total_votes
+		FROM votes v
+		JOIN superstate_items si ON si.item_id = v.ballot_item_id
+		GROUP BY v.ballot_item_id
+	)
+	SELECT si.ballot_id, si.item_id, si.title, COALESCE(iv.total_votes, 0) AS total_votes
+	FROM superstate_items si
+	LEFT JOIN item_votes iv ON iv.ballot_item_id = si.item_id
+	ORDER BY total_votes DESC, si.item_id ASC`
+
+var superstateResultsColumns = []string{"ballot_id", "item_id", "title", "total_votes"}
+
+func getSuperstateResults(t *testing.T, testSetup *TestSetup, superstate string) *httptest.ResponseRecorder {
+	req, err := CreateTestRequest("GET", "/api/v1/public/superstates/"+superstate+"/results", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGetSuperstateResults(t *testing.T) {
+	t.Run("Ranks Items Across Ballots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateResultsSQL).
+			WithArgs("new-england").
+			WillReturnRows(sqlmock.NewRows(superstateResultsColumns).
+				AddRow(8, 21, "Dredge the harbor", 40).
+				AddRow(4, 11, "Keep the winter ferry", 12).
+				AddRow(8, 22, "Leave it silted", 0))
+
+		recorder := getSuperstateResults(t, testSetup, "new-england")
+		require.Equal(t, 200, recorder.Code)
+
+		var results models.SuperstateResults
+		require.NoError(t, parseJSONResponse(recorder, &results))
+		assert.Equal(t, models.SuperstateResults{
+			Superstate: "new-england",
+			Items: []models.SuperstateResultItem{
+				{BallotID: 8, ItemID: 21, Title: "Dredge the harbor", TotalVotes: 40},
+				{BallotID: 4, ItemID: 11, Title: "Keep the winter ferry", TotalVotes: 12},
+				{BallotID: 8, ItemID: 22, Title: "Leave it silted", TotalVotes: 0},
+			},
+		}, results)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Ballots Returns Empty Items", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateResultsSQL).
+			WithArgs("new-england").
+			WillReturnRows(sqlmock.NewRows(superstateResultsColumns))
+
+		recorder := getSuperstateResults(t, testSetup, "new-england")
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, []interface{}{}, response["items"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Superstate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := getSuperstateResults(t, testSetup, "atlantis")
+
+		AssertErrorResponse(t, recorder, 404, "Unknown superstate")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(superstateResultsSQL).
+			WithArgs("new-england").
+			WillReturnError(sql.ErrConnDone)
+
+		recorder := getSuperstateResults(t, testSetup, "new-england")
+
+		AssertErrorResponse(t, recorder, 500, "Database error")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersionBallotItemsKey(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	getBallot := func(t *testing.T, version string) map[string]interface{} {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(1, "Test Ballot", "Test Description", "", "", "", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, 1, "Option 1", "First option", 5, createdAt).
+				AddRow(2, 1, "Option 2", "Second option", 3, createdAt))
+
+		req, err := CreateTestRequest("GET", "/api/"+version+"/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("V1 Uses Options", func(t *testing.T) {
+		response := getBallot(t, "v1")
+
+		assert.Len(t, response["options"], 2)
+		assert.NotContains(t, response, "items")
+	})
+
+	t.Run("V2 Uses Items", func(t *testing.T) {
+		response := getBallot(t, "v2")
+
+		require.Len(t, response["items"], 2)
+		assert.NotContains(t, response, "options")
+		first := response["items"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "Option 1", first["title"])
+		assert.Equal(t, "Test Ballot", response["title"])
+	})
+
+	t.Run("V2 Errors Are Unchanged", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v2/public/ballots/invalid", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid ballot ID")
+	})
+
+	t.Run("Unknown Version Is Not Routed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v3/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 404, recorder.Code)
+	})
+}
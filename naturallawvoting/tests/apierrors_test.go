@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"voting-api/apierrors"
+	"voting-api/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Without Details", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+
+		apierrors.RespondError(c, http.StatusNotFound, apierrors.ErrCodeNotFound, "Ballot not found")
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.JSONEq(t, `{"code": "NOT_FOUND", "error": "Ballot not found"}`, recorder.Body.String())
+	})
+
+	t.Run("With Details", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+
+		apierrors.RespondErrorWithDetails(c, http.StatusRequestEntityTooLarge, apierrors.ErrCodePayloadTooLarge, "Too many rows", gin.H{"max_rows": 10})
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+		assert.JSONEq(t, `{"code": "PAYLOAD_TOO_LARGE", "error": "Too many rows", "details": {"max_rows": 10}}`, recorder.Body.String())
+	})
+}
+
+func TestErrorResponseCodes(t *testing.T) {
+	tests := []struct {
+		name           string
+		mock           func(mock sqlmock.Sqlmock)
+		request        func() (*http.Request, error)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "Missing Token",
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("GET", "/api/v1/profile", nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedCode:   apierrors.ErrCodeUnauthorized,
+		},
+		{
+			name: "Invalid Ballot ID",
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("GET", "/api/v1/public/ballots/abc/results", nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   apierrors.ErrCodeBadRequest,
+		},
+		{
+			name: "Malformed Body",
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("POST", "/api/v1/auth/register", map[string]string{"email": "voter@example.com"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   apierrors.ErrCodeValidation,
+		},
+		{
+			name: "Ballot Not Found",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(ballotTypeSQL).WithArgs(42).WillReturnError(sql.ErrNoRows)
+			},
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("GET", "/api/v1/public/ballots/42/results", nil)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apierrors.ErrCodeNotFound,
+		},
+		{
+			name: "Email Already Registered",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+					WithArgs("existing@example.com").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			},
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("POST", "/api/v1/auth/register", models.RegisterRequest{
+					Email:    "existing@example.com",
+					Username: "newuser",
+					Password: "password123",
+				})
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   apierrors.ErrCodeConflict,
+		},
+		{
+			name: "Database Failure",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(ballotTypeSQL).WithArgs(42).WillReturnError(sql.ErrConnDone)
+			},
+			request: func() (*http.Request, error) {
+				return CreateTestRequest("GET", "/api/v1/public/ballots/42/results", nil)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apierrors.ErrCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			if tt.mock != nil {
+				tt.mock(testSetup.Mock)
+			}
+
+			req, err := tt.request()
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			var response apierrors.APIError
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.Code)
+			assert.NotEmpty(t, response.Message)
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+}
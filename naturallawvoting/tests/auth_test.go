@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"voting-api/models"
@@ -20,9 +21,12 @@ func TestUserRegistration(t *testing.T) {
 		require.NoError(t, err)
 		defer testSetup.DB.Close()
 		
-		// Mock that user doesn't exist
-		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1 OR username = $2").
-			WithArgs("test@example.com", "testuser").
+		// Mock that neither the email nor the username is taken
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+			WithArgs("test@example.com").
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE username = $1").
+			WithArgs("testuser").
 			WillReturnError(sql.ErrNoRows)
 
 		// Mock user insertion
@@ -68,9 +72,9 @@ func TestUserRegistration(t *testing.T) {
 		require.NoError(t, err)
 		defer testSetup.DB.Close()
 
-		// Mock that user already exists
-		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1 OR username = $2").
-			WithArgs("existing@example.com", "existing").
+		// Mock that user already exists; the email check fails first
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+			WithArgs("existing@example.com").
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
 		reqBody := models.RegisterRequest{
@@ -85,7 +89,60 @@ func TestUserRegistration(t *testing.T) {
 		recorder := httptest.NewRecorder()
 		testSetup.Router.ServeHTTP(recorder, req)
 
-		AssertErrorResponse(t, recorder, 409, "User already exists")
+		AssertErrorResponse(t, recorder, 409, "Email already registered")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Registration with Duplicate Email and New Username", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+			WithArgs("existing@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		reqBody := models.RegisterRequest{
+			Username: "brandnew",
+			Email:    "existing@example.com",
+			Password: "password123",
+		}
+
+		req, err := CreateTestRequest("POST", "/api/v1/auth/register", reqBody)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 409, "Email already registered")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Registration with Taken Username", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+			WithArgs("new@example.com").
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE username = $1").
+			WithArgs("existing").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		reqBody := models.RegisterRequest{
+			Username: "existing",
+			Email:    "new@example.com",
+			Password: "password123",
+		}
+
+		req, err := CreateTestRequest("POST", "/api/v1/auth/register", reqBody)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 409, "Username already taken")
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
 
@@ -122,10 +179,13 @@ func TestUserLogin(t *testing.T) {
 
 		// Mock user found in database
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1").
+		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1").
 			WithArgs("test@example.com").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "created_at", "updated_at"}).
-				AddRow(1, "testuser", "test@example.com", hashedPassword, createdAt, createdAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "role", "created_at", "updated_at"}).
+				AddRow(1, "testuser", "test@example.com", hashedPassword, "user", createdAt, createdAt))
+		testSetup.Mock.ExpectExec("INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)").
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		reqBody := models.LoginRequest{
 			Email:    "test@example.com",
@@ -145,7 +205,13 @@ func TestUserLogin(t *testing.T) {
 		require.NoError(t, err)
 
 		assert.NotEmpty(t, response.Token)
+		assert.Len(t, response.RefreshToken, 64)
 		assert.Equal(t, "testuser", response.User.Username)
+		assert.Equal(t, "user", response.User.Role)
+
+		claims, err := utils.ValidateJWT(response.Token)
+		require.NoError(t, err)
+		assert.Equal(t, "user", claims["role"])
 		assert.Equal(t, "test@example.com", response.User.Email)
 		assert.Empty(t, response.User.Password) // Password should not be returned
 
@@ -158,7 +224,7 @@ func TestUserLogin(t *testing.T) {
 		defer testSetup.DB.Close()
 
 		// Mock user not found
-		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1").
+		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1").
 			WithArgs("nonexistent@example.com").
 			WillReturnError(sql.ErrNoRows)
 
@@ -186,10 +252,10 @@ func TestUserLogin(t *testing.T) {
 		require.NoError(t, err)
 
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, created_at, updated_at FROM users WHERE email = $1").
+		testSetup.Mock.ExpectQuery("SELECT id, username, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1").
 			WithArgs("test@example.com").
-			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "created_at", "updated_at"}).
-				AddRow(1, "testuser", "test@example.com", hashedPassword, createdAt, createdAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "password_hash", "role", "created_at", "updated_at"}).
+				AddRow(1, "testuser", "test@example.com", hashedPassword, "user", createdAt, createdAt))
 
 		reqBody := models.LoginRequest{
 			Email:    "test@example.com",
@@ -207,6 +273,100 @@ func TestUserLogin(t *testing.T) {
 	})
 }
 
+func TestRefreshToken(t *testing.T) {
+	const lookupSQL = "SELECT rt.id, rt.user_id, u.email, u.role, rt.expires_at, rt.revoked FROM refresh_tokens rt JOIN users u ON u.id = rt.user_id WHERE rt.token_hash = $1 AND u.deleted_at IS NULL FOR UPDATE OF rt"
+	lookupColumns := []string{"id", "user_id", "email", "role", "expires_at", "revoked"}
+	const refreshToken = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tokenHash := utils.HashRefreshToken(refreshToken)
+
+	refresh := func(t *testing.T, testSetup *TestSetup) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("POST", "/api/v1/auth/refresh", models.RefreshTokenRequest{RefreshToken: refreshToken})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Rotates Token", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(lookupSQL).
+			WithArgs(tokenHash).
+			WillReturnRows(sqlmock.NewRows(lookupColumns).AddRow(5, 1, "test@example.com", "user", time.Now().Add(time.Hour), false))
+		testSetup.Mock.ExpectExec("UPDATE refresh_tokens SET revoked = true WHERE id = $1").
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)").
+			WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(6, 1))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := refresh(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+
+		var response models.RefreshTokenResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Len(t, response.RefreshToken, 64)
+		assert.NotEqual(t, refreshToken, response.RefreshToken)
+
+		claims, err := utils.ValidateJWT(response.Token)
+		require.NoError(t, err)
+		assert.Equal(t, float64(1), claims["user_id"])
+		assert.Equal(t, "test@example.com", claims["email"])
+		assert.Equal(t, "user", claims["role"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(lookupSQL).
+			WithArgs(tokenHash).
+			WillReturnRows(sqlmock.NewRows(lookupColumns).AddRow(5, 1, "test@example.com", "user", time.Now().Add(-time.Minute), false))
+		testSetup.Mock.ExpectRollback()
+
+		AssertErrorResponse(t, refresh(t, testSetup), 401, "Refresh token has expired")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Revoked Token", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(lookupSQL).
+			WithArgs(tokenHash).
+			WillReturnRows(sqlmock.NewRows(lookupColumns).AddRow(5, 1, "test@example.com", "user", time.Now().Add(time.Hour), true))
+		testSetup.Mock.ExpectRollback()
+
+		AssertErrorResponse(t, refresh(t, testSetup), 401, "Refresh token has been revoked")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(lookupSQL).
+			WithArgs(tokenHash).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectRollback()
+
+		AssertErrorResponse(t, refresh(t, testSetup), 401, "Invalid refresh token")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
 func TestGetProfile(t *testing.T) {
 	t.Run("Get Profile Successfully", func(t *testing.T) {
 		testSetup, err := SetupTestEnvironment()
@@ -279,4 +439,415 @@ func parseJSONResponse(recorder *httptest.ResponseRecorder, target interface{})
 
 func parseJSONFromBytes(data []byte, target interface{}) error {
 	return json.Unmarshal(data, target)
-}
\ No newline at end of file
+}
+
+func TestDeleteAccount(t *testing.T) {
+	const passwordHashSQL = "SELECT password_hash FROM users WHERE id = $1 AND deleted_at IS NULL"
+
+	userID := 5
+	email := "leaving@example.com"
+	hashedPassword, err := utils.HashPassword("correctpassword")
+	require.NoError(t, err)
+
+	deleteAccount := func(t *testing.T, testSetup *TestSetup, body interface{}) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/profile/account", body, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Deletes Account", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+		testSetup.Mock.ExpectBegin()
+		// Votes are kept, without the voter, so ballot tallies still add up
+		testSetup.Mock.ExpectExec("UPDATE votes SET user_id = NULL WHERE user_id = $1").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		testSetup.Mock.ExpectExec("DELETE FROM users WHERE id = $1").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := deleteAccount(t, testSetup, models.DeleteAccountRequest{ConfirmPassword: "correctpassword"})
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Account deleted successfully"})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+
+		recorder := deleteAccount(t, testSetup, models.DeleteAccountRequest{ConfirmPassword: "wrongpassword"})
+
+		AssertErrorResponse(t, recorder, 401, "Incorrect password")
+		// Nothing is deleted
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Confirm Password", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := deleteAccount(t, testSetup, map[string]string{})
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := deleteAccount(t, testSetup, models.DeleteAccountRequest{ConfirmPassword: "correctpassword"})
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delete Fails And Rolls Back", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("UPDATE votes SET user_id = NULL WHERE user_id = $1").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		testSetup.Mock.ExpectExec("DELETE FROM users WHERE id = $1").
+			WithArgs(userID).
+			WillReturnError(sql.ErrConnDone)
+		testSetup.Mock.ExpectRollback()
+
+		recorder := deleteAccount(t, testSetup, models.DeleteAccountRequest{ConfirmPassword: "correctpassword"})
+
+		AssertErrorResponse(t, recorder, 500, "Error deleting account")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Rejected While Impersonating", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		token, err := utils.GenerateImpersonationJWT(userID, email, 1, time.Hour)
+		require.NoError(t, err)
+		req, err := CreateTestRequest("DELETE", "/api/v1/profile/account", models.DeleteAccountRequest{ConfirmPassword: "correctpassword"})
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Cannot delete an account while impersonating")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Without Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("DELETE", "/api/v1/profile/account", models.DeleteAccountRequest{ConfirmPassword: "correctpassword"})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authorization header required")
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	const passwordHashSQL = "SELECT password_hash FROM users WHERE id = $1 AND deleted_at IS NULL"
+
+	userID := 4
+	email := "changer@example.com"
+	hashedPassword, err := utils.HashPassword("oldpassword")
+	require.NoError(t, err)
+
+	changePassword := func(t *testing.T, testSetup *TestSetup, body interface{}) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/profile/password", body, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Changes Password And Revokes Refresh Tokens", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("UPDATE users SET password_hash = $1 WHERE id = $2").
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE refresh_tokens SET revoked = true WHERE user_id = $1 AND revoked = false").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword123"})
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Password updated successfully"})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Wrong Old Password", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "notmypassword", NewPassword: "newpassword123"})
+
+		AssertErrorResponse(t, recorder, 401, "Incorrect password")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("New Password Too Short", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "short"})
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Old Password", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := changePassword(t, testSetup, map[string]string{"new_password": "newpassword123"})
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("New Password Same As Old", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "samepassword", NewPassword: "samepassword"})
+
+		AssertErrorResponse(t, recorder, 400, "New password must be different from the old password")
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword123"})
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update Fails And Rolls Back", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(passwordHashSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"password_hash"}).AddRow(hashedPassword))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("UPDATE users SET password_hash = $1 WHERE id = $2").
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnError(sql.ErrConnDone)
+		testSetup.Mock.ExpectRollback()
+
+		recorder := changePassword(t, testSetup, models.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword123"})
+
+		AssertErrorResponse(t, recorder, 500, "Error updating password")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Without Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("PUT", "/api/v1/profile/password", models.ChangePasswordRequest{OldPassword: "oldpassword", NewPassword: "newpassword123"})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authorization header required")
+	})
+}
+
+func TestCheckUsername(t *testing.T) {
+	const usernameExistsSQL = "SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)"
+
+	checkUsername := func(t *testing.T, testSetup *TestSetup, username string) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("GET", "/api/v1/auth/check-username?username="+username, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Available", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(usernameExistsSQL).
+			WithArgs("new_voter1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		recorder := checkUsername(t, testSetup, "new_voter1")
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"username": "new_voter1", "available": true})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Taken", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(usernameExistsSQL).
+			WithArgs("testuser").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		recorder := checkUsername(t, testSetup, "testuser")
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"username": "testuser", "available": false})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	invalid := []struct {
+		name     string
+		username string
+	}{
+		{"Missing", ""},
+		{"Too Short", "ab"},
+		{"Too Long", strings.Repeat("a", 51)},
+		{"Invalid Characters", "bad%20name"},
+	}
+	for _, tc := range invalid {
+		t.Run("Invalid Format "+tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			recorder := checkUsername(t, testSetup, tc.username)
+
+			AssertErrorResponse(t, recorder, 400, "Username must be 3-50 letters, digits or underscores")
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Has Its Own Rate Limit", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Using up the login budget leaves username checks available
+		for i := 0; i < 11; i++ {
+			req, err := CreateTestRequest("POST", "/api/v1/auth/login", map[string]string{"email": "test@example.com"})
+			require.NoError(t, err)
+			testSetup.Router.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		for i := 0; i < 60; i++ {
+			require.Equal(t, 400, checkUsername(t, testSetup, "x").Code)
+		}
+		assert.Equal(t, 429, checkUsername(t, testSetup, "x").Code)
+	})
+}
+
+func TestRegisterEmailValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		valid bool
+	}{
+		{name: "Plain Address", email: "voter@example.com", valid: true},
+		{name: "Plus Tag And Subdomain", email: "voter+ballots@mail.example.org", valid: true},
+		{name: "Empty", email: "", valid: false},
+		{name: "Missing At Sign", email: "voter.example.com", valid: false},
+		{name: "Missing Domain", email: "voter@", valid: false},
+		{name: "Missing Local Part", email: "@example.com", valid: false},
+		{name: "Display Name", email: "Voter <voter@example.com>", valid: false},
+		{name: "Embedded Space", email: "vo ter@example.com", valid: false},
+		{name: "Two At Signs", email: "voter@@example.com", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			// A valid email reaches the duplicate check; reporting a clash stops there
+			if tt.valid {
+				testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+					WithArgs(tt.email).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			}
+
+			req, err := CreateTestRequest("POST", "/api/v1/auth/register", models.RegisterRequest{
+				Username: "newvoter",
+				Email:    tt.email,
+				Password: "password123",
+			})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			if tt.valid {
+				AssertErrorResponse(t, recorder, 409, "Email already registered")
+			} else {
+				assert.Equal(t, 400, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), "VALIDATION_FAILED")
+				assert.Contains(t, recorder.Body.String(), "RegisterRequest.Email")
+			}
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,17 +2,82 @@ package tests
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 	"voting-api/models"
+	"voting-api/utils"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// createBallotSQL is the ballot insert issued by CreateBallot.
+const createBallotSQL = "INSERT INTO ballots (title, description, category, superstate, state, ballot_type, allow_vote_retraction, minimum_quorum, closes_at, creator_id, is_active) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, title, description, category, superstate, state, creator_id, is_active, ballot_type, allow_vote_retraction, minimum_quorum, closes_at, created_at, updated_at"
+
+var createBallotColumns = []string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "ballot_type", "allow_vote_retraction", "minimum_quorum", "closes_at", "created_at", "updated_at"}
+
+// archivedBallotSQL is the ballot lookup issued by GetArchivedBallot, which
+// also finds deleted ballots.
+const archivedBallotSQL = `SELECT b.id, b.title, b.description, b.category, COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, COALESCE(b.ballot_type, 'plurality'), COALESCE(b.locked, false), b.closes_at, b.created_at, b.updated_at,
+       (SELECT COUNT(*) FROM ballot_sponsors WHERE ballot_id = b.id) AS sponsor_count
+FROM ballots b WHERE b.id = $1`
+
+// getBallotSQL is the ballot lookup issued by GetBallot.
+const getBallotSQL = archivedBallotSQL + ` AND b.deleted_at IS NULL`
+
+var getBallotColumns = []string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "ballot_type", "locked", "closes_at", "created_at", "updated_at", "sponsor_count"}
+
+// listBallotsSQL is the ballot listing query issued by GetAllBallots before any
+// filters or ordering are appended.
+const listBallotsSQL = `SELECT b.id, b.title, b.description, b.category, COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, b.created_at, b.updated_at,
+       b.closes_at, EXTRACT(epoch FROM b.closes_at - NOW())/3600 AS hours_remaining,
+       u.username as creator_username,
+       (SELECT COALESCE(SUM(vc.vote_count), 0) FROM ballot_items bi JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id WHERE bi.ballot_id = b.id) AS total_votes,
+       (SELECT COUNT(*) FROM ballot_items WHERE ballot_id = b.id) AS item_count
+FROM ballots b
+JOIN users u ON b.creator_id = u.id
+WHERE b.is_active = true`
+
+var listBallotsColumns = []string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "created_at", "updated_at", "closes_at", "hours_remaining", "creator_username", "total_votes", "item_count"}
+
+// getBallotItemsSQL is the item lookup issued by GetBallot after getBallotSQL.
+const getBallotItemsSQL = `SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0), bi.updated_at
+FROM ballot_items bi
+LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+WHERE bi.ballot_id = $1
+ORDER BY bi.id ASC`
+
+var getBallotItemColumns = []string{"id", "ballot_id", "title", "description", "vote_count", "updated_at"}
+
+// ballotCreatorSQL is the ownership check issued before a ballot is modified.
+const ballotCreatorSQL = "SELECT creator_id, EXISTS(SELECT 1 FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2) FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+// voteEligibilitySQL is the read-only eligibility check GetBallot runs for
+// authenticated callers.
+const voteEligibilitySQL = `SELECT closes_at IS NOT NULL AND closes_at <= NOW(),
+		       EXISTS(SELECT 1 FROM users WHERE id = $2 AND deleted_at IS NOT NULL)
+		FROM ballots WHERE id = $1`
+
+// ballotLockedSQL is the lock check issued before a ballot's content is edited.
+const ballotLockedSQL = "SELECT COALESCE(locked, false) FROM ballots WHERE id = $1"
+
+// ballotEditLookupSQL reads the current title and description inside UpdateBallot's
+// transaction so the changelog can record the old values, along with the state
+// that decides whether the active flag may change.
+const ballotEditLookupSQL = "SELECT title, COALESCE(description, ''), is_active, closes_at IS NOT NULL, moderated_at IS NOT NULL FROM ballots WHERE id = $1 AND deleted_at IS NULL FOR UPDATE"
+
+var ballotEditLookupColumns = []string{"title", "description", "is_active", "scheduled_close", "moderated"}
+
+const changelogInsertSQL = "INSERT INTO ballot_changelog (ballot_id, changed_by, field, old_value, new_value) VALUES ($1, $2, $3, $4, $5)"
+
 func TestCreateBallot(t *testing.T) {
 	testSetup, err := SetupTestEnvironment()
 	require.NoError(t, err)
@@ -27,10 +92,10 @@ func TestCreateBallot(t *testing.T) {
 
 		// Mock ballot insertion
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery("INSERT INTO ballots (title, description, creator_id) VALUES ($1, $2, $3) RETURNING id, title, description, creator_id, is_active, created_at, updated_at").
-			WithArgs("Best Programming Language", "Vote for your favorite", userID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-				AddRow(1, "Best Programming Language", "Vote for your favorite", userID, true, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Best Programming Language", "Vote for your favorite", "", "", "", "plurality", true, nil, nil, userID, true).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(1, "Best Programming Language", "Vote for your favorite", "", "", "", userID, true, "plurality", true, nil, nil, createdAt, createdAt))
 
 		// Mock ballot items insertion
 		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
@@ -114,6 +179,206 @@ func TestCreateBallot(t *testing.T) {
 
 		assert.Equal(t, 400, recorder.Code)
 	})
+
+	t.Run("Create Draft Ballot", func(t *testing.T) {
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Library Hours", "", "", "", "", "plurality", true, nil, nil, userID, false).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(5, "Library Hours", "", "", "", "", userID, false, "plurality", true, nil, nil, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(5, "Extend", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(9, 5, "Extend", "", 0))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(5, "Keep", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(10, 5, "Keep", "", 0))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := models.CreateBallotRequest{
+			Title: "Library Hours",
+			Draft: true,
+			Items: []models.CreateBallotItemRequest{
+				{Title: "Extend"},
+				{Title: "Keep"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		require.Equal(t, 201, recorder.Code)
+
+		var ballot models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballot))
+		assert.False(t, ballot.IsActive)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Create Ballot With Closing Time", func(t *testing.T) {
+		userID := 1
+		email := "test@example.com"
+		closesAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Library Hours", "", "", "", "", "plurality", true, nil, closesAt, userID, true).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(2, "Library Hours", "", "", "", "", userID, true, "plurality", true, nil, closesAt, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(2, "Extend", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(3, 2, "Extend", "", 0))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(2, "Keep", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(4, 2, "Keep", "", 0))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := models.CreateBallotRequest{
+			Title:    "Library Hours",
+			ClosesAt: &closesAt,
+			Items: []models.CreateBallotItemRequest{
+				{Title: "Extend"},
+				{Title: "Keep"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		require.Equal(t, 201, recorder.Code)
+
+		var ballot models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballot))
+		require.NotNil(t, ballot.ClosesAt)
+		assert.True(t, closesAt.Equal(*ballot.ClosesAt))
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Create Ballot With Closing Time In The Past", func(t *testing.T) {
+		closesAt := time.Now().Add(-time.Hour)
+		reqBody := models.CreateBallotRequest{
+			Title:    "Too Late",
+			ClosesAt: &closesAt,
+			Items: []models.CreateBallotItemRequest{
+				{Title: "Yes"},
+				{Title: "No"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "closes_at must be in the future")
+	})
+
+	t.Run("Create Ballot Strips HTML", func(t *testing.T) {
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Park Budget", "Fund the parks", "", "", "", "plurality", true, nil, nil, userID, true).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(3, "Park Budget", "Fund the parks", "", "", "", userID, true, "plurality", true, nil, nil, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(3, "Yes", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(5, 3, "Yes", "", 0))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(3, "No", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(6, 3, "No", "", 0))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := models.CreateBallotRequest{
+			Title:       `Park Budget<script>alert("xss")</script>`,
+			Description: `<img src=x onerror="alert(1)">Fund the parks`,
+			Items: []models.CreateBallotItemRequest{
+				{Title: "<b>Yes</b>"},
+				{Title: "No", Description: "<script></script>"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Create Ballot With Markup-Only Title", func(t *testing.T) {
+		reqBody := models.CreateBallotRequest{
+			Title: "<script>alert(1)</script>",
+			Items: []models.CreateBallotItemRequest{
+				{Title: "Yes"},
+				{Title: "No"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Title must contain text")
+	})
+
+	t.Run("Create Ballot With Duplicate Item Titles", func(t *testing.T) {
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Library Hours", "", "", "", "", "plurality", true, nil, nil, userID, true).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(4, "Library Hours", "", "", "", "", userID, true, "plurality", true, nil, nil, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(4, "Open Sundays", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(7, 4, "Open Sundays", "", 0))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
+			WithArgs(4, "Open Sundays", "").
+			WillReturnError(&pq.Error{Code: "23505", Constraint: "ballot_items_ballot_id_title_key"})
+		testSetup.Mock.ExpectRollback()
+
+		reqBody := models.CreateBallotRequest{
+			Title: "Library Hours",
+			Items: []models.CreateBallotItemRequest{
+				{Title: "Open Sundays"},
+				{Title: "Open Sundays"},
+			},
+		}
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots", reqBody, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Duplicate ballot item title: Open Sundays")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
 }
 
 func TestGetAllBallots(t *testing.T) {
@@ -125,16 +390,11 @@ func TestGetAllBallots(t *testing.T) {
 		// Mock ballots query
 		createdAt1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 		createdAt2 := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
-		rows := sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at", "creator_username"}).
-			AddRow(1, "Ballot 1", "Description 1", 1, true, createdAt1, createdAt1, "user1").
-			AddRow(2, "Ballot 2", "Description 2", 2, true, createdAt2, createdAt2, "user2")
-
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at,
-       u.username as creator_username
-FROM ballots b 
-JOIN users u ON b.creator_id = u.id 
-WHERE b.is_active = true 
-ORDER BY b.created_at DESC`).
+		rows := sqlmock.NewRows(listBallotsColumns).
+			AddRow(1, "Ballot 1", "Description 1", "", "", "", 1, true, createdAt1, createdAt1, nil, nil, "user1", 15, 3).
+			AddRow(2, "Ballot 2", "Description 2", "", "", "", 2, true, createdAt2, createdAt2, nil, nil, "user2", 0, 2)
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.created_at DESC`).
 			WillReturnRows(rows)
 
 		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
@@ -153,18 +413,19 @@ ORDER BY b.created_at DESC`).
 		assert.Equal(t, "Ballot 1", ballots[0].Title)
 		assert.Equal(t, "Ballot 2", ballots[1].Title)
 
+		// Vote totals and item counts come from the listing query itself
+		assert.Equal(t, 15, ballots[0].TotalVotes)
+		assert.Equal(t, 3, ballots[0].ItemCount)
+		assert.Equal(t, 0, ballots[1].TotalVotes)
+		assert.Equal(t, 2, ballots[1].ItemCount)
+
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
 
 	t.Run("Get All Ballots Empty Result", func(t *testing.T) {
 		// Mock empty result
-		rows := sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at", "creator_username"})
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at,
-       u.username as creator_username
-FROM ballots b 
-JOIN users u ON b.creator_id = u.id 
-WHERE b.is_active = true 
-ORDER BY b.created_at DESC`).
+		rows := sqlmock.NewRows(listBallotsColumns)
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.created_at DESC`).
 			WillReturnRows(rows)
 
 		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
@@ -195,21 +456,17 @@ func TestGetBallot(t *testing.T) {
 
 		// Mock ballot query
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at
-FROM ballots b WHERE b.id = $1`).
+		testSetup.Mock.ExpectQuery(getBallotSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-				AddRow(ballotID, "Test Ballot", "Test Description", 1, true, createdAt, createdAt))
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(ballotID, "Test Ballot", "Test Description", "", "", "", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
 
 		// Mock ballot items query
-		testSetup.Mock.ExpectQuery(`SELECT id, ballot_id, title, description, vote_count
-FROM ballot_items 
-WHERE ballot_id = $1 
-ORDER BY id ASC`).
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
-				AddRow(1, ballotID, "Option 1", "First option", 5).
-				AddRow(2, ballotID, "Option 2", "Second option", 3))
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, ballotID, "Option 1", "First option", 5, createdAt).
+				AddRow(2, ballotID, "Option 2", "Second option", 3, createdAt))
 
 		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d", ballotID), nil)
 		require.NoError(t, err)
@@ -236,8 +493,7 @@ ORDER BY id ASC`).
 		ballotID := 999
 
 		// Mock ballot not found
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at
-FROM ballots b WHERE b.id = $1`).
+		testSetup.Mock.ExpectQuery(getBallotSQL).
 			WithArgs(ballotID).
 			WillReturnError(sql.ErrNoRows)
 
@@ -262,6 +518,228 @@ FROM ballots b WHERE b.id = $1`).
 	})
 }
 
+func TestGetBallotWithUserVote(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	const userVoteSQL = "SELECT ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2"
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expectBallot := func(ballotID int) {
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(ballotID, "Test Ballot", "Test Description", "", "", "", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, ballotID, "Option 1", "First option", 5, createdAt).
+				AddRow(2, ballotID, "Option 2", "Second option", 3, createdAt))
+	}
+
+	t.Run("Includes User Vote", func(t *testing.T) {
+		expectBallot(10)
+		testSetup.Mock.ExpectQuery(userVoteSQL).
+			WithArgs(7, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id"}).AddRow(2))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/ballots/10", nil, 7, "voter@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballot models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballot))
+		assert.Equal(t, 10, ballot.ID)
+		require.Len(t, ballot.Items, 2)
+		require.NotNil(t, ballot.UserVote)
+		assert.Equal(t, 2, *ballot.UserVote)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Vote Is Null When Not Voted", func(t *testing.T) {
+		expectBallot(11)
+		testSetup.Mock.ExpectQuery(userVoteSQL).
+			WithArgs(7, 11).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/ballots/11", nil, 7, "voter@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &body))
+		value, present := body["user_vote"]
+		assert.True(t, present)
+		assert.Nil(t, value)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(999).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/ballots/999", nil, 7, "voter@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Requires Authentication", func(t *testing.T) {
+		req, err := CreateTestRequest("GET", "/api/v1/ballots/10", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+}
+
+func TestBallotContentNegotiation(t *testing.T) {
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectListing := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(1, "Ballot 1", "Description 1", "", "", "", 1, true, createdAt, createdAt, nil, nil, "user1", 15, 3).
+				AddRow(2, "Ballot 2", "Description 2", "", "", "", 2, true, createdAt, createdAt, nil, nil, "user2", 0, 2))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		accept string
+	}{
+		{"Defaults To JSON", ""},
+		{"Explicit JSON", "application/json"},
+		{"Wildcard Gets JSON", "*/*"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			expectListing(testSetup)
+
+			req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
+			require.NoError(t, err)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, 200, recorder.Code)
+			assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+			var ballots []models.Ballot
+			err = parseJSONResponse(recorder, &ballots)
+			require.NoError(t, err)
+			assert.Len(t, ballots, 2)
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("XML Listing", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectListing(testSetup)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/xml")
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(recorder.Body.String(), "<ballots><ballot>"))
+
+		var listing struct {
+			XMLName xml.Name        `xml:"ballots"`
+			Ballots []models.Ballot `xml:"ballot"`
+		}
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &listing))
+		require.Len(t, listing.Ballots, 2)
+		assert.Equal(t, 1, listing.Ballots[0].ID)
+		assert.Equal(t, "Ballot 1", listing.Ballots[0].Title)
+		assert.Equal(t, 15, listing.Ballots[0].TotalVotes)
+		assert.Equal(t, createdAt, listing.Ballots[0].CreatedAt)
+		assert.Equal(t, "Ballot 2", listing.Ballots[1].Title)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("XML Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(1, "Test Ballot", "Test Description", "", "", "", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, 1, "Option 1", "First option", 5, createdAt).
+				AddRow(2, 1, "Option 2", "Second option", 3, createdAt))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/xml")
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/xml; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+		var ballot models.Ballot
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &ballot))
+		assert.Equal(t, "ballot", ballot.XMLName.Local)
+		assert.Equal(t, "Test Ballot", ballot.Title)
+		require.Len(t, ballot.Items, 2)
+		assert.Equal(t, "item", ballot.Items[0].XMLName.Local)
+		assert.Equal(t, "Option 1", ballot.Items[0].Title)
+		assert.Equal(t, 3, ballot.Items[1].VoteCount)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsupported Media Type", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/yaml")
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 406, "Supported response types are application/json and application/xml")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
 func TestGetUserBallots(t *testing.T) {
 	testSetup, err := SetupTestEnvironment()
 	require.NoError(t, err)
@@ -274,13 +752,13 @@ func TestGetUserBallots(t *testing.T) {
 		// Mock user ballots query
 		createdAt1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 		createdAt2 := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
-		rows := sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-			AddRow(1, "My Ballot 1", "My Description 1", userID, true, createdAt1, createdAt1).
-			AddRow(2, "My Ballot 2", "My Description 2", userID, false, createdAt2, createdAt2)
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "closes_at", "created_at", "updated_at"}).
+			AddRow(1, "My Ballot 1", "My Description 1", "", "", "", userID, true, nil, createdAt1, createdAt1).
+			AddRow(2, "My Ballot 2", "My Description 2", "", "", "", userID, false, nil, createdAt2, createdAt2)
 
-		testSetup.Mock.ExpectQuery(`SELECT id, title, description, creator_id, is_active, created_at, updated_at
-FROM ballots 
-WHERE creator_id = $1 
+		testSetup.Mock.ExpectQuery(`SELECT id, title, description, category, COALESCE(superstate, ''), COALESCE(state, ''), creator_id, is_active, closes_at, created_at, updated_at
+FROM ballots
+WHERE creator_id = $1
 ORDER BY created_at DESC`).
 			WithArgs(userID).
 			WillReturnRows(rows)
@@ -321,10 +799,10 @@ ORDER BY created_at DESC`).
 		email := "test@example.com"
 
 		// Mock empty result
-		rows := sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"})
-		testSetup.Mock.ExpectQuery(`SELECT id, title, description, creator_id, is_active, created_at, updated_at
-FROM ballots 
-WHERE creator_id = $1 
+		rows := sqlmock.NewRows([]string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "closes_at", "created_at", "updated_at"})
+		testSetup.Mock.ExpectQuery(`SELECT id, title, description, category, COALESCE(superstate, ''), COALESCE(state, ''), creator_id, is_active, closes_at, created_at, updated_at
+FROM ballots
+WHERE creator_id = $1
 ORDER BY created_at DESC`).
 			WithArgs(userID).
 			WillReturnRows(rows)
@@ -346,3 +824,3312 @@ ORDER BY created_at DESC`).
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
 }
+
+func TestScheduleBallotActivation(t *testing.T) {
+	const scheduleActivationStateSQL = "SELECT is_active, closed_at IS NOT NULL, moderated_at IS NOT NULL, closes_at, deactivate_at FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	scheduleActivationStateColumns := []string{"is_active", "closed", "moderated", "closes_at", "deactivate_at"}
+
+	t.Run("Schedule Activation Successfully", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		email := "test@example.com"
+		ballotID := 1
+		activateAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(ballotID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(userID, false))
+		testSetup.Mock.ExpectQuery(scheduleActivationStateSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(scheduleActivationStateColumns).AddRow(false, false, false, nil, nil))
+
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET activate_at = $1 WHERE id = $2 RETURNING id, is_active, activate_at, deactivate_at").
+			WithArgs(activateAt, ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "is_active", "activate_at", "deactivate_at"}).
+				AddRow(ballotID, false, activateAt, nil))
+
+		reqBody := map[string]interface{}{"activate_at": activateAt.Format(time.RFC3339)}
+		req, err := CreateAuthenticatedRequest("PUT", fmt.Sprintf("/api/v1/ballots/%d/activate-at", ballotID), reqBody, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, false, response["is_active"])
+		assert.Equal(t, activateAt.Format(time.RFC3339), response["activate_at"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Schedule Activation In The Past", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		reqBody := map[string]interface{}{"activate_at": time.Now().Add(-time.Hour).Format(time.RFC3339)}
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/activate-at", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "activate_at must be in the future")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Schedule Deactivation In The Past", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		reqBody := map[string]interface{}{"deactivate_at": time.Now().Add(-time.Minute).Format(time.RFC3339)}
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/deactivate-at", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "deactivate_at must be in the future")
+	})
+
+	t.Run("Schedule Activation By Non-Creator", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(2, false))
+
+		reqBody := map[string]interface{}{"activate_at": time.Now().Add(time.Hour).Format(time.RFC3339)}
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/activate-at", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Schedule Activation Of Moderated Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery(scheduleActivationStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(scheduleActivationStateColumns).AddRow(false, false, true, nil, nil))
+
+		reqBody := map[string]interface{}{"activate_at": time.Now().Add(time.Hour).Format(time.RFC3339)}
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/activate-at", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Ballot was deactivated by an administrator")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	rejected := []struct {
+		name    string
+		active  bool
+		closed  bool
+		offset  time.Duration
+		message string
+	}{
+		{"Live Ballot", true, false, 0, "Only draft ballots can be scheduled for activation"},
+		{"Closed Ballot", false, true, 0, "Only draft ballots can be scheduled for activation"},
+		{"At Deactivation Time", false, false, 0, "activate_at must be before deactivate_at"},
+		{"After Deactivation Time", false, false, time.Hour, "activate_at must be before deactivate_at"},
+	}
+	for _, tc := range rejected {
+		t.Run("Schedule Activation Rejects "+tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			deactivateAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+			testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+			testSetup.Mock.ExpectQuery(scheduleActivationStateSQL).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows(scheduleActivationStateColumns).AddRow(tc.active, tc.closed, false, nil, deactivateAt))
+
+			// A live or closed ballot is refused whatever the time; the others hit the ordering check
+			reqBody := map[string]interface{}{"activate_at": deactivateAt.Add(tc.offset).Format(time.RFC3339)}
+			if tc.active || tc.closed {
+				reqBody["activate_at"] = time.Now().Add(time.Hour).Format(time.RFC3339)
+			}
+			req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/activate-at", reqBody, 1, "test@example.com")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertErrorResponse(t, recorder, 400, tc.message)
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	future := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	closingTimes := []struct {
+		name         string
+		closesAt     interface{}
+		deactivateAt interface{}
+		activateAt   time.Time
+		message      string
+	}{
+		{"Passed Closing Time", past, nil, future, "Ballot closing time has already passed"},
+		{"Passed Deactivation Time", nil, past, future, "Ballot closing time has already passed"},
+		{"Activation After Closing Time", future, nil, future.Add(time.Hour), "activate_at must be before closes_at"},
+	}
+	for _, tc := range closingTimes {
+		t.Run("Schedule Activation Rejects "+tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+				WithArgs(1, 1).
+				WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+			testSetup.Mock.ExpectQuery(scheduleActivationStateSQL).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows(scheduleActivationStateColumns).AddRow(false, false, false, tc.closesAt, tc.deactivateAt))
+
+			reqBody := map[string]interface{}{"activate_at": tc.activateAt.Format(time.RFC3339)}
+			req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/activate-at", reqBody, 1, "test@example.com")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertErrorResponse(t, recorder, 400, tc.message)
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetBallotSimilarVoters(t *testing.T) {
+	expectBallotWithItems := func(mock sqlmock.Sqlmock, ballotID int) {
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(getBallotSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(ballotID, "Test Ballot", "Test Description", "", "", "", 2, true, "plurality", false, nil, createdAt, createdAt, 0))
+		mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, ballotID, "Option 1", "First option", 5, createdAt).
+				AddRow(2, ballotID, "Option 2", "Second option", 3, createdAt))
+	}
+
+	partyVotesSQL := `SELECT ballot_item_id, COUNT(*) as cnt
+FROM votes v
+JOIN user_political_affiliations upa ON v.user_id = upa.user_id
+WHERE v.ballot_id = $1 AND upa.party_affiliation = $2
+GROUP BY ballot_item_id
+ORDER BY cnt DESC
+LIMIT 1`
+
+	t.Run("User With Matching Party Data", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		ballotID := 1
+		expectBallotWithItems(testSetup.Mock, ballotID)
+		testSetup.Mock.ExpectQuery(voteEligibilitySQL).
+			WithArgs(ballotID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"closed", "deactivated"}).AddRow(false, false))
+		testSetup.Mock.ExpectQuery("SELECT party_affiliation FROM user_political_affiliations WHERE user_id = $1").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"party_affiliation"}).AddRow("Independent"))
+		testSetup.Mock.ExpectQuery(partyVotesSQL).
+			WithArgs(ballotID, "Independent").
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id", "cnt"}).AddRow(2, 4))
+
+		req, err := CreateAuthenticatedRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d?show_similar_voters=true", ballotID), nil, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Test Ballot", response["title"])
+		popular, ok := response["most_popular_among_your_party"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, float64(2), popular["item_id"])
+		assert.Equal(t, "Option 2", popular["title"])
+		assert.Equal(t, float64(4), popular["vote_count"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Without Party Data", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		ballotID := 1
+		expectBallotWithItems(testSetup.Mock, ballotID)
+		testSetup.Mock.ExpectQuery(voteEligibilitySQL).
+			WithArgs(ballotID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"closed", "deactivated"}).AddRow(false, false))
+		testSetup.Mock.ExpectQuery("SELECT party_affiliation FROM user_political_affiliations WHERE user_id = $1").
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateAuthenticatedRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d?show_similar_voters=true", ballotID), nil, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		value, present := response["most_popular_among_your_party"]
+		assert.True(t, present)
+		assert.Nil(t, value)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Requires Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1?show_similar_voters=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authentication required to show similar voters")
+	})
+}
+
+func TestGetBallotVoteEligibility(t *testing.T) {
+	expectBallot := func(mock sqlmock.Sqlmock, isActive bool) {
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(1, "Test Ballot", "Test Description", "", "", "", 2, isActive, "plurality", false, nil, createdAt, createdAt, 0))
+		mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, 1, "Option 1", "First option", 5, createdAt).
+				AddRow(2, 1, "Option 2", "Second option", 3, createdAt))
+	}
+
+	getEligibility := func(t *testing.T, testSetup *TestSetup, token string) map[string]interface{} {
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	userToken := func(t *testing.T) string {
+		token, err := utils.GenerateJWT(1, "voter@example.com")
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("Can Vote", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, true)
+		testSetup.Mock.ExpectQuery(voteEligibilitySQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"closed", "deactivated"}).AddRow(false, false))
+
+		response := getEligibility(t, testSetup, userToken(t))
+
+		assert.Equal(t, true, response["can_vote"])
+		assert.NotContains(t, response, "cannot_vote_reason")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Active", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, false)
+
+		response := getEligibility(t, testSetup, userToken(t))
+
+		assert.Equal(t, false, response["can_vote"])
+		assert.Equal(t, "Ballot is not active", response["cannot_vote_reason"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Closed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, true)
+		testSetup.Mock.ExpectQuery(voteEligibilitySQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"closed", "deactivated"}).AddRow(true, false))
+
+		response := getEligibility(t, testSetup, userToken(t))
+
+		assert.Equal(t, false, response["can_vote"])
+		assert.Equal(t, "Ballot is closed", response["cannot_vote_reason"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Account Deactivated", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, true)
+		testSetup.Mock.ExpectQuery(voteEligibilitySQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"closed", "deactivated"}).AddRow(false, true))
+
+		response := getEligibility(t, testSetup, userToken(t))
+
+		assert.Equal(t, false, response["can_vote"])
+		assert.Equal(t, "Account is deactivated", response["cannot_vote_reason"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Impersonating", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, true)
+		token, err := utils.GenerateImpersonationJWT(5, "target@example.com", 1, 30*time.Minute)
+		require.NoError(t, err)
+
+		response := getEligibility(t, testSetup, token)
+
+		assert.Equal(t, false, response["can_vote"])
+		assert.Equal(t, "Cannot vote while impersonating", response["cannot_vote_reason"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Anonymous Caller Gets No Eligibility", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallot(testSetup.Mock, true)
+
+		response := getEligibility(t, testSetup, "")
+
+		assert.NotContains(t, response, "can_vote")
+		assert.NotContains(t, response, "cannot_vote_reason")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAllBallotsCursorPagination(t *testing.T) {
+	t1 := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	t2 := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	t3 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ballotRow := func(rows *sqlmock.Rows, id int, createdAt time.Time) *sqlmock.Rows {
+		return rows.AddRow(id, fmt.Sprintf("Ballot %d", id), "", "", "", "", 1, true, createdAt, createdAt, nil, nil, "creator", 0, 2)
+	}
+
+	encodeCursor := func(createdAt time.Time, id int) string {
+		encoded, _ := json.Marshal(map[string]interface{}{"created_at": createdAt, "id": id})
+		return base64.RawURLEncoding.EncodeToString(encoded)
+	}
+
+	type pageResponse struct {
+		Ballots     []models.Ballot `json:"ballots"`
+		NextCursor  *string         `json:"next_cursor"`
+		NextPageURL *string         `json:"next_page_url"`
+		PrevCursor  *string         `json:"prev_cursor"`
+		PrevPageURL *string         `json:"prev_page_url"`
+	}
+
+	getPage := func(t *testing.T, testSetup *TestSetup, url string) pageResponse {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response pageResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("First Page Has Next URL", func(t *testing.T) {
+		t.Setenv("BASE_URL", "https://api.example.com")
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		rows := sqlmock.NewRows(listBallotsColumns)
+		ballotRow(rows, 3, t1)
+		ballotRow(rows, 2, t2)
+		ballotRow(rows, 1, t3)
+		testSetup.Mock.ExpectQuery(listBallotsSQL+" AND b.category = $1 ORDER BY b.created_at DESC, b.id DESC LIMIT $2").
+			WithArgs("local-civil", 3).
+			WillReturnRows(rows)
+
+		response := getPage(t, testSetup, "/api/v1/public/ballots?category=local-civil&limit=2")
+
+		require.Len(t, response.Ballots, 2)
+		assert.Equal(t, 3, response.Ballots[0].ID)
+		assert.Equal(t, 2, response.Ballots[1].ID)
+
+		cursor := encodeCursor(t2, 2)
+		require.NotNil(t, response.NextCursor)
+		assert.Equal(t, cursor, *response.NextCursor)
+		require.NotNil(t, response.NextPageURL)
+		assert.Equal(t, "https://api.example.com/api/v1/public/ballots?after_cursor="+cursor+"&category=local-civil&limit=2", *response.NextPageURL)
+		assert.Nil(t, response.PrevCursor)
+		assert.Nil(t, response.PrevPageURL)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Last Page Has Null Next URL", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		rows := sqlmock.NewRows(listBallotsColumns)
+		ballotRow(rows, 1, t3)
+		testSetup.Mock.ExpectQuery(listBallotsSQL+" AND (b.created_at, b.id) < ($1, $2) ORDER BY b.created_at DESC, b.id DESC LIMIT $3").
+			WithArgs(t2, 2, 3).
+			WillReturnRows(rows)
+
+		response := getPage(t, testSetup, "/api/v1/public/ballots?after_cursor="+encodeCursor(t2, 2)+"&limit=2")
+
+		require.Len(t, response.Ballots, 1)
+		assert.Nil(t, response.NextCursor)
+		assert.Nil(t, response.NextPageURL)
+		require.NotNil(t, response.PrevPageURL)
+		assert.Contains(t, *response.PrevPageURL, "before_cursor="+encodeCursor(t3, 1))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Backward Cursor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Fetched oldest first; ballot 3 is the extra row showing an earlier page exists
+		rows := sqlmock.NewRows(listBallotsColumns)
+		ballotRow(rows, 1, t3)
+		ballotRow(rows, 2, t2)
+		ballotRow(rows, 3, t1)
+		testSetup.Mock.ExpectQuery(listBallotsSQL+" AND (b.created_at, b.id) > ($1, $2) ORDER BY b.created_at ASC, b.id ASC LIMIT $3").
+			WithArgs(t3.Add(-24*time.Hour), 0, 3).
+			WillReturnRows(rows)
+
+		response := getPage(t, testSetup, "/api/v1/public/ballots?before_cursor="+encodeCursor(t3.Add(-24*time.Hour), 0)+"&limit=2")
+
+		require.Len(t, response.Ballots, 2)
+		assert.Equal(t, 2, response.Ballots[0].ID)
+		assert.Equal(t, 1, response.Ballots[1].ID)
+
+		require.NotNil(t, response.PrevCursor)
+		assert.Equal(t, encodeCursor(t2, 2), *response.PrevCursor)
+		require.NotNil(t, response.NextCursor)
+		assert.Equal(t, encodeCursor(t3, 1), *response.NextCursor)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?after_cursor=not-a-cursor", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid cursor")
+	})
+
+	t.Run("Both Cursors Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		cursor := encodeCursor(t2, 2)
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?after_cursor="+cursor+"&before_cursor="+cursor, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Use only one of after_cursor and before_cursor")
+	})
+}
+
+func TestGetAllBallotsOffsetPagination(t *testing.T) {
+	const countSQL = `SELECT COUNT(*) FROM ballots b JOIN users u ON b.creator_id = u.id WHERE b.is_active = true`
+	createdAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type offsetPage struct {
+		Data   []models.Ballot `json:"data"`
+		Total  int             `json:"total"`
+		Limit  int             `json:"limit"`
+		Offset int             `json:"offset"`
+	}
+
+	t.Run("Limit And Offset", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL + ` AND b.category = $1`).
+			WithArgs("local-civil").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+		testSetup.Mock.ExpectQuery(listBallotsSQL+` AND b.category = $1 ORDER BY b.created_at DESC LIMIT $2 OFFSET $3`).
+			WithArgs("local-civil", 2, 4).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(5, "Ballot 5", "", "local-civil", "", "", 1, true, createdAt, createdAt, nil, nil, "creator", 0, 2).
+				AddRow(4, "Ballot 4", "", "local-civil", "", "", 1, true, createdAt, createdAt, nil, nil, "creator", 0, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?category=local-civil&limit=2&offset=4", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response offsetPage
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, 5, response.Data[0].ID)
+		assert.Equal(t, 7, response.Total)
+		assert.Equal(t, 2, response.Limit)
+		assert.Equal(t, 4, response.Offset)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Default Limit And Cap", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(30))
+		testSetup.Mock.ExpectQuery(listBallotsSQL+` ORDER BY b.created_at DESC LIMIT $1 OFFSET $2`).
+			WithArgs(20, 40).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+		testSetup.Mock.ExpectQuery(countSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(30))
+		testSetup.Mock.ExpectQuery(listBallotsSQL+` ORDER BY b.created_at DESC LIMIT $1 OFFSET $2`).
+			WithArgs(100, 0).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+
+		for _, tc := range []struct {
+			url    string
+			limit  int
+			offset int
+		}{
+			{"/api/v1/public/ballots?offset=40", 20, 40},
+			{"/api/v1/public/ballots?offset=0&limit=500", 100, 0},
+		} {
+			req, err := CreateTestRequest("GET", tc.url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+
+			var response offsetPage
+			require.NoError(t, parseJSONResponse(recorder, &response))
+			assert.NotNil(t, response.Data)
+			assert.Empty(t, response.Data)
+			assert.Equal(t, 30, response.Total)
+			assert.Equal(t, tc.limit, response.Limit)
+			assert.Equal(t, tc.offset, response.Offset)
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		for url, message := range map[string]string{
+			"/api/v1/public/ballots?offset=-1":                 "Invalid offset",
+			"/api/v1/public/ballots?offset=abc":                "Invalid offset",
+			"/api/v1/public/ballots?offset=0&limit=0":          "Invalid limit",
+			"/api/v1/public/ballots?offset=0&after_cursor=abc": "Use either offset or a cursor, not both",
+		} {
+			req, err := CreateTestRequest("GET", url, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertErrorResponse(t, recorder, 400, message)
+		}
+	})
+}
+
+func TestGetCategories(t *testing.T) {
+	const categoriesSQL = "SELECT category, COUNT(*) FROM ballots WHERE is_active = true AND category != '' GROUP BY category ORDER BY category"
+
+	t.Run("Lists Categories With Counts", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(categoriesSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"category", "count"}).
+				AddRow("education", 4).
+				AddRow("infrastructure", 2).
+				AddRow("parks", 1))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/categories", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var categories []models.CategorySummary
+		require.NoError(t, parseJSONResponse(recorder, &categories))
+		assert.Equal(t, []models.CategorySummary{
+			{Category: "education", BallotCount: 4},
+			{Category: "infrastructure", BallotCount: 2},
+			{Category: "parks", BallotCount: 1},
+		}, categories)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Categories", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(categoriesSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"category", "count"}))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/categories", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, "[]", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(categoriesSQL).
+			WillReturnError(sql.ErrConnDone)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/categories", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 500, "Database error")
+	})
+}
+
+func TestCountBallots(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(`SELECT COUNT(*) FROM ballots WHERE is_active = true`).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/count", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, float64(12), response["total"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(`SELECT COUNT(*) FROM ballots WHERE is_active = true`).
+			WillReturnError(sql.ErrConnDone)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/count", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 500, "Database error")
+	})
+}
+
+func TestGetAllBallotsRecentlyVotedOn(t *testing.T) {
+	recentlyVotedSQL := listBallotsSQL + ` AND EXISTS (SELECT 1 FROM votes v WHERE v.ballot_id = b.id AND v.user_id = $1 AND v.created_at > NOW() - interval '7 days') ORDER BY (SELECT MAX(v.created_at) FROM votes v WHERE v.ballot_id = b.id AND v.user_id = $1) DESC NULLS LAST, b.created_at DESC`
+
+	t.Run("Only Ballots Voted On In The Last 7 Days", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		// The user voted on ballot 1 yesterday and ballot 2 a month ago; only ballot 1 matches the window
+		testSetup.Mock.ExpectQuery(recentlyVotedSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(1, "Recently Voted Ballot", "Voted yesterday", "", "", "", 2, true, createdAt, createdAt, nil, nil, "user2", 4, 2))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/public/ballots?recently_voted_on=true", nil, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		err = parseJSONResponse(recorder, &ballots)
+		require.NoError(t, err)
+
+		require.Len(t, ballots, 1)
+		assert.Equal(t, "Recently Voted Ballot", ballots[0].Title)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Recent Votes Returns Empty List", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 2
+
+		// The user's only votes are older than 7 days
+		testSetup.Mock.ExpectQuery(recentlyVotedSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/public/ballots?recently_voted_on=true", nil, userID, "old@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		err = parseJSONResponse(recorder, &ballots)
+		require.NoError(t, err)
+		assert.Len(t, ballots, 0)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Requires Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?recently_voted_on=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authentication required to filter by recently voted ballots")
+	})
+}
+
+func TestGetBallotQRCode(t *testing.T) {
+	t.Run("Returns PNG", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/qr-code?size=128", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=3600", recorder.Header().Get("Cache-Control"))
+		require.NotEmpty(t, recorder.Body.Bytes())
+		assert.Equal(t, "\x89PNG", string(recorder.Body.Bytes()[:4]))
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Size Out Of Range", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/qr-code?size=1024", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "size must be between 64 and 512")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(999).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/999/qr-code", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBallotAccessibility(t *testing.T) {
+	const accessibilityBallotSQL = `SELECT b.title, b.is_active, LEAST(b.closes_at, b.deactivate_at), COALESCE(b.ballot_type, 'plurality'), COALESCE(b.language, 'en'), u.username
+		FROM ballots b JOIN users u ON b.creator_id = u.id
+		WHERE b.id = $1 AND b.deleted_at IS NULL`
+	const accessibilityItemsSQL = "SELECT id, title, COALESCE(description, '') FROM ballot_items WHERE ballot_id = $1 ORDER BY id ASC"
+
+	itemRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "title", "description"}).
+			AddRow(1, "Yes", "Approve the measure").
+			AddRow(2, "No", "")
+	}
+
+	t.Run("Active Ballot With End Date", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		closesAt := time.Date(2026, 11, 3, 20, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery(accessibilityBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title", "is_active", "closes_at", "ballot_type", "language", "username"}).
+				AddRow("Park Levy", true, closesAt, "plurality", "en", "alice"))
+		testSetup.Mock.ExpectQuery(accessibilityItemsSQL).
+			WithArgs(1).
+			WillReturnRows(itemRows())
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/accessibility", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response models.BallotAccessibility
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Active ballot: Park Levy, created by alice, closes November 3, 2026", response.AriaLabel)
+		assert.Equal(t, 2, response.ItemCount)
+		assert.Equal(t, "To vote, select one of the following 2 options and submit.", response.Instructions)
+		assert.Equal(t, "en", response.Language)
+		require.Len(t, response.Items, 2)
+		assert.Equal(t, "Option 1 of 2: Yes. Approve the measure", response.Items[0].AriaDescription)
+		assert.Equal(t, 2, response.Items[1].Position)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Active Ballot Without End Date", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(accessibilityBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title", "is_active", "closes_at", "ballot_type", "language", "username"}).
+				AddRow("Park Levy", true, nil, "plurality", "en", "alice"))
+		testSetup.Mock.ExpectQuery(accessibilityItemsSQL).
+			WithArgs(1).
+			WillReturnRows(itemRows())
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/accessibility", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		var response models.BallotAccessibility
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Active ballot: Park Levy, created by alice, closes no end date", response.AriaLabel)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Closed Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(accessibilityBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title", "is_active", "closes_at", "ballot_type", "language", "username"}).
+				AddRow("Park Levy", false, nil, "plurality", "en", "alice"))
+		testSetup.Mock.ExpectQuery(accessibilityItemsSQL).
+			WithArgs(1).
+			WillReturnRows(itemRows())
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/accessibility", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		var response models.BallotAccessibility
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Closed ballot: Park Levy, created by alice, no longer accepting votes", response.AriaLabel)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(accessibilityBallotSQL).
+			WithArgs(999).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/999/accessibility", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotAnnouncements(t *testing.T) {
+	const recentAnnouncementSQL = "SELECT EXISTS(SELECT 1 FROM ballot_announcements WHERE ballot_id = b.id AND created_at > NOW() - interval '1 hour') FROM ballots b WHERE b.id = $1 AND b.deleted_at IS NULL FOR UPDATE"
+
+	t.Run("Announcement Notifies Voters", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(recentAnnouncementSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_announcements (ballot_id, creator_id, message) VALUES ($1, $2, $3) RETURNING id, ballot_id, creator_id, message, created_at").
+			WithArgs(1, 1, "Results are final").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "creator_id", "message", "created_at"}).
+				AddRow(7, 1, 1, "Results are final", createdAt))
+		testSetup.Mock.ExpectExec(`INSERT INTO user_notifications (user_id, ballot_id, message)
+		SELECT voters.user_id, $1, $2 FROM (SELECT DISTINCT user_id FROM votes WHERE ballot_id = $1 AND user_id IS NOT NULL LIMIT $3) voters`).
+			WithArgs(1, "Results are final", 10000).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := models.CreateAnnouncementRequest{Message: "Results are final"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/announcements", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+		assert.Equal(t, float64(3), response["voters_notified"])
+
+		announcement := response["announcement"].(map[string]interface{})
+		assert.Equal(t, float64(7), announcement["id"])
+		assert.Equal(t, "Results are final", announcement["message"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Announcement Rate Limited", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(recentAnnouncementSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectRollback()
+
+		reqBody := models.CreateAnnouncementRequest{Message: "Reopening tomorrow"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/announcements", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 429, "An announcement was already made for this ballot in the last hour")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Creator Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(2, false))
+
+		reqBody := models.CreateAnnouncementRequest{Message: "Hello"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/announcements", reqBody, 1, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("List Announcements", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_id, creator_id, message, created_at FROM ballot_announcements WHERE ballot_id = $1 ORDER BY created_at DESC").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "creator_id", "message", "created_at"}).
+				AddRow(7, 1, 1, "Results are final", createdAt))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/announcements", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response []models.BallotAnnouncement
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+		require.Len(t, response, 1)
+		assert.Equal(t, "Results are final", response[0].Message)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestSearchBallots(t *testing.T) {
+	const searchSelect = `SELECT b.id, b.title, COALESCE(b.description, ''), COALESCE(b.category, ''), b.created_at, ts_rank(to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')), query)`
+	const searchFrom = `FROM ballots b, plainto_tsquery('english', $1) query
+		WHERE b.is_active = true AND to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')) @@ query
+		ORDER BY rank DESC, b.created_at DESC
+		LIMIT 50`
+	const headlineColumn = `, ts_headline('english', b.title || ' ' || COALESCE(b.description, ''), query, 'StartSel=<mark>,StopSel=</mark>') AS headline`
+	const boost = ` * GREATEST(1, 1 + ($2::float - extract(epoch from age(b.created_at))/86400) / $2::float)`
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Without Highlight", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(searchSelect + ` AS rank ` + searchFrom).
+			WithArgs("vermont").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "created_at", "rank"}).
+				AddRow(1, "Vermont Environmental Policy Initiative", "", "environment", createdAt, 0.6))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/search?q=vermont", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		results := response["results"].([]interface{})
+		require.Len(t, results, 1)
+		assert.NotContains(t, results[0].(map[string]interface{}), "headline")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("With Highlight", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(searchSelect + ` AS rank` + headlineColumn + ` ` + searchFrom).
+			WithArgs("vermont").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "created_at", "rank", "headline"}).
+				AddRow(1, "Vermont Environmental Policy Initiative", "", "environment", createdAt, 0.6, "<mark>Vermont</mark> Environmental Policy Initiative"))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/search?q=vermont&highlight=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Results []models.BallotSearchResult `json:"results"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Results, 1)
+		require.NotNil(t, response.Results[0].Headline)
+		assert.Equal(t, "<mark>Vermont</mark> Environmental Policy Initiative", *response.Results[0].Headline)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Boost Recent Ballots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// boost_recent_days is capped at 90
+		testSetup.Mock.ExpectQuery(searchSelect+boost+` AS rank `+searchFrom).
+			WithArgs("policy", 90).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "created_at", "rank"}).
+				AddRow(2, "New Policy", "", "", createdAt.AddDate(0, 0, 30), 0.9).
+				AddRow(1, "Old Policy", "", "", createdAt, 0.5))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/search?q=policy&boost_recent_days=365", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Results []models.BallotSearchResult `json:"results"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, 2, response.Results[0].ID)
+		assert.Greater(t, response.Results[0].Rank, response.Results[1].Rank)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Query", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/search", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "q is required")
+	})
+}
+
+func TestCheckDuplicateBallots(t *testing.T) {
+	const checkDuplicateSQL = `SELECT b.id, b.title, ts_rank(to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')), plainto_tsquery('english', $1)) AS sim
+		FROM ballots b
+		WHERE b.is_active = true AND to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')) @@ plainto_tsquery('english', $1)
+		ORDER BY sim DESC
+		LIMIT 5`
+	similarColumns := []string{"id", "title", "sim"}
+
+	checkDuplicates := func(t *testing.T, testSetup *TestSetup, body interface{}) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/check-duplicate", body, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Strong Match Is Likely Duplicate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(checkDuplicateSQL).
+			WithArgs("Vermont Parks Funding").
+			WillReturnRows(sqlmock.NewRows(similarColumns).
+				AddRow(4, "Vermont Parks Funding Measure", 0.61).
+				AddRow(9, "Parks Funding in Vermont Towns", 0.2))
+
+		recorder := checkDuplicates(t, testSetup, models.CheckDuplicateRequest{Title: "Vermont Parks Funding", Description: "Should the state fund parks?"})
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, true, response["is_duplicate_likely"])
+		similar := response["similar_ballots"].([]interface{})
+		require.Len(t, similar, 2)
+		assert.Equal(t, map[string]interface{}{
+			"id":               float64(4),
+			"title":            "Vermont Parks Funding Measure",
+			"similarity_score": 0.61,
+		}, similar[0])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Score At Threshold Is Not Likely Duplicate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(checkDuplicateSQL).
+			WithArgs("Vermont Parks Funding").
+			WillReturnRows(sqlmock.NewRows(similarColumns).
+				AddRow(4, "Vermont Parks Funding Measure", 0.5))
+
+		recorder := checkDuplicates(t, testSetup, models.CheckDuplicateRequest{Title: "Vermont Parks Funding"})
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, false, response["is_duplicate_likely"])
+		assert.Len(t, response["similar_ballots"], 1)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Matches", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(checkDuplicateSQL).
+			WithArgs("Library Hours").
+			WillReturnRows(sqlmock.NewRows(similarColumns))
+
+		recorder := checkDuplicates(t, testSetup, models.CheckDuplicateRequest{Title: "Library Hours"})
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"similar_ballots":[],"is_duplicate_likely":false}`, recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing Title", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := checkDuplicates(t, testSetup, map[string]string{"description": "No title"})
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBallotCache(t *testing.T) {
+	expectBallotQueries := func(mock sqlmock.Sqlmock, ballotID int) {
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(getBallotSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(ballotID, "Cached Ballot", "Description", "", "", "", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
+		mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, ballotID, "Option 1", "", 3, createdAt).
+				AddRow(2, ballotID, "Option 2", "", 1, createdAt))
+	}
+
+	t.Run("Cache Miss Populates Cache", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallotQueries(testSetup.Mock, 1)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Contains(t, ballotCache.Values, "ballot:1")
+		assert.Equal(t, 30*time.Second, ballotCache.TTLs["ballot:1"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cache Hit Skips Database", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		ballotCache.Values["ballot:1"] = []byte(`{"id":1,"title":"Cached Ballot","is_active":true,"options":[{"id":1,"ballot_id":1,"title":"Option 1","vote_count":3}]}`)
+
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response models.Ballot
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+		assert.Equal(t, "Cached Ballot", response.Title)
+		require.Len(t, response.Items, 1)
+
+		// No database expectations were registered, so any query would fail here
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Change Invalidates Cache", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		ballotCache.Values["ballot:1"] = []byte(`{"id":1,"title":"Cached Ballot"}`)
+
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		deactivateAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET deactivate_at = $1 WHERE id = $2 RETURNING id, is_active, activate_at, deactivate_at").
+			WithArgs(deactivateAt, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "is_active", "activate_at", "deactivate_at"}).
+				AddRow(1, true, nil, deactivateAt))
+
+		reqBody := models.ScheduleDeactivationRequest{DeactivateAt: deactivateAt}
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/ballots/1/deactivate-at", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NotContains(t, ballotCache.Values, "ballot:1")
+
+		// The next read goes back to the database
+		expectBallotQueries(testSetup.Mock, 1)
+
+		req, err = CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder = httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateBallot(t *testing.T) {
+	const updateColumnsSQL = " RETURNING id, title, description, category, creator_id, is_active, created_at, updated_at"
+	updatedColumns := []string{"id", "title", "description", "category", "creator_id", "is_active", "created_at", "updated_at"}
+
+	patch := func(t *testing.T, testSetup *TestSetup, url string, body interface{}, userID int) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("PATCH", url, body, userID, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Deactivate Closes Ballot Without Lock Check", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Park Budget", "", true, false, false))
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET is_active = $1, closed_at = NOW(), deactivate_at = NULL WHERE id = $2"+updateColumnsSQL).
+			WithArgs(false, 1).
+			WillReturnRows(sqlmock.NewRows(updatedColumns).AddRow(1, "Park Budget", "", "", 1, false, now, now))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]bool{"is_active": false}, 1)
+		require.Equal(t, 200, recorder.Code)
+
+		var ballot models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballot))
+		assert.False(t, ballot.IsActive)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Title And Active Flag Together", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery(ballotLockedSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Park Budgett", "", false, false, false))
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET title = $1, is_active = $2, closed_at = NULL WHERE id = $3"+updateColumnsSQL).
+			WithArgs("Park Budget", true, 1).
+			WillReturnRows(sqlmock.NewRows(updatedColumns).AddRow(1, "Park Budget", "", "", 1, true, now, now))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 1, "title", "Park Budgett", "Park Budget").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]interface{}{"title": "Park Budget", "is_active": true}, 1)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Other User Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]bool{"is_active": false}, 2)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(99, 1).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/99", map[string]string{"title": "New Title"}, 1)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Deleted Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectRollback()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]bool{"is_active": true}, 1)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Reopen Ballot With Closing Time", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Park Budget", "", false, true, false))
+		testSetup.Mock.ExpectRollback()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]bool{"is_active": true}, 1)
+
+		AssertErrorResponse(t, recorder, 400, "Ballots with a scheduled closing time cannot be reopened")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Reactivate Ballot Deactivated By Admin", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Park Budget", "", false, false, true))
+		testSetup.Mock.ExpectRollback()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]bool{"is_active": true}, 1)
+
+		AssertErrorResponse(t, recorder, 403, "Ballot was deactivated by an administrator")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Fields", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/1", map[string]string{}, 1)
+
+		AssertErrorResponse(t, recorder, 400, "No fields to update")
+	})
+
+	t.Run("Invalid Ballot ID", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := patch(t, testSetup, "/api/v1/ballots/abc", map[string]string{"title": "New Title"}, 1)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid ballot ID")
+	})
+}
+
+func TestBallotCoCreators(t *testing.T) {
+	const updateBallotSQL = "UPDATE ballots SET title = $1 WHERE id = $2 RETURNING id, title, description, category, creator_id, is_active, created_at, updated_at"
+
+	t.Run("Co-Creator Updates Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, true))
+		testSetup.Mock.ExpectQuery(ballotLockedSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Corected Title", "", true, false, false))
+		testSetup.Mock.ExpectQuery(updateBallotSQL).
+			WithArgs("Corrected Title", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "creator_id", "is_active", "created_at", "updated_at"}).
+				AddRow(1, "Corrected Title", "", "", 1, true, now, now))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 2, "title", "Corected Title", "Corrected Title").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := map[string]string{"title": "Corrected Title"}
+		req, err := CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", reqBody, 2, "cocreator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballot models.Ballot
+		err = parseJSONResponse(recorder, &ballot)
+		require.NoError(t, err)
+		assert.Equal(t, "Corrected Title", ballot.Title)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Co-Creator Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+
+		reqBody := map[string]string{"title": "Hijacked Title"}
+		req, err := CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", reqBody, 3, "other@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Creator Adds Co-Creator", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		addedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery("SELECT creator_id FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id"}).AddRow(1))
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)").
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("INSERT INTO ballot_co_creators (ballot_id, user_id, added_by) VALUES ($1, $2, $3) ON CONFLICT (ballot_id, user_id) DO NOTHING RETURNING added_at").
+			WithArgs(1, 2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"added_at"}).AddRow(addedAt))
+
+		reqBody := models.AddCoCreatorRequest{UserID: 2}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/add-co-creator", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+
+		var coCreator models.BallotCoCreator
+		err = parseJSONResponse(recorder, &coCreator)
+		require.NoError(t, err)
+		assert.Equal(t, models.BallotCoCreator{BallotID: 1, UserID: 2, AddedBy: 1, AddedAt: addedAt}, coCreator)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Creator Removes Co-Creator", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT creator_id FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id"}).AddRow(1))
+		testSetup.Mock.ExpectExec("DELETE FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/ballots/1/remove-co-creator/2", nil, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Co-creator removed successfully"})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Co-Creator Cannot Manage Co-Creators", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT creator_id FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id"}).AddRow(1))
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/ballots/1/remove-co-creator/3", nil, 2, "cocreator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Only the original ballot creator can manage co-creators")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetSuperstateBallots(t *testing.T) {
+	const countSQL = "SELECT COUNT(*) FROM ballots WHERE superstate = $1 AND is_active = true"
+	const superstateBallotsSQL = `SELECT b.id, b.title, COALESCE(b.description, ''), COALESCE(b.category, ''), COALESCE(b.superstate, ''), COALESCE(b.state, ''), b.creator_id, b.is_active, COALESCE(b.ballot_type, 'plurality'), COALESCE(b.locked, false), b.closes_at, b.created_at, b.updated_at,
+		       bi.id, bi.title, bi.description, COALESCE(vc.vote_count, 0)
+		FROM (
+			SELECT * FROM ballots
+			WHERE superstate = $1 AND is_active = true
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2 OFFSET $3
+		) b
+		LEFT JOIN ballot_items bi ON bi.ballot_id = b.id
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		ORDER BY b.created_at DESC, b.id DESC, bi.id ASC`
+	columns := []string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "ballot_type", "locked", "closes_at", "created_at", "updated_at", "item_id", "item_title", "item_description", "vote_count"}
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type superstatePage struct {
+		Superstate string          `json:"superstate"`
+		Data       []models.Ballot `json:"data"`
+		Total      int             `json:"total"`
+		Limit      int             `json:"limit"`
+		Offset     int             `json:"offset"`
+	}
+
+	get := func(t *testing.T, testSetup *TestSetup, url string) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Items Match Ballot Endpoint", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL).
+			WithArgs("new-england").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		testSetup.Mock.ExpectQuery(superstateBallotsSQL).
+			WithArgs("new-england", 20, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(2, "Harbor Dredging", "Dredge the harbor", "infrastructure", "new-england", "maine", 1, true, "plurality", false, nil, createdAt, createdAt, 3, "Yes", "Fund it", 5).
+				AddRow(2, "Harbor Dredging", "Dredge the harbor", "infrastructure", "new-england", "maine", 1, true, "plurality", false, nil, createdAt, createdAt, 4, "No", "Do not fund it", 2).
+				AddRow(1, "Town Green", "", "", "new-england", "vermont", 1, true, "plurality", false, nil, createdAt, createdAt, nil, nil, nil, nil))
+
+		recorder := get(t, testSetup, "/api/v1/public/superstates/new-england/ballots")
+		require.Equal(t, 200, recorder.Code)
+
+		var page superstatePage
+		require.NoError(t, parseJSONResponse(recorder, &page))
+		assert.Equal(t, "new-england", page.Superstate)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, 20, page.Limit)
+		assert.Equal(t, 0, page.Offset)
+		require.Len(t, page.Data, 2)
+		assert.Equal(t, 2, page.Data[0].ItemCount)
+		assert.Equal(t, 7, page.Data[0].TotalVotes)
+		assert.Empty(t, page.Data[1].Items)
+		assert.Equal(t, 0, page.Data[1].ItemCount)
+
+		// The embedded items carry the same fields as GET /public/ballots/:id
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(2, "Harbor Dredging", "Dredge the harbor", "infrastructure", "new-england", "maine", 1, true, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(3, 2, "Yes", "Fund it", 5, createdAt).
+				AddRow(4, 2, "No", "Do not fund it", 2, createdAt))
+
+		recorder = get(t, testSetup, "/api/v1/public/ballots/2")
+		require.Equal(t, 200, recorder.Code)
+
+		var single models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &single))
+		for i := range single.Items {
+			single.Items[i].UpdatedAt = nil
+		}
+		assert.Equal(t, single.Items, page.Data[0].Items)
+		assert.Equal(t, single.Title, page.Data[0].Title)
+		assert.Equal(t, single.State, page.Data[0].State)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Pagination Params", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL).
+			WithArgs("texas").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		testSetup.Mock.ExpectQuery(superstateBallotsSQL).
+			WithArgs("texas", 100, 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		recorder := get(t, testSetup, "/api/v1/public/superstates/texas/ballots?limit=500&offset=10")
+		require.Equal(t, 200, recorder.Code)
+
+		var page superstatePage
+		require.NoError(t, parseJSONResponse(recorder, &page))
+		assert.NotNil(t, page.Data)
+		assert.Empty(t, page.Data)
+		assert.Equal(t, 100, page.Limit)
+		assert.Equal(t, 10, page.Offset)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Offset", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		AssertErrorResponse(t, get(t, testSetup, "/api/v1/public/superstates/texas/ballots?offset=-5"), 400, "Invalid offset")
+	})
+}
+
+func TestGetBallotsByState(t *testing.T) {
+	const stateFilter = ` AND b.state = $1`
+	const countSQL = `SELECT COUNT(*) FROM ballots b
+JOIN users u ON b.creator_id = u.id
+WHERE b.is_active = true` + stateFilter
+	const stateBallotsSQL = listBallotsSQL + stateFilter + ` ORDER BY b.created_at DESC, b.id DESC LIMIT $2 OFFSET $3`
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type statePage struct {
+		State  string          `json:"state"`
+		Data   []models.Ballot `json:"data"`
+		Total  int             `json:"total"`
+		Limit  int             `json:"limit"`
+		Offset int             `json:"offset"`
+	}
+
+	get := func(t *testing.T, testSetup *TestSetup, url string) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Filters By State", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL).
+			WithArgs("new-york-city").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		testSetup.Mock.ExpectQuery(stateBallotsSQL).
+			WithArgs("new-york-city", 20, 0).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(7, "Subway Hours", "Run trains all night", "transit", "new-york", "new-york-city", 1, true, createdAt, createdAt, nil, nil, "user1", 12, 2))
+
+		recorder := get(t, testSetup, "/api/v1/public/states/new-york-city/ballots")
+		require.Equal(t, 200, recorder.Code)
+
+		var page statePage
+		require.NoError(t, parseJSONResponse(recorder, &page))
+		assert.Equal(t, "new-york-city", page.State)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, 20, page.Limit)
+		assert.Equal(t, 0, page.Offset)
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, "Subway Hours", page.Data[0].Title)
+		assert.Equal(t, "new-york-city", page.Data[0].State)
+		assert.Equal(t, 12, page.Data[0].TotalVotes)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Pagination Params", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(countSQL).
+			WithArgs("maine").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		testSetup.Mock.ExpectQuery(stateBallotsSQL).
+			WithArgs("maine", 100, 40).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+
+		recorder := get(t, testSetup, "/api/v1/public/states/maine/ballots?limit=250&offset=40")
+		require.Equal(t, 200, recorder.Code)
+
+		var page statePage
+		require.NoError(t, parseJSONResponse(recorder, &page))
+		assert.NotNil(t, page.Data)
+		assert.Empty(t, page.Data)
+		assert.Equal(t, 100, page.Limit)
+		assert.Equal(t, 40, page.Offset)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid State", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		for _, state := range []string{"New-York", "new_york", "-maine", "maine-", "new--york", "%20"} {
+			AssertErrorResponse(t, get(t, testSetup, "/api/v1/public/states/"+state+"/ballots"), 400, "State must be a lowercase slug such as new-york-city")
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		AssertErrorResponse(t, get(t, testSetup, "/api/v1/public/states/maine/ballots?limit=0"), 400, "Invalid limit")
+	})
+}
+
+func TestGetAllBallotsSearch(t *testing.T) {
+	const searchClause = ` AND to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')) @@ plainto_tsquery('english', $1)`
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	search := func(t *testing.T, testSetup *TestSetup, url string) []models.Ballot {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballots))
+		return ballots
+	}
+
+	t.Run("Matches Title And Description", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL + searchClause + ` ORDER BY b.created_at DESC`).
+			WithArgs("park funding").
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(1, "Park Funding", "", "", "", "", 1, true, createdAt, createdAt, nil, nil, "creator", 0, 2).
+				AddRow(2, "City Budget", "Adds funding for every park", "", "", "", 1, true, createdAt, createdAt, nil, nil, "creator", 0, 2))
+
+		ballots := search(t, testSetup, "/api/v1/public/ballots?search=park+funding")
+
+		require.Len(t, ballots, 2)
+		assert.Equal(t, "Park Funding", ballots[0].Title)
+		assert.Equal(t, "City Budget", ballots[1].Title)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Match Returns Empty List", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL+` AND b.category = $1 AND to_tsvector('english', b.title || ' ' || COALESCE(b.description, '')) @@ plainto_tsquery('english', $2) ORDER BY b.created_at DESC`).
+			WithArgs("local-civil", "zoning").
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+
+		ballots := search(t, testSetup, "/api/v1/public/ballots?category=local-civil&search=zoning")
+
+		assert.Empty(t, ballots)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAllBallotsClosingSoon(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	closesSoon := time.Now().Add(6 * time.Hour).UTC().Truncate(time.Second)
+	closesLater := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+
+	t.Run("Sort By Closing Soon", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.closes_at ASC NULLS LAST, b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(2, "Closing Soon", "", "", "", "", 1, true, createdAt, createdAt, closesSoon, 6.0, "user1", 0, 2).
+				AddRow(3, "Closing Later", "", "", "", "", 1, true, createdAt, createdAt, closesLater, 72.0, "user1", 0, 2).
+				AddRow(1, "Open Ended", "", "", "", "", 1, true, createdAt, createdAt, nil, nil, "user1", 0, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?sort=closing_soon", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		err = parseJSONResponse(recorder, &ballots)
+		require.NoError(t, err)
+
+		require.Len(t, ballots, 3)
+		assert.Equal(t, []int{2, 3, 1}, []int{ballots[0].ID, ballots[1].ID, ballots[2].ID})
+		require.NotNil(t, ballots[0].HoursRemaining)
+		assert.Equal(t, 6.0, *ballots[0].HoursRemaining)
+		assert.Nil(t, ballots[2].ClosesAt)
+		assert.Nil(t, ballots[2].HoursRemaining)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Only Closing Within 48 Hours", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` AND b.closes_at IS NOT NULL AND b.closes_at > NOW() AND b.closes_at < NOW() + interval '48 hours' ORDER BY b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(2, "Closing Soon", "", "", "", "", 1, true, createdAt, createdAt, closesSoon, 6.0, "user1", 0, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?only_closing_soon=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		err = parseJSONResponse(recorder, &ballots)
+		require.NoError(t, err)
+
+		require.Len(t, ballots, 1)
+		assert.Equal(t, 2, ballots[0].ID)
+		require.NotNil(t, ballots[0].ClosesAt)
+		assert.True(t, ballots[0].ClosesAt.Equal(closesSoon))
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Sort", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?sort=popular", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "sort must be closing_soon")
+	})
+}
+
+func TestGetAllBallotsByCreatorUserID(t *testing.T) {
+	const userExistsSQL = "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)"
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Composes With Superstate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(userExistsSQL).
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(listBallotsSQL+` AND b.superstate = $1 AND b.creator_id = $2 ORDER BY b.created_at DESC`).
+			WithArgs("Northeast", 7).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(4, "Harbor Dredging", "", "", "Northeast", "Maine", 7, true, createdAt, createdAt, nil, nil, "creator7", 0, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?superstate=Northeast&creator_user_id=7", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballots))
+		require.Len(t, ballots, 1)
+		assert.Equal(t, 7, ballots[0].CreatorID)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(userExistsSQL).
+			WithArgs(999).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?creator_user_id=999", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	for _, value := range []string{"abc", "0", "-3"} {
+		t.Run("Invalid Value "+value, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			req, err := CreateTestRequest("GET", "/api/v1/public/ballots?creator_user_id="+value, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertErrorResponse(t, recorder, 400, "creator_user_id must be a positive integer")
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBallotLocking(t *testing.T) {
+	const updateBallotSQL = "UPDATE ballots SET title = $1 WHERE id = $2 RETURNING id, title, description, category, creator_id, is_active, created_at, updated_at"
+
+	t.Run("Lock Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectExec("UPDATE ballots SET locked = $1 WHERE id = $2").
+			WithArgs(true, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/lock", nil, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"id": 1, "locked": true})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Locked Ballot Rejects Title Change", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery(ballotLockedSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+
+		reqBody := map[string]string{"title": "New Title"}
+		req, err := CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 409, "Ballot is locked")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unlock Then Update", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectExec("UPDATE ballots SET locked = $1 WHERE id = $2").
+			WithArgs(false, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery(ballotLockedSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Old Title", "", true, false, false))
+		testSetup.Mock.ExpectQuery(updateBallotSQL).
+			WithArgs("New Title", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "creator_id", "is_active", "created_at", "updated_at"}).
+				AddRow(1, "New Title", "", "", 1, false, now, now))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 1, "title", "Old Title", "New Title").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/unlock", nil, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"id": 1, "locked": false})
+
+		reqBody := map[string]string{"title": "New Title"}
+		req, err = CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder = httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestCloseAndReopenBallot(t *testing.T) {
+	const closeStateSQL = "SELECT is_active, closes_at IS NOT NULL, moderated_at IS NOT NULL FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	const closeBallotSQL = "UPDATE ballots SET is_active = false, closed_at = NOW(), deactivate_at = NULL WHERE id = $1 RETURNING closed_at"
+	const reopenBallotSQL = "UPDATE ballots SET is_active = true, closed_at = NULL WHERE id = $1"
+	closeStateColumns := []string{"is_active", "scheduled_close", "moderated"}
+
+	expectCreator := func(testSetup *TestSetup, ballotID, userID, creatorID int) {
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(ballotID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(creatorID, false))
+	}
+
+	post := func(t *testing.T, testSetup *TestSetup, url string, userID int) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("POST", url, nil, userID, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Creator Closes Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		closedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(true, false, false))
+		testSetup.Mock.ExpectQuery(closeBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"closed_at"}).AddRow(closedAt))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/close", 1)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{
+			"id":        1,
+			"is_active": false,
+			"closed_at": "2026-05-01T12:00:00Z",
+		})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Co-Creator Closes Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		closedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, true))
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(true, false, false))
+		testSetup.Mock.ExpectQuery(closeBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"closed_at"}).AddRow(closedAt))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/close", 2)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{
+			"id":        1,
+			"is_active": false,
+			"closed_at": "2026-05-01T12:00:00Z",
+		})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Creator Cannot Close", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 2, 1)
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/close", 2)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(false, false, false))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/close", 1)
+
+		AssertErrorResponse(t, recorder, 400, "Ballot is already closed")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(99, 1).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := post(t, testSetup, "/api/v1/ballots/99/close", 1)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Creator Reopens Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(false, false, false))
+		testSetup.Mock.ExpectExec(reopenBallotSQL).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/reopen", 1)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"id": 1, "is_active": true})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Creator Cannot Reopen", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 2, 1)
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/reopen", 2)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can modify this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Reopen Open Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(true, false, false))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/reopen", 1)
+
+		AssertErrorResponse(t, recorder, 400, "Ballot is already open")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Reopen Ballot With Closing Time", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(false, true, false))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/reopen", 1)
+
+		AssertErrorResponse(t, recorder, 400, "Ballots with a scheduled closing time cannot be reopened")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Cannot Reopen Ballot Deactivated By Admin", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectCreator(testSetup, 1, 1, 1)
+		testSetup.Mock.ExpectQuery(closeStateSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(closeStateColumns).AddRow(false, false, true))
+
+		recorder := post(t, testSetup, "/api/v1/ballots/1/reopen", 1)
+
+		AssertErrorResponse(t, recorder, 403, "Ballot was deactivated by an administrator")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetSitemapXML(t *testing.T) {
+	t.Run("Lists Active Ballots", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM ballots WHERE is_active = true").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		testSetup.Mock.ExpectQuery("SELECT id, updated_at FROM ballots WHERE is_active = true ORDER BY id LIMIT $1 OFFSET $2").
+			WithArgs(50000, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).
+				AddRow(1, updatedAt).
+				AddRow(7, updatedAt))
+
+		req, err := CreateTestRequest("GET", "/sitemap.xml", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/xml", recorder.Header().Get("Content-Type"))
+
+		var sitemap struct {
+			XMLName xml.Name `xml:"urlset"`
+			URLs    []struct {
+				Loc        string `xml:"loc"`
+				LastMod    string `xml:"lastmod"`
+				ChangeFreq string `xml:"changefreq"`
+				Priority   string `xml:"priority"`
+			} `xml:"url"`
+		}
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &sitemap))
+
+		require.Len(t, sitemap.URLs, 2)
+		assert.Equal(t, "http://localhost:3000/ballot-detail.html?id=7", sitemap.URLs[1].Loc)
+		assert.Equal(t, "2026-01-02T03:04:05Z", sitemap.URLs[0].LastMod)
+		assert.Equal(t, "daily", sitemap.URLs[0].ChangeFreq)
+		assert.Equal(t, "0.8", sitemap.URLs[0].Priority)
+
+		assert.Equal(t, time.Hour, ballotCache.TTLs["sitemap:"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Index For Large Sets", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM ballots WHERE is_active = true").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(120000))
+
+		req, err := CreateTestRequest("GET", "/sitemap.xml", nil)
+		require.NoError(t, err)
+		req.Host = "api.example.com"
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var index struct {
+			XMLName  xml.Name `xml:"sitemapindex"`
+			Sitemaps []struct {
+				Loc string `xml:"loc"`
+			} `xml:"sitemap"`
+		}
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &index))
+
+		require.Len(t, index.Sitemaps, 3)
+		assert.Equal(t, "http://api.example.com/sitemap.xml?page=3", index.Sitemaps[2].Loc)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotChangelog(t *testing.T) {
+	const updateBallotColumnsSQL = " RETURNING id, title, description, category, creator_id, is_active, created_at, updated_at"
+	const changelogSQL = "SELECT changed_by, field, old_value, new_value, changed_at FROM ballot_changelog WHERE ballot_id = $1 ORDER BY changed_at ASC, id ASC"
+	updatedColumns := []string{"id", "title", "description", "category", "creator_id", "is_active", "created_at", "updated_at"}
+
+	expectEditable := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "is_co_creator"}).AddRow(1, false))
+		testSetup.Mock.ExpectQuery(ballotLockedSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	}
+
+	t.Run("Title Change Recorded", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		expectEditable(testSetup)
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Park Budgett", "Annual parks budget", true, false, false))
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET title = $1 WHERE id = $2"+updateBallotColumnsSQL).
+			WithArgs("Park Budget", 1).
+			WillReturnRows(sqlmock.NewRows(updatedColumns).
+				AddRow(1, "Park Budget", "Annual parks budget", "", 1, true, now, now))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 1, "title", "Park Budgett", "Park Budget").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		req, err := CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", map[string]string{"title": "Park Budget"}, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Title And Description Change Creates Two Rows", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		expectEditable(testSetup)
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery(ballotEditLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(ballotEditLookupColumns).AddRow("Old Title", "Old description", true, false, false))
+		testSetup.Mock.ExpectQuery("UPDATE ballots SET title = $1, description = $2 WHERE id = $3"+updateBallotColumnsSQL).
+			WithArgs("New Title", "New description", 1).
+			WillReturnRows(sqlmock.NewRows(updatedColumns).
+				AddRow(1, "New Title", "New description", "", 1, true, now, now))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 1, "title", "Old Title", "New Title").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec(changelogInsertSQL).
+			WithArgs(1, 1, "description", "Old description", "New description").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := map[string]string{"title": "New Title", "description": "New description"}
+		req, err := CreateAuthenticatedRequest("PATCH", "/api/v1/ballots/1", reqBody, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Public Changelog Hides Editor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		changedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(changelogSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"changed_by", "field", "old_value", "new_value", "changed_at"}).
+				AddRow(1, "title", "Old Title", "New Title", changedAt))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/changelog", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `[{"field":"title","old_value":"Old Title","new_value":"New Title","changed_at":"2026-01-01T12:00:00Z"}]`, recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Admin Changelog Includes Editor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		changedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+		testSetup.MockUserRole(9, "admin")
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(changelogSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"changed_by", "field", "old_value", "new_value", "changed_at"}).
+				AddRow(1, "title", "Old Title", "New Title", changedAt))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/admin/ballots/1/changelog", nil, 9, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `[{"changed_by":1,"field":"title","old_value":"Old Title","new_value":"New Title","changed_at":"2026-01-01T12:00:00Z"}]`, recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetBallotFeed(t *testing.T) {
+	const feedBallotSQL = "SELECT title, COALESCE(description, ''), created_at, updated_at FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	const feedAnnouncementsSQL = "SELECT id, message, created_at FROM ballot_announcements WHERE ballot_id = $1 ORDER BY created_at DESC"
+
+	createdAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	announcedAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	expectFeed := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(feedBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title", "description", "created_at", "updated_at"}).
+				AddRow("Park Budget", "Annual parks budget", createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(feedAnnouncementsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "message", "created_at"}).
+				AddRow(4, "Voting closes Friday", announcedAt))
+	}
+
+	t.Run("Atom Feed", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectFeed(testSetup)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/feed.atom", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/atom+xml", recorder.Header().Get("Content-Type"))
+
+		var feed struct {
+			XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+			Updated string   `xml:"updated"`
+			Links   []struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"link"`
+			Entries []struct {
+				Title string `xml:"title"`
+				ID    string `xml:"id"`
+			} `xml:"entry"`
+		}
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+
+		require.Len(t, feed.Entries, 2)
+		assert.Equal(t, "Voting closes Friday", feed.Entries[0].Title)
+		assert.Equal(t, "Ballot created: Park Budget", feed.Entries[1].Title)
+		assert.Equal(t, "2026-01-05T09:00:00Z", feed.Updated)
+		require.NotEmpty(t, feed.Links)
+		assert.Equal(t, "self", feed.Links[0].Rel)
+		assert.True(t, strings.HasSuffix(feed.Links[0].Href, "/api/v1/public/ballots/1/feed.atom"))
+
+		_, cached := ballotCache.Values["feed:atom:1"]
+		assert.True(t, cached)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("RSS Feed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectFeed(testSetup)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/feed.rss", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/rss+xml", recorder.Header().Get("Content-Type"))
+
+		var feed struct {
+			XMLName xml.Name `xml:"rss"`
+			Version string   `xml:"version,attr"`
+			Channel struct {
+				Title string `xml:"title"`
+				Items []struct {
+					Title   string `xml:"title"`
+					PubDate string `xml:"pubDate"`
+				} `xml:"item"`
+			} `xml:"channel"`
+		}
+		require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+
+		assert.Equal(t, "2.0", feed.Version)
+		assert.Equal(t, "Park Budget", feed.Channel.Title)
+		require.Len(t, feed.Channel.Items, 2)
+		assert.Equal(t, "Mon, 05 Jan 2026 09:00:00 +0000", feed.Channel.Items[0].PubDate)
+		assert.Contains(t, recorder.Body.String(), `<atom:link href="`)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Served From Cache", func(t *testing.T) {
+		ballotCache := NewMockCache()
+		ballotCache.Values["feed:rss:1"] = []byte("<rss></rss>")
+		testSetup, err := SetupTestEnvironmentWithCache(ballotCache)
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/feed.rss", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "<rss></rss>", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(feedBallotSQL).
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/feed.atom", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotSponsors(t *testing.T) {
+	const sponsorBallotExistsSQL = "SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)"
+	const sponsorInsertSQL = "INSERT INTO ballot_sponsors (ballot_id, organization_name, sponsor_url) VALUES ($1, $2, NULLIF($3, '')) ON CONFLICT (ballot_id, organization_name) DO NOTHING RETURNING sponsored_at"
+	const sponsorsSQL = "SELECT ballot_id, organization_name, COALESCE(sponsor_url, ''), sponsored_at FROM ballot_sponsors WHERE ballot_id = $1 ORDER BY sponsored_at ASC, organization_name ASC"
+	sponsoredAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Admin Adds Sponsor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(sponsorBallotExistsSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(sponsorInsertSQL).
+			WithArgs(5, "League of Voters", "https://voters.example.org").
+			WillReturnRows(sqlmock.NewRows([]string{"sponsored_at"}).AddRow(sponsoredAt))
+
+		reqBody := models.SponsorBallotRequest{OrganizationName: "League of Voters", SponsorURL: "https://voters.example.org"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/5/sponsor", reqBody, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 201, recorder.Code)
+
+		var sponsor models.BallotSponsor
+		err = parseJSONResponse(recorder, &sponsor)
+		require.NoError(t, err)
+		assert.Equal(t, models.BallotSponsor{
+			BallotID:         5,
+			OrganizationName: "League of Voters",
+			SponsorURL:       "https://voters.example.org",
+			SponsoredAt:      sponsoredAt,
+		}, sponsor)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Duplicate Sponsor", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(1, "admin")
+		testSetup.Mock.ExpectQuery(sponsorBallotExistsSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(sponsorInsertSQL).
+			WithArgs(5, "League of Voters", "").
+			WillReturnError(sql.ErrNoRows)
+
+		reqBody := models.SponsorBallotRequest{OrganizationName: "League of Voters"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/5/sponsor", reqBody, 1, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 409, "Organization already sponsors this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Non-Admin Forbidden", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		reqBody := models.SponsorBallotRequest{OrganizationName: "League of Voters"}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/5/sponsor", reqBody, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Admin access required")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Public Listing", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(sponsorBallotExistsSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(sponsorsSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_id", "organization_name", "sponsor_url", "sponsored_at"}).
+				AddRow(5, "League of Voters", "https://voters.example.org", sponsoredAt).
+				AddRow(5, "Neighborhood Council", "", sponsoredAt.Add(time.Hour)))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/5/sponsors", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var sponsors []models.BallotSponsor
+		err = parseJSONResponse(recorder, &sponsors)
+		require.NoError(t, err)
+		require.Len(t, sponsors, 2)
+		assert.Equal(t, "League of Voters", sponsors[0].OrganizationName)
+		assert.Equal(t, "Neighborhood Council", sponsors[1].OrganizationName)
+		assert.Equal(t, "", sponsors[1].SponsorURL)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Listing Unknown Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(sponsorBallotExistsSQL).
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/sponsors", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Detail Includes Sponsor Count", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(5, "Sponsored Ballot", "", "", "", "", 1, true, "plurality", false, nil, sponsoredAt, sponsoredAt, 2))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/5", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballot models.Ballot
+		err = parseJSONResponse(recorder, &ballot)
+		require.NoError(t, err)
+		require.NotNil(t, ballot.SponsorCount)
+		assert.Equal(t, 2, *ballot.SponsorCount)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Filter Sponsored Ballots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` AND EXISTS (SELECT 1 FROM ballot_sponsors s WHERE s.ballot_id = b.id) ORDER BY b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(5, "Sponsored Ballot", "", "", "", "", 1, true, sponsoredAt, sponsoredAt, nil, nil, "user1", 0, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots?has_sponsor=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		err = parseJSONResponse(recorder, &ballots)
+		require.NoError(t, err)
+		require.Len(t, ballots, 1)
+		assert.Equal(t, 5, ballots[0].ID)
+		assert.Nil(t, ballots[0].SponsorCount)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteBallot(t *testing.T) {
+	const deleteLookupSQL = "SELECT creator_id, deleted_at IS NOT NULL FROM ballots WHERE id = $1"
+	const softDeleteSQL = "UPDATE ballots SET is_active = false, deleted_at = NOW(), activate_at = NULL WHERE id = $1"
+	const archivedLookupSQL = "SELECT creator_id, deleted_at FROM ballots WHERE id = $1 AND deleted_at IS NOT NULL"
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2026, 2, 1, 9, 30, 0, 0, time.UTC)
+
+	serve := func(t *testing.T, testSetup *TestSetup, method, url string, userID int) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest(method, url, nil, userID, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Soft Delete Lifecycle", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Delete keeps the row and its votes
+		testSetup.Mock.ExpectQuery(deleteLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "deleted"}).AddRow(1, false))
+		testSetup.Mock.ExpectExec(softDeleteSQL).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		recorder := serve(t, testSetup, "DELETE", "/api/v1/ballots/1", 1)
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Ballot deleted successfully"})
+
+		// The deactivated ballot drops out of the active listing
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
+		require.NoError(t, err)
+		recorder = httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var ballots []models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballots))
+		assert.Empty(t, ballots)
+
+		// Nor can it be fetched directly, publicly or with the caller's vote
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err = CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+		recorder = httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		AssertErrorResponse(t, serve(t, testSetup, "GET", "/api/v1/ballots/1", 1), 404, "Ballot not found")
+
+		// The creator can still read it, results included
+		testSetup.Mock.ExpectQuery(archivedLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "deleted_at"}).AddRow(1, deletedAt))
+		testSetup.Mock.ExpectQuery(archivedBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(1, "Test Ballot", "Test Description", "", "", "", 1, false, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, 1, "Option 1", "", 4, createdAt).
+				AddRow(2, 1, "Option 2", "", 2, createdAt))
+
+		recorder = serve(t, testSetup, "GET", "/api/v1/ballots/1/archived", 1)
+		require.Equal(t, 200, recorder.Code)
+
+		var archived models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &archived))
+		assert.False(t, archived.IsActive)
+		require.NotNil(t, archived.DeletedAt)
+		assert.True(t, deletedAt.Equal(*archived.DeletedAt))
+		require.Len(t, archived.Items, 2)
+		assert.Equal(t, 4, archived.Items[0].VoteCount)
+
+		// Deleting again finds nothing to delete
+		testSetup.Mock.ExpectQuery(deleteLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "deleted"}).AddRow(1, true))
+
+		AssertErrorResponse(t, serve(t, testSetup, "DELETE", "/api/v1/ballots/1", 1), 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Only Creator Can Delete", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(deleteLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "deleted"}).AddRow(1, false))
+
+		AssertErrorResponse(t, serve(t, testSetup, "DELETE", "/api/v1/ballots/1", 2), 403, "Only the ballot creator can delete this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Only Creator Can View Archive", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(archivedLookupSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"creator_id", "deleted_at"}).AddRow(1, deletedAt))
+
+		AssertErrorResponse(t, serve(t, testSetup, "GET", "/api/v1/ballots/1/archived", 2), 403, "Only the ballot creator can view an archived ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Live Ballot Is Not Archived", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(archivedLookupSQL).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		AssertErrorResponse(t, serve(t, testSetup, "GET", "/api/v1/ballots/1/archived", 1), 404, "Archived ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("DELETE", "/api/v1/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authorization header required")
+	})
+}
+
+func TestDeletedBallotNotFound(t *testing.T) {
+	const liveBallotExistsSQL = "SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)"
+	const ballotTypeLookupSQL = "SELECT COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+	get := func(t *testing.T, testSetup *TestSetup, url string) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Endpoints that only need the ballot to exist
+	existenceChecked := []string{
+		"/api/v1/public/ballots/1/activity-heatmap",
+		"/api/v1/public/ballots/1/voters-map",
+		"/api/v1/public/ballots/1/participants-count",
+		"/api/v1/public/ballots/1/voters",
+		"/api/v1/public/ballots/1/leading-item-timeline",
+		"/api/v1/public/ballots/1/results/stream",
+		"/api/v1/public/ballots/1/changelog",
+		"/api/v1/public/ballots/1/qr-code",
+		"/api/v1/public/ballots/1/announcements",
+		"/api/v1/public/ballots/1/sponsors",
+	}
+	for _, url := range existenceChecked {
+		t.Run(url, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(liveBallotExistsSQL).
+				WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+			AssertErrorResponse(t, get(t, testSetup, url), 404, "Ballot not found")
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	// Endpoints that read ballot fields and find no live row
+	lookups := []struct {
+		url string
+		sql string
+	}{
+		{"/api/v1/public/ballots/1/results", ballotTypeSQL},
+		{"/api/v1/public/ballots/1/ranked-results", ballotTypeLookupSQL},
+		{"/api/v1/public/ballots/1/item-correlation", ballotTypeLookupSQL},
+		{"/api/v1/public/ballots/1/feed.rss", "SELECT title, COALESCE(description, ''), created_at, updated_at FROM ballots WHERE id = $1 AND deleted_at IS NULL"},
+		{"/api/v1/public/ballots/1/results/export-pdf", "SELECT title, COALESCE(description, ''), created_at, closes_at FROM ballots WHERE id = $1 AND deleted_at IS NULL"},
+		{"/api/v1/public/ballots/1/accessibility", `SELECT b.title, b.is_active, LEAST(b.closes_at, b.deactivate_at), COALESCE(b.ballot_type, 'plurality'), COALESCE(b.language, 'en'), u.username
+		FROM ballots b JOIN users u ON b.creator_id = u.id
+		WHERE b.id = $1 AND b.deleted_at IS NULL`},
+	}
+	for _, tc := range lookups {
+		t.Run(tc.url, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(tc.sql).
+				WithArgs(1).
+				WillReturnError(sql.ErrNoRows)
+
+			AssertErrorResponse(t, get(t, testSetup, tc.url), 404, "Ballot not found")
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Creator Cannot Lock Deleted Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotCreatorSQL).
+			WithArgs(1, 1).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/lock", nil, 1, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"testing"
+	"time"
+	"voting-api/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("Hit", func(t *testing.T) {
+		lru := cache.NewLRU(2, time.Minute)
+		require.NoError(t, lru.Set("a", []byte("1"), 0))
+
+		value, err := lru.Get("a")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("1"), value)
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		lru := cache.NewLRU(2, time.Minute)
+
+		_, err := lru.Get("missing")
+		assert.Equal(t, cache.ErrMiss, err)
+	})
+
+	t.Run("TTL Expiry", func(t *testing.T) {
+		lru := cache.NewLRU(2, 20*time.Millisecond)
+		require.NoError(t, lru.Set("default", []byte("1"), 0))
+		require.NoError(t, lru.Set("long", []byte("2"), time.Minute))
+
+		time.Sleep(40 * time.Millisecond)
+
+		_, err := lru.Get("default")
+		assert.Equal(t, cache.ErrMiss, err)
+		value, err := lru.Get("long")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("2"), value)
+		assert.Equal(t, 1, lru.Len())
+	})
+
+	t.Run("Evicts Least Recently Used", func(t *testing.T) {
+		lru := cache.NewLRU(2, time.Minute)
+		require.NoError(t, lru.Set("a", []byte("1"), 0))
+		require.NoError(t, lru.Set("b", []byte("2"), 0))
+
+		// Reading a makes b the least recently used
+		_, err := lru.Get("a")
+		require.NoError(t, err)
+		require.NoError(t, lru.Set("c", []byte("3"), 0))
+
+		_, err = lru.Get("b")
+		assert.Equal(t, cache.ErrMiss, err)
+		_, err = lru.Get("a")
+		assert.NoError(t, err)
+		_, err = lru.Get("c")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, lru.Len())
+	})
+
+	t.Run("Set Replaces Existing Entry", func(t *testing.T) {
+		lru := cache.NewLRU(2, time.Minute)
+		require.NoError(t, lru.Set("a", []byte("1"), 0))
+		require.NoError(t, lru.Set("a", []byte("2"), 0))
+
+		value, err := lru.Get("a")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("2"), value)
+		assert.Equal(t, 1, lru.Len())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		lru := cache.NewLRU(2, time.Minute)
+		require.NoError(t, lru.Set("a", []byte("1"), 0))
+		require.NoError(t, lru.Delete("a"))
+
+		_, err := lru.Get("a")
+		assert.Equal(t, cache.ErrMiss, err)
+	})
+}
@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+	"voting-api/database"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurePool(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv("DB_MAX_OPEN_CONNS", "")
+		t.Setenv("DB_MAX_IDLE_CONNS", "")
+		t.Setenv("DB_CONN_MAX_LIFETIME_SECONDS", "")
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		database.ConfigurePool(db)
+
+		assert.Equal(t, 25, db.Stats().MaxOpenConnections)
+	})
+
+	t.Run("From Environment", func(t *testing.T) {
+		t.Setenv("DB_MAX_OPEN_CONNS", "7")
+		t.Setenv("DB_MAX_IDLE_CONNS", "2")
+		t.Setenv("DB_CONN_MAX_LIFETIME_SECONDS", "60")
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		database.ConfigurePool(db)
+
+		assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+	})
+
+	t.Run("Invalid Value Falls Back To Default", func(t *testing.T) {
+		t.Setenv("DB_MAX_OPEN_CONNS", "lots")
+
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		database.ConfigurePool(db)
+
+		assert.Equal(t, 25, db.Stats().MaxOpenConnections)
+	})
+}
+
+// TestNewConnectionPoolSettings needs a reachable PostgreSQL instance, so it
+// only runs when DATABASE_URL is set.
+func TestNewConnectionPoolSettings(t *testing.T) {
+	if os.Getenv("DATABASE_URL") == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	t.Setenv("DB_MAX_OPEN_CONNS", "12")
+
+	db, err := database.NewConnection()
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, 12, db.Stats().MaxOpenConnections)
+}
+
+const (
+	createSchemaMigrationsSQL = "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"
+	lockMigrationsSQL         = "SELECT pg_advisory_xact_lock($1)"
+	migrationVersionSQL       = "SELECT version, dirty FROM schema_migrations LIMIT 1"
+)
+
+const migrationsDir = "../database/migrations"
+
+func newMigrationMock(t *testing.T) (*database.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return &database.DB{DB: db}, mock
+}
+
+// upMigrations returns the contents of every up migration on disk, oldest first.
+func upMigrations(t *testing.T) []string {
+	paths, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	require.NoError(t, err)
+	sort.Strings(paths)
+
+	var ups []string
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		require.NoError(t, err)
+		ups = append(ups, string(contents))
+	}
+	return ups
+}
+
+func TestRunMigrations(t *testing.T) {
+	ups := upMigrations(t)
+	latest := len(ups)
+
+	t.Run("Applies Pending Migrations To Fresh Database", func(t *testing.T) {
+		db, mock := newMigrationMock(t)
+
+		mock.ExpectExec(createSchemaMigrationsSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(lockMigrationsSQL).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(migrationVersionSQL).WillReturnError(sql.ErrNoRows)
+		for _, up := range ups {
+			mock.ExpectExec(up).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec("DELETE FROM schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)").
+			WithArgs(int64(latest)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, db.RunMigrations())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Applies Only Newer Migrations", func(t *testing.T) {
+		db, mock := newMigrationMock(t)
+
+		mock.ExpectExec(createSchemaMigrationsSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(lockMigrationsSQL).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(migrationVersionSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+		for _, up := range ups[1:] {
+			mock.ExpectExec(up).WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec("DELETE FROM schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)").
+			WithArgs(int64(latest)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, db.RunMigrations())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No-op When Already Up To Date", func(t *testing.T) {
+		db, mock := newMigrationMock(t)
+
+		mock.ExpectExec(createSchemaMigrationsSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(lockMigrationsSQL).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(migrationVersionSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(latest, false))
+		mock.ExpectCommit()
+
+		require.NoError(t, db.RunMigrations())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Refuses Dirty Database", func(t *testing.T) {
+		db, mock := newMigrationMock(t)
+
+		mock.ExpectExec(createSchemaMigrationsSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(lockMigrationsSQL).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(migrationVersionSQL).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, true))
+		mock.ExpectRollback()
+
+		err := db.RunMigrations()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dirty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failed Migration Rolls Back", func(t *testing.T) {
+		db, mock := newMigrationMock(t)
+
+		mock.ExpectExec(createSchemaMigrationsSQL).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectBegin()
+		mock.ExpectExec(lockMigrationsSQL).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(migrationVersionSQL).WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(ups[0]).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		err := db.RunMigrations()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "000001")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestMigrationFiles checks the on-disk layout golang-migrate expects: every
+// migration has both an up and a down file.
+func TestMigrationFiles(t *testing.T) {
+	entries, err := os.ReadDir(migrationsDir)
+	require.NoError(t, err)
+
+	pattern := regexp.MustCompile(`^(\d+)_\w+\.(up|down)\.sql$`)
+	directions := make(map[string][]string)
+	for _, entry := range entries {
+		match := pattern.FindStringSubmatch(entry.Name())
+		require.NotNil(t, match, "unexpected file %s", entry.Name())
+		directions[match[1]] = append(directions[match[1]], match[2])
+	}
+
+	require.NotEmpty(t, directions)
+	for version, dirs := range directions {
+		assert.ElementsMatch(t, []string{"down", "up"}, dirs, "migration %s", version)
+	}
+}
+
+func TestCompareVoteCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		stored   map[int]int
+		actual   map[int]int
+		expected []database.VoteCountDiscrepancy
+	}{
+		{
+			name:     "In Sync",
+			stored:   map[int]int{1: 3, 2: 0},
+			actual:   map[int]int{1: 3},
+			expected: []database.VoteCountDiscrepancy{},
+		},
+		{
+			name:   "Drifted Counts",
+			stored: map[int]int{1: 5, 2: 2, 3: 4},
+			actual: map[int]int{1: 3, 3: 4},
+			expected: []database.VoteCountDiscrepancy{
+				{BallotItemID: 1, Stored: 5, Actual: 3},
+				{BallotItemID: 2, Stored: 2, Actual: 0},
+			},
+		},
+		{
+			name:   "Votes Never Counted",
+			stored: map[int]int{7: 0},
+			actual: map[int]int{7: 2},
+			expected: []database.VoteCountDiscrepancy{
+				{BallotItemID: 7, Stored: 0, Actual: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, database.CompareVoteCounts(tt.stored, tt.actual))
+		})
+	}
+}
+
+func TestVoteCountDiscrepancies(t *testing.T) {
+	db, mock := newMigrationMock(t)
+
+	mock.ExpectQuery("SELECT id, vote_count FROM ballot_items").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "vote_count"}).AddRow(1, 4).AddRow(2, 1).AddRow(3, 0))
+	mock.ExpectQuery("SELECT ballot_item_id, vote_count FROM ballot_item_vote_counts").
+		WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id", "vote_count"}).AddRow(1, 4).AddRow(2, 3))
+
+	discrepancies, err := db.VoteCountDiscrepancies()
+	require.NoError(t, err)
+	assert.Equal(t, []database.VoteCountDiscrepancy{{BallotItemID: 2, Stored: 1, Actual: 3}}, discrepancies)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepairVoteCounts(t *testing.T) {
+	db, mock := newMigrationMock(t)
+
+	mock.ExpectExec(`UPDATE ballot_items bi
+	SET vote_count = COALESCE((SELECT vc.vote_count FROM ballot_item_vote_counts vc WHERE vc.ballot_item_id = bi.id), 0)
+	WHERE bi.vote_count <> COALESCE((SELECT vc.vote_count FROM ballot_item_vote_counts vc WHERE vc.ballot_item_id = bi.id), 0)`).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	updated, err := db.RepairVoteCounts()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
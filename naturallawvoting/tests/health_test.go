@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"voting-api/cache"
+	"voting-api/database"
+	"voting-api/routes"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheck(t *testing.T) {
+	// Pings are only checked against expectations when monitoring is on
+	newHealthSetup := func(t *testing.T) (*gin.Engine, sqlmock.Sqlmock, func()) {
+		gin.SetMode(gin.TestMode)
+		mockDB, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+		require.NoError(t, err)
+		return routes.SetupRoutes(&database.DB{DB: mockDB}, cache.NoOpCache{}), mock, func() { mockDB.Close() }
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		router, mock, closeDB := newHealthSetup(t)
+		defer closeDB()
+
+		mock.ExpectPing()
+
+		req, err := CreateTestRequest("GET", "/health", nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, "ok", response["status"])
+		assert.Contains(t, response, "db_open_connections")
+		assert.Contains(t, response, "db_in_use")
+		assert.NotContains(t, response, "db_error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Unreachable", func(t *testing.T) {
+		router, mock, closeDB := newHealthSetup(t)
+		defer closeDB()
+
+		mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+		req, err := CreateTestRequest("GET", "/health", nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 503, map[string]interface{}{
+			"status":   "degraded",
+			"db_error": "connection refused",
+		})
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -30,8 +30,11 @@ func TestFullVotingFlow(t *testing.T) {
 
 	t.Run("1. Register User", func(t *testing.T) {
 		// Mock user doesn't exist
-		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1 OR username = $2").
-			WithArgs(email, username).
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE email = $1").
+			WithArgs(email).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectQuery("SELECT id FROM users WHERE username = $1").
+			WithArgs(username).
 			WillReturnError(sql.ErrNoRows)
 
 		// Mock user insertion
@@ -72,10 +75,10 @@ func TestFullVotingFlow(t *testing.T) {
 
 		// Mock ballot insertion
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery("INSERT INTO ballots (title, description, creator_id) VALUES ($1, $2, $3) RETURNING id, title, description, creator_id, is_active, created_at, updated_at").
-			WithArgs("Integration Test Ballot", "Testing the full workflow", userID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", userID, true, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(createBallotSQL).
+			WithArgs("Integration Test Ballot", "Testing the full workflow", "", "", "", "plurality", true, nil, nil, userID, true).
+			WillReturnRows(sqlmock.NewRows(createBallotColumns).
+				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", "", "", "", userID, true, "plurality", true, nil, nil, createdAt, createdAt))
 
 		// Mock ballot items insertion
 		testSetup.Mock.ExpectQuery("INSERT INTO ballot_items (ballot_id, title, description) VALUES ($1, $2, $3) RETURNING id, ballot_id, title, description, vote_count").
@@ -121,14 +124,9 @@ func TestFullVotingFlow(t *testing.T) {
 	t.Run("3. Get All Ballots (Public)", func(t *testing.T) {
 		// Mock ballots query
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at,
-       u.username as creator_username
-FROM ballots b 
-JOIN users u ON b.creator_id = u.id 
-WHERE b.is_active = true 
-ORDER BY b.created_at DESC`).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at", "creator_username"}).
-				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", userID, true, createdAt, createdAt, username))
+		testSetup.Mock.ExpectQuery(listBallotsSQL + ` ORDER BY b.created_at DESC`).
+			WillReturnRows(sqlmock.NewRows(listBallotsColumns).
+				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", "", "", "", userID, true, createdAt, createdAt, nil, nil, username, 0, 2))
 
 		req, err := CreateTestRequest("GET", "/api/v1/public/ballots", nil)
 		require.NoError(t, err)
@@ -151,21 +149,17 @@ ORDER BY b.created_at DESC`).
 	t.Run("4. Get Specific Ballot with Items", func(t *testing.T) {
 		// Mock ballot query
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery(`SELECT b.id, b.title, b.description, b.creator_id, b.is_active, b.created_at, b.updated_at
-FROM ballots b WHERE b.id = $1`).
+		testSetup.Mock.ExpectQuery(getBallotSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", userID, true, createdAt, createdAt))
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", "", "", "", userID, true, "plurality", false, nil, createdAt, createdAt, 0))
 
 		// Mock ballot items query
-		testSetup.Mock.ExpectQuery(`SELECT id, ballot_id, title, description, vote_count
-FROM ballot_items 
-WHERE ballot_id = $1 
-ORDER BY id ASC`).
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
-				AddRow(1, ballotID, "Option A", "First choice", 0).
-				AddRow(2, ballotID, "Option B", "Second choice", 0))
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, ballotID, "Option A", "First choice", 0, createdAt).
+				AddRow(2, ballotID, "Option B", "Second choice", 0, createdAt))
 
 		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d", ballotID), nil)
 		require.NoError(t, err)
@@ -191,7 +185,7 @@ ORDER BY id ASC`).
 		ballotItemID := 1
 
 		// Mock ballot exists and is active
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
 
@@ -214,7 +208,7 @@ ORDER BY id ASC`).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		// Mock update vote count
-		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1 WHERE id = $1").
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
 			WithArgs(ballotItemID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -267,15 +261,16 @@ ORDER BY id ASC`).
 
 	t.Run("7. Get Ballot Results", func(t *testing.T) {
 		// Mock ballot exists
-		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1)").
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			WillReturnRows(ballotTypeRows("plurality", nil))
 
 		// Mock ballot results (Option A should have 1 vote now)
-		testSetup.Mock.ExpectQuery(`SELECT id, ballot_id, title, description, vote_count
-FROM ballot_items 
-WHERE ballot_id = $1 
-ORDER BY vote_count DESC, id ASC`).
+		testSetup.Mock.ExpectQuery(`SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0) AS vote_count
+FROM ballot_items bi
+LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+WHERE bi.ballot_id = $1
+ORDER BY vote_count DESC, bi.id ASC`).
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
 				AddRow(1, ballotID, "Option A", "First choice", 1).
@@ -311,13 +306,13 @@ ORDER BY vote_count DESC, id ASC`).
 	t.Run("8. Get User's Ballots", func(t *testing.T) {
 		// Mock user ballots query
 		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-		testSetup.Mock.ExpectQuery(`SELECT id, title, description, creator_id, is_active, created_at, updated_at
-FROM ballots 
-WHERE creator_id = $1 
+		testSetup.Mock.ExpectQuery(`SELECT id, title, description, category, COALESCE(superstate, ''), COALESCE(state, ''), creator_id, is_active, closes_at, created_at, updated_at
+FROM ballots
+WHERE creator_id = $1
 ORDER BY created_at DESC`).
 			WithArgs(userID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "creator_id", "is_active", "created_at", "updated_at"}).
-				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", userID, true, createdAt, createdAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "category", "superstate", "state", "creator_id", "is_active", "closes_at", "created_at", "updated_at"}).
+				AddRow(ballotID, "Integration Test Ballot", "Testing the full workflow", "", "", "", userID, true, nil, createdAt, createdAt))
 
 		req, err := CreateAuthenticatedRequest("GET", "/api/v1/my-ballots", nil, userID, email)
 		require.NoError(t, err)
@@ -0,0 +1,319 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voting-api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireJSON(t *testing.T) {
+	t.Run("POST Without Content-Type", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := http.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 415, "Unsupported Media Type: Content-Type must be application/json")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("PUT With Form Content-Type", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := http.NewRequest("PUT", "/api/v1/profile/info", strings.NewReader("full_name=Jane+Doe"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 415, "Unsupported Media Type: Content-Type must be application/json")
+	})
+
+	t.Run("POST Without Body", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Bodiless actions reach authentication instead of being rejected
+		req, err := http.NewRequest("POST", "/api/v1/ballots/1/lock", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authorization header required")
+	})
+
+	t.Run("GET Without Content-Type", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := http.NewRequest("GET", "/health", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+
+	t.Run("POST With JSON Content-Type Passes Through", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// An incomplete body reaches the handler and fails validation there
+		req, err := http.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"email":"test@example.com"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Run("Auth Endpoint Returns 429 Past The Limit", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// An incomplete body fails validation without touching the database
+		login := func() *httptest.ResponseRecorder {
+			req, err := CreateTestRequest("POST", "/api/v1/auth/login", map[string]string{"email": "test@example.com"})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+			return recorder
+		}
+
+		for i := 0; i < 10; i++ {
+			require.Equal(t, 400, login().Code)
+		}
+		AssertErrorResponse(t, login(), 429, "Too many requests")
+
+		// Other route groups are not limited
+		req, err := http.NewRequest("GET", "/health", nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		assert.Equal(t, 200, recorder.Code)
+	})
+
+	newRouter := func(maxRequests int, window time.Duration) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(middleware.RateLimitMiddleware(maxRequests, window))
+		router.GET("/", func(c *gin.Context) {
+			c.Status(200)
+		})
+		return router
+	}
+
+	get := func(router *gin.Engine, remoteAddr string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remoteAddr
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	t.Run("Clients Are Limited Separately", func(t *testing.T) {
+		router := newRouter(2, time.Minute)
+
+		assert.Equal(t, 200, get(router, "10.0.0.1:1234"))
+		assert.Equal(t, 200, get(router, "10.0.0.1:1234"))
+		assert.Equal(t, 429, get(router, "10.0.0.1:1234"))
+		assert.Equal(t, 200, get(router, "10.0.0.2:1234"))
+	})
+
+	t.Run("Window Expires", func(t *testing.T) {
+		router := newRouter(1, 50*time.Millisecond)
+
+		assert.Equal(t, 200, get(router, "10.0.0.1:1234"))
+		assert.Equal(t, 429, get(router, "10.0.0.1:1234"))
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, 200, get(router, "10.0.0.1:1234"))
+	})
+}
+
+func TestRequestLogger(t *testing.T) {
+	t.Run("Router Sets Request ID Header", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := http.NewRequest("GET", "/health", nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, recorder.Header().Get("X-Request-ID"))
+	})
+
+	t.Run("Logs One JSON Line Per Request", func(t *testing.T) {
+		var logs bytes.Buffer
+		var seenID interface{}
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(middleware.NewRequestLogger(&logs))
+		router.GET("/ballots/:id", func(c *gin.Context) {
+			seenID, _ = c.Get("request_id")
+			c.Status(404)
+		})
+
+		first := httptest.NewRecorder()
+		router.ServeHTTP(first, httptest.NewRequest("GET", "/ballots/7", nil))
+		second := httptest.NewRecorder()
+		router.ServeHTTP(second, httptest.NewRequest("GET", "/ballots/8", nil))
+
+		lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+		require.Len(t, lines, 2)
+
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, "/ballots/8", entry["path"])
+		assert.Equal(t, float64(404), entry["status"])
+		assert.Contains(t, entry, "latency")
+		assert.Equal(t, second.Header().Get("X-Request-ID"), entry["request_id"])
+		assert.Equal(t, seenID, entry["request_id"])
+
+		// Each request gets its own ID
+		assert.NotEqual(t, first.Header().Get("X-Request-ID"), second.Header().Get("X-Request-ID"))
+	})
+}
+
+func TestTransformJSONResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.TransformJSONResponse(func(body interface{}) interface{} {
+		return map[string]interface{}{"wrapped": body}
+	}))
+	router.GET("/json", func(c *gin.Context) {
+		c.JSON(201, gin.H{"id": 9007199254740993})
+	})
+	router.GET("/text", func(c *gin.Context) {
+		c.String(200, "plain")
+	})
+
+	t.Run("Rewrites JSON Body And Keeps Status", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/json", nil))
+
+		assert.Equal(t, 201, recorder.Code)
+		assert.Contains(t, recorder.Header().Get("Content-Type"), "application/json")
+		// Large IDs must not lose precision through the round trip
+		assert.JSONEq(t, `{"wrapped": {"id": 9007199254740993}}`, recorder.Body.String())
+	})
+
+	t.Run("Leaves Other Content Types Alone", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", "/text", nil))
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "plain", recorder.Body.String())
+	})
+}
+
+func TestCORS(t *testing.T) {
+	newRouter := func(allowed []string) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(middleware.CORS(allowed))
+		router.GET("/ping", func(c *gin.Context) {
+			c.JSON(200, gin.H{"ok": true})
+		})
+		return router
+	}
+
+	request := func(router *gin.Engine, method, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/ping", nil)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	allowed := middleware.ParseAllowedOrigins(" https://app.example.com/, ,https://admin.example.com")
+
+	t.Run("Parses Allowed Origins", func(t *testing.T) {
+		assert.Equal(t, []string{"https://app.example.com", "https://admin.example.com"}, allowed)
+	})
+
+	t.Run("Allowed Origin Is Echoed", func(t *testing.T) {
+		recorder := request(newRouter(allowed), "GET", "https://admin.example.com")
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "https://admin.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", recorder.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "Origin", recorder.Header().Get("Vary"))
+	})
+
+	t.Run("Disallowed Origin Gets No CORS Headers", func(t *testing.T) {
+		recorder := request(newRouter(allowed), "GET", "https://evil.example.com")
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Methods"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+	})
+
+	t.Run("Disallowed Preflight Gets No CORS Headers", func(t *testing.T) {
+		recorder := request(newRouter(allowed), "OPTIONS", "https://evil.example.com")
+
+		assert.Equal(t, 204, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Wildcard When No Origins Configured", func(t *testing.T) {
+		recorder := request(newRouter(nil), "OPTIONS", "https://anywhere.example.com")
+
+		assert.Equal(t, 204, recorder.Code)
+		assert.Equal(t, "*", recorder.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, recorder.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Contains(t, recorder.Header().Get("Access-Control-Allow-Methods"), "PATCH")
+	})
+
+	t.Run("Router Reads ALLOWED_ORIGINS", func(t *testing.T) {
+		t.Setenv("ALLOWED_ORIGINS", "https://app.example.com")
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := http.NewRequest("GET", "/health", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", "https://app.example.com")
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, "https://app.example.com", recorder.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
@@ -335,6 +335,44 @@ func TestUpdateUserProfile(t *testing.T) {
 
 		AssertErrorResponse(t, recorder, 400, "No fields to update")
 	})
+
+	t.Run("Update Every Field", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		email := "test@example.com"
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		birthday := time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC)
+
+		name, birthdayStr, gender, maidenName, phone := "Jane Doe", "1990-05-15", "Female", "Jones", "555-9876"
+		reqBody := models.UpdateUserProfileRequest{
+			FullName:          &name,
+			Birthday:          &birthdayStr,
+			Gender:            &gender,
+			MothersMaidenName: &maidenName,
+			PhoneNumber:       &phone,
+			AdditionalEmails:  []string{"jane@other.com"},
+		}
+
+		testSetup.Mock.ExpectQuery("SELECT email FROM users WHERE id = $1").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow(email))
+		testSetup.Mock.ExpectQuery("UPDATE user_profiles SET full_name = $1, birthday = $2, gender = $3, mothers_maiden_name = $4, phone_number = $5, additional_emails = $6 WHERE email = $7 RETURNING user_id, email, full_name, birthday, gender, mothers_maiden_name, phone_number, additional_emails, created_at, updated_at").
+			WithArgs(name, birthday, gender, maidenName, phone, pq.Array([]string{"jane@other.com"}), email).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "full_name", "birthday", "gender", "mothers_maiden_name", "phone_number", "additional_emails", "created_at", "updated_at"}).
+				AddRow(userID, email, name, birthday, gender, maidenName, phone, pq.Array([]string{"jane@other.com"}), createdAt, createdAt))
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/profile/info", reqBody, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
 }
 
 func TestDeleteUserProfile(t *testing.T) {
@@ -591,6 +629,39 @@ func TestUpdateUserAddress(t *testing.T) {
 
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
+
+	t.Run("Update Every Field", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		number, street, line2, city, state, zip := "42", "Elm Street", "Unit 3", "Burlington", "vermont", "05401"
+		reqBody := models.UpdateUserAddressRequest{
+			StreetNumber: &number,
+			StreetName:   &street,
+			AddressLine2: &line2,
+			City:         &city,
+			State:        &state,
+			ZipCode:      &zip,
+		}
+
+		testSetup.Mock.ExpectQuery("UPDATE user_addresses SET street_number = $1, street_name = $2, address_line_2 = $3, city = $4, state = $5, zip_code = $6 WHERE user_id = $7 RETURNING user_id, street_number, street_name, address_line_2, city, state, zip_code, created_at, updated_at").
+			WithArgs(number, street, line2, city, state, zip, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "street_number", "street_name", "address_line_2", "city", "state", "zip_code", "created_at", "updated_at"}).
+				AddRow(userID, number, street, line2, city, state, zip, createdAt, createdAt))
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/profile/address", reqBody, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
 }
 
 func TestDeleteUserAddress(t *testing.T) {
@@ -1374,6 +1445,42 @@ func TestUpdateEconomicInfo(t *testing.T) {
 
 		AssertErrorResponse(t, recorder, 401, "Authorization header required")
 	})
+
+	t.Run("Update Every Field", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		structure, capitalism, laws, altEcon, altComm, text := "support", "neutral", "oppose", "high", "low", "notes"
+		reqBody := models.UpdateEconomicInfoRequest{
+			ForCurrentPoliticalStructure: &structure,
+			ForCapitalism:                &capitalism,
+			ForLaws:                      &laws,
+			GoodsServices:                []string{"software"},
+			Affiliations:                 []string{"tech union"},
+			SupportOfAltEcon:             &altEcon,
+			SupportAltComm:               &altComm,
+			AdditionalText:               &text,
+		}
+
+		// Nine placeholders, the most any dynamic profile update produces
+		testSetup.Mock.ExpectQuery("UPDATE economic_info SET for_current_political_structure = $1, for_capitalism = $2, for_laws = $3, goods_services = $4, affiliations = $5, support_of_alt_econ = $6, support_alt_comm = $7, additional_text = $8 WHERE user_id = $9 RETURNING user_id, for_current_political_structure, for_capitalism, for_laws, goods_services, affiliations, support_of_alt_econ, support_alt_comm, additional_text, created_at, updated_at").
+			WithArgs(structure, capitalism, laws, pq.Array([]string{"software"}), pq.Array([]string{"tech union"}), altEcon, altComm, text, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "for_current_political_structure", "for_capitalism", "for_laws", "goods_services", "affiliations", "support_of_alt_econ", "support_alt_comm", "additional_text", "created_at", "updated_at"}).
+				AddRow(userID, structure, capitalism, laws, pq.Array([]string{"software"}), pq.Array([]string{"tech union"}), altEcon, altComm, text, createdAt, createdAt))
+
+		req, err := CreateAuthenticatedRequest("PUT", "/api/v1/profile/economic", reqBody, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
 }
 
 func TestDeleteEconomicInfo(t *testing.T) {
@@ -1444,3 +1551,432 @@ func TestDeleteEconomicInfo(t *testing.T) {
 		AssertErrorResponse(t, recorder, 401, "Authorization header required")
 	})
 }
+
+// ============================================================================
+// Full Profile Tests
+// ============================================================================
+
+// Queries issued by loadFullProfile, shared by the full profile and export tests.
+const (
+	userSQL          = "SELECT id, username, email, created_at, updated_at FROM users WHERE id = $1"
+	profileSQL       = "SELECT user_id, email, full_name, birthday, gender, mothers_maiden_name, phone_number, additional_emails, created_at, updated_at FROM user_profiles WHERE email = $1"
+	addressSQL       = "SELECT user_id, street_number, street_name, address_line_2, city, state, zip_code, created_at, updated_at FROM user_addresses WHERE user_id = $1"
+	politicalSQL     = "SELECT user_id, party_affiliation, created_at, updated_at FROM user_political_affiliations WHERE user_id = $1"
+	religiousSQL     = "SELECT user_id, religion, supporting_religion, religious_services_types, created_at, updated_at FROM user_religious_affiliations WHERE user_id = $1"
+	raceEthnicitySQL = "SELECT user_id, race, created_at, updated_at FROM user_race_ethnicity WHERE user_id = $1"
+	economicSQL      = "SELECT user_id, for_current_political_structure, for_capitalism, for_laws, goods_services, affiliations, support_of_alt_econ, support_alt_comm, additional_text, created_at, updated_at FROM economic_info WHERE user_id = $1"
+)
+
+func TestGetFullProfile(t *testing.T) {
+	userID := 1
+	email := "test@example.com"
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	birthday := time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	expectUser := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(userSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "created_at", "updated_at"}).
+				AddRow(userID, "testuser", email, createdAt, createdAt))
+	}
+	expectProfile := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(profileSQL).
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "full_name", "birthday", "gender", "mothers_maiden_name", "phone_number", "additional_emails", "created_at", "updated_at"}).
+				AddRow(userID, email, "John Doe", birthday, "Male", "Smith", "555-1234", pq.Array([]string{}), createdAt, createdAt))
+	}
+	expectAddress := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(addressSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "street_number", "street_name", "address_line_2", "city", "state", "zip_code", "created_at", "updated_at"}).
+				AddRow(userID, "123", "Main St", "", "Boston", "MA", "02101", createdAt, createdAt))
+	}
+	expectMissing := func(testSetup *TestSetup, query string, arg interface{}) {
+		testSetup.Mock.ExpectQuery(query).
+			WithArgs(arg).
+			WillReturnError(sql.ErrNoRows)
+	}
+
+	getFull := func(t *testing.T, testSetup *TestSetup) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/profile/full", nil, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("All Sections Present", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectUser(testSetup)
+		expectProfile(testSetup)
+		expectAddress(testSetup)
+		testSetup.Mock.ExpectQuery(politicalSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "party_affiliation", "created_at", "updated_at"}).
+				AddRow(userID, "Independent", createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(religiousSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "religion", "supporting_religion", "religious_services_types", "created_at", "updated_at"}).
+				AddRow(userID, "Christian", nil, pq.Array([]string{"Sunday Service"}), createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(raceEthnicitySQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "race", "created_at", "updated_at"}).
+				AddRow(userID, pq.Array([]string{"Asian"}), createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(economicSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "for_current_political_structure", "for_capitalism", "for_laws", "goods_services", "affiliations", "support_of_alt_econ", "support_alt_comm", "additional_text", "created_at", "updated_at"}).
+				AddRow(userID, "Yes", "No", "Yes", pq.Array([]string{}), pq.Array([]string{}), "", "", "", createdAt, createdAt))
+
+		recorder := getFull(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+
+		var full models.FullUserProfile
+		require.NoError(t, parseJSONResponse(recorder, &full))
+		assert.Equal(t, "testuser", full.User.Username)
+		require.NotNil(t, full.Profile)
+		assert.Equal(t, "John Doe", full.Profile.FullName)
+		require.NotNil(t, full.Address)
+		assert.Equal(t, "Boston", full.Address.City)
+		require.NotNil(t, full.PoliticalAffiliation)
+		assert.Equal(t, "Independent", full.PoliticalAffiliation.PartyAffiliation)
+		require.NotNil(t, full.ReligiousAffiliation)
+		assert.Equal(t, "Christian", full.ReligiousAffiliation.Religion)
+		require.NotNil(t, full.RaceEthnicity)
+		assert.Equal(t, []string{"Asian"}, []string(full.RaceEthnicity.Race))
+		require.NotNil(t, full.EconomicInfo)
+		assert.Equal(t, "No", full.EconomicInfo.ForCapitalism)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Some Sections Present", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectUser(testSetup)
+		expectProfile(testSetup)
+		expectAddress(testSetup)
+		expectMissing(testSetup, politicalSQL, userID)
+		expectMissing(testSetup, religiousSQL, userID)
+		expectMissing(testSetup, raceEthnicitySQL, userID)
+		expectMissing(testSetup, economicSQL, userID)
+
+		recorder := getFull(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+
+		var full models.FullUserProfile
+		require.NoError(t, parseJSONResponse(recorder, &full))
+		assert.NotNil(t, full.Profile)
+		assert.NotNil(t, full.Address)
+		assert.Nil(t, full.PoliticalAffiliation)
+		assert.Nil(t, full.ReligiousAffiliation)
+		assert.Nil(t, full.RaceEthnicity)
+		assert.Nil(t, full.EconomicInfo)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Sections Present", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectUser(testSetup)
+		expectMissing(testSetup, profileSQL, email)
+		expectMissing(testSetup, addressSQL, userID)
+		expectMissing(testSetup, politicalSQL, userID)
+		expectMissing(testSetup, religiousSQL, userID)
+		expectMissing(testSetup, raceEthnicitySQL, userID)
+		expectMissing(testSetup, economicSQL, userID)
+
+		recorder := getFull(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		for _, section := range []string{"profile", "address", "political_affiliation", "religious_affiliation", "race_ethnicity", "economic_info"} {
+			value, present := response[section]
+			assert.True(t, present, section)
+			assert.Nil(t, value, section)
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectUser(testSetup)
+		expectProfile(testSetup)
+		testSetup.Mock.ExpectQuery(addressSQL).
+			WithArgs(userID).
+			WillReturnError(sql.ErrConnDone)
+
+		AssertErrorResponse(t, getFull(t, testSetup), 500, "Database error")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetProfileCompleteness(t *testing.T) {
+	const completenessSQL = `SELECT EXISTS(SELECT 1 FROM user_profiles WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_addresses WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_political_affiliations WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_religious_affiliations WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM user_race_ethnicity WHERE user_id = $1),
+		       EXISTS(SELECT 1 FROM economic_info WHERE user_id = $1)`
+	columns := []string{"profile", "address", "political", "religious", "race_ethnicity", "economic"}
+	userID := 4
+
+	testCases := []struct {
+		name     string
+		filled   []bool
+		expected models.ProfileCompleteness
+	}{
+		{
+			name:   "Empty Profile",
+			filled: []bool{false, false, false, false, false, false},
+			expected: models.ProfileCompleteness{
+				ScorePct:  0,
+				Missing:   []string{"profile", "address", "political", "religious", "race-ethnicity", "economic"},
+				Completed: []string{},
+			},
+		},
+		{
+			name:   "Partially Complete",
+			filled: []bool{true, true, true, false, true, false},
+			expected: models.ProfileCompleteness{
+				ScorePct:  66,
+				Missing:   []string{"religious", "economic"},
+				Completed: []string{"profile", "address", "political", "race-ethnicity"},
+			},
+		},
+		{
+			name:   "Fully Complete",
+			filled: []bool{true, true, true, true, true, true},
+			expected: models.ProfileCompleteness{
+				ScorePct:  100,
+				Missing:   []string{},
+				Completed: []string{"profile", "address", "political", "religious", "race-ethnicity", "economic"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(completenessSQL).
+				WithArgs(userID).
+				WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(tc.filled[0], tc.filled[1], tc.filled[2], tc.filled[3], tc.filled[4], tc.filled[5]))
+
+			req, err := CreateAuthenticatedRequest("GET", "/api/v1/profile/completeness", nil, userID, "test@example.com")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+			require.Equal(t, 200, recorder.Code)
+
+			var completeness models.ProfileCompleteness
+			require.NoError(t, parseJSONResponse(recorder, &completeness))
+			assert.Equal(t, tc.expected, completeness)
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(completenessSQL).
+			WithArgs(userID).
+			WillReturnError(sql.ErrConnDone)
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/profile/completeness", nil, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 500, "Database error")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestExportUserData(t *testing.T) {
+	const exportVotesSQL = `SELECT ballot_id, ballot_item_id, NULL::integer AS score, NULL::integer AS rank, created_at FROM votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, NULL, NULL, created_at FROM multi_votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, score, NULL, created_at FROM score_votes WHERE user_id = $1
+		UNION ALL
+		SELECT ballot_id, ballot_item_id, NULL, rank, created_at FROM ranked_votes WHERE user_id = $1
+		ORDER BY created_at ASC`
+	exportVotesColumns := []string{"ballot_id", "ballot_item_id", "score", "rank", "created_at"}
+	userID := 1
+	email := "test@example.com"
+	createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expectAccount := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(userSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "created_at", "updated_at"}).
+				AddRow(userID, "testuser", email, createdAt, createdAt))
+		testSetup.Mock.ExpectQuery(profileSQL).
+			WithArgs(email).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectQuery(addressSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "street_number", "street_name", "address_line_2", "city", "state", "zip_code", "created_at", "updated_at"}).
+				AddRow(userID, "123", "Main St", "", "Boston", "MA", "02101", createdAt, createdAt))
+		for _, query := range []string{politicalSQL, religiousSQL, raceEthnicitySQL, economicSQL} {
+			testSetup.Mock.ExpectQuery(query).
+				WithArgs(userID).
+				WillReturnError(sql.ErrNoRows)
+		}
+	}
+
+	getExport := func(t *testing.T, testSetup *TestSetup) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/profile/export", nil, userID, email)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Downloads Every Section And Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectAccount(testSetup)
+		testSetup.Mock.ExpectQuery(exportVotesSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(exportVotesColumns).
+				AddRow(3, 11, nil, nil, createdAt).
+				AddRow(5, 20, nil, nil, createdAt.Add(time.Hour)).
+				AddRow(6, 30, 7, nil, createdAt.Add(2*time.Hour)).
+				AddRow(8, 40, nil, 2, createdAt.Add(3*time.Hour)))
+
+		recorder := getExport(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+		assert.Equal(t, `attachment; filename="user_data.json"`, recorder.Header().Get("Content-Disposition"))
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		for _, section := range []string{"user", "profile", "address", "political_affiliation", "religious_affiliation", "race_ethnicity", "economic_info", "votes", "exported_at"} {
+			assert.Contains(t, response, section)
+		}
+
+		var export models.UserDataExport
+		require.NoError(t, parseJSONResponse(recorder, &export))
+		assert.Equal(t, "testuser", export.User.Username)
+		assert.Nil(t, export.Profile)
+		require.NotNil(t, export.Address)
+		assert.Equal(t, "Boston", export.Address.City)
+		require.Len(t, export.Votes, 4)
+		assert.Equal(t, models.ExportedVote{BallotID: 3, BallotItemID: 11, VotedAt: createdAt}, export.Votes[0])
+		assert.Equal(t, 20, export.Votes[1].BallotItemID)
+		require.NotNil(t, export.Votes[2].Score)
+		assert.Equal(t, 7, *export.Votes[2].Score)
+		assert.Nil(t, export.Votes[2].Rank)
+		require.NotNil(t, export.Votes[3].Rank)
+		assert.Equal(t, 2, *export.Votes[3].Rank)
+		assert.Nil(t, export.Votes[3].Score)
+
+		// Plain votes leave score and rank out of the download
+		votes := response["votes"].([]interface{})
+		assert.NotContains(t, votes[0], "score")
+		assert.NotContains(t, votes[0], "rank")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Votes Exports Empty List", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectAccount(testSetup)
+		testSetup.Mock.ExpectQuery(exportVotesSQL).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(exportVotesColumns))
+
+		recorder := getExport(t, testSetup)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, []interface{}{}, response["votes"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(userSQL).
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := getExport(t, testSetup)
+
+		AssertErrorResponse(t, recorder, 404, "User not found")
+		assert.Empty(t, recorder.Header().Get("Content-Disposition"))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestProfileAdditionalEmailValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		emails []string
+		valid  bool
+	}{
+		{name: "None", emails: nil, valid: true},
+		{name: "Valid Addresses", emails: []string{"home@example.com", "work.name@example.co.uk"}, valid: true},
+		{name: "Empty String", emails: []string{""}, valid: false},
+		{name: "Malformed", emails: []string{"home@example.com", "not-an-email"}, valid: false},
+		{name: "Display Name", emails: []string{"Home <home@example.com>"}, valid: false},
+	}
+
+	for _, method := range []string{"POST", "PUT"} {
+		for _, tt := range tests {
+			t.Run(method+" "+tt.name, func(t *testing.T) {
+				testSetup, err := SetupTestEnvironment()
+				require.NoError(t, err)
+				defer testSetup.DB.Close()
+
+				// Valid requests get past binding to the user lookup, failed here to end early
+				if tt.valid {
+					testSetup.Mock.ExpectQuery("SELECT email FROM users WHERE id = $1").
+						WithArgs(1).
+						WillReturnError(sql.ErrConnDone)
+				}
+
+				body := map[string]interface{}{"full_name": "Jane Voter", "additional_emails": tt.emails}
+				req, err := CreateAuthenticatedRequest(method, "/api/v1/profile/info", body, 1, "jane@example.com")
+				require.NoError(t, err)
+
+				recorder := httptest.NewRecorder()
+				testSetup.Router.ServeHTTP(recorder, req)
+
+				if tt.valid {
+					AssertErrorResponse(t, recorder, 500, "Database error")
+				} else {
+					assert.Equal(t, 400, recorder.Code)
+					assert.Contains(t, recorder.Body.String(), "AdditionalEmails")
+				}
+				assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+			})
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"voting-api/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	streamBallotExistsSQL = "SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)"
+	resultUpdateSQL       = "SELECT COALESCE((SELECT vote_count FROM ballot_item_vote_counts WHERE ballot_item_id = $1), 0), (SELECT COUNT(*) FROM votes WHERE ballot_id = $2)"
+)
+
+func TestStreamBallotResults(t *testing.T) {
+	t.Run("Streams Update After Vote", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		server := httptest.NewServer(testSetup.Router)
+		defer server.Close()
+
+		ballotID, itemID, userID := 4, 9, 2
+
+		testSetup.Mock.ExpectQuery(streamBallotExistsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("SELECT ballot_id FROM ballot_items WHERE id = $1").
+			WithArgs(itemID).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_id"}).AddRow(ballotID))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(userID, ballotID).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectExec("INSERT INTO votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)").
+			WithArgs(userID, ballotID, itemID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(itemID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+		testSetup.Mock.ExpectQuery(resultUpdateSQL).
+			WithArgs(itemID, ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"vote_count", "total_votes"}).AddRow(3, 7))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		streamReq, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/public/ballots/4/results/stream", nil)
+		require.NoError(t, err)
+		stream, err := http.DefaultClient.Do(streamReq)
+		require.NoError(t, err)
+		defer stream.Body.Close()
+
+		require.Equal(t, 200, stream.StatusCode)
+		assert.Equal(t, "text/event-stream", stream.Header.Get("Content-Type"))
+
+		// The stream is subscribed once its headers arrive, so the vote can't be missed
+		voteReq, err := CreateAuthenticatedRequest("POST", server.URL+"/api/v1/ballots/4/vote", models.VoteRequest{BallotItemID: itemID}, userID, "voter@example.com")
+		require.NoError(t, err)
+		voteResp, err := http.DefaultClient.Do(voteReq)
+		require.NoError(t, err)
+		voteResp.Body.Close()
+		require.Equal(t, 200, voteResp.StatusCode)
+
+		reader := bufio.NewReader(stream.Body)
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(line, "data: "), line)
+
+		var update models.ResultUpdate
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &update))
+		assert.Equal(t, models.ResultUpdate{BallotID: ballotID, ItemID: itemID, NewVoteCount: 3, TotalVotes: 7}, update)
+
+		blank, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "\n", blank)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(streamBallotExistsSQL).
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/results/stream", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Ballot ID", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/abc/results/stream", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid ballot ID")
+	})
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"testing"
+	"voting-api/sanitize"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripHTML(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Plain Text", "Best Programming Language", "Best Programming Language"},
+		{"Script Tag", `Vote now<script>alert("xss")</script>`, "Vote now"},
+		{"Script Only", "<script>document.cookie</script>", ""},
+		{"Style Tag", "<style>body{display:none}</style>Budget", "Budget"},
+		{"Event Attribute", `<img src=x onerror="alert(1)">Parks`, "Parks"},
+		{"Link With Javascript URL", `<a href="javascript:alert(1)">Click</a> here`, "Click here"},
+		{"Benign Formatting", "<b>Bold</b> and <i>italic</i>", "Bold and italic"},
+		{"Entities Decoded", "Fish &amp; Chips &lt;3", "Fish & Chips <3"},
+		{"Unclosed Tag", "Title <div onclick=steal()", "Title"},
+		{"Control Characters", "Line one\x00\x07\nLine two\tend", "Line one\nLine two\tend"},
+		{"Surrounding Whitespace", "  <p> padded </p>  ", "padded"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sanitize.StripHTML(tc.input))
+		})
+	}
+}
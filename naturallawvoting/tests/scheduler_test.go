@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"database/sql"
+	"testing"
+	"voting-api/database"
+	"voting-api/scheduler"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const activateScheduledBallotsSQL = `UPDATE ballots SET is_active = true, activate_at = NULL
+WHERE is_active = false AND deleted_at IS NULL AND moderated_at IS NULL
+  AND activate_at IS NOT NULL AND activate_at <= NOW()`
+
+const deactivateScheduledBallotsSQL = `UPDATE ballots SET is_active = false, deactivate_at = NULL
+WHERE is_active = true AND deactivate_at IS NOT NULL AND deactivate_at <= NOW()`
+
+func TestActivateScheduledBallots(t *testing.T) {
+	t.Run("Activates Due Ballots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectExec(activateScheduledBallotsSQL).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		activated, err := scheduler.ActivateScheduledBallots(testSetup.DB)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), activated)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Only Matches Pending Drafts And Clears activate_at", func(t *testing.T) {
+		// Match on the clauses themselves, since the statement's idempotency comes from
+		// only picking up inactive ballots and clearing the schedule it acted on
+		mockDB, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+		defer mockDB.Close()
+
+		mock.ExpectExec(`SET is_active = true, activate_at = NULL\s+WHERE is_active = false .*AND activate_at <= NOW\(\)`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		activated, err := scheduler.ActivateScheduledBallots(&database.DB{DB: mockDB})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), activated)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeactivateScheduledBallots(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	testSetup.Mock.ExpectExec(deactivateScheduledBallotsSQL).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deactivated, err := scheduler.DeactivateScheduledBallots(testSetup.DB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deactivated)
+
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
+
+func TestCloseExpiredBallots(t *testing.T) {
+	const closeExpiredBallotsSQL = `UPDATE ballots SET is_active = false
+WHERE closes_at IS NOT NULL AND closes_at <= NOW() AND is_active = true`
+
+	t.Run("Closes Expired Ballots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectExec(closeExpiredBallotsSQL).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		closed, err := scheduler.CloseExpiredBallots(testSetup.DB)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), closed)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectExec(closeExpiredBallotsSQL).
+			WillReturnError(sql.ErrConnDone)
+
+		_, err = scheduler.CloseExpiredBallots(testSetup.DB)
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestSnapshotBallotResults(t *testing.T) {
+	const snapshotBallotResultsSQL = `INSERT INTO ballot_result_snapshots (ballot_id, results)
+		SELECT b.id, jsonb_agg(jsonb_build_object('item_id', bi.id, 'title', bi.title, 'vote_count', COALESCE(vc.vote_count, 0)) ORDER BY COALESCE(vc.vote_count, 0) DESC, bi.id)
+		FROM ballots b
+		JOIN ballot_items bi ON bi.ballot_id = b.id
+		LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+		WHERE b.is_active = true AND NOT EXISTS (
+			SELECT 1 FROM ballot_result_snapshots s WHERE s.ballot_id = b.id AND s.snapshotted_at > NOW() - INTERVAL '1 hour'
+		)
+		GROUP BY b.id`
+
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	testSetup.Mock.ExpectExec(snapshotBallotResultsSQL).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	snapshotted, err := scheduler.SnapshotBallotResults(testSetup.DB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), snapshotted)
+
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"voting-api/cache"
 	"voting-api/database"
 	"voting-api/routes"
 	"voting-api/utils"
@@ -26,6 +28,11 @@ type TestSetup struct {
 
 // SetupTestEnvironment creates a test environment with mocked database
 func SetupTestEnvironment() (*TestSetup, error) {
+	return SetupTestEnvironmentWithCache(cache.NoOpCache{})
+}
+
+// SetupTestEnvironmentWithCache creates a test environment with mocked database and the given cache
+func SetupTestEnvironmentWithCache(ballotCache cache.Cacher) (*TestSetup, error) {
 	gin.SetMode(gin.TestMode)
 	
 	// Create mock database with exact query matching
@@ -35,7 +42,7 @@ func SetupTestEnvironment() (*TestSetup, error) {
 	}
 
 	db := &database.DB{DB: mockDB}
-	router := routes.SetupRoutes(db)
+	router := routes.SetupRoutes(db, ballotCache)
 
 	return &TestSetup{
 		Router: router,
@@ -118,6 +125,9 @@ func AssertErrorResponse(t *testing.T, recorder *httptest.ResponseRecorder, expe
 	errorMsg, exists := response["error"]
 	assert.True(t, exists, "Expected error field in response")
 	assert.Equal(t, expectedError, errorMsg)
+
+	code, _ := response["code"].(string)
+	assert.NotEmpty(t, code, "Expected code field in response")
 }
 
 // MockUserExists mocks a database query to check if user exists
@@ -153,4 +163,55 @@ func (ts *TestSetup) MockUserLogin(email, hashedPassword string, userID int, use
 			WithArgs(email).
 			WillReturnError(sql.ErrNoRows)
 	}
-}
\ No newline at end of file
+}
+// MockUserRole mocks the role lookup performed by the admin middleware
+func (ts *TestSetup) MockUserRole(userID int, role string) {
+	ts.Mock.ExpectQuery("SELECT role FROM users WHERE id = $1").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(role))
+}
+
+// MockCache is an in-memory cache.Cacher that records the TTLs it was given
+type MockCache struct {
+	Values map[string][]byte
+	TTLs   map[string]time.Duration
+}
+
+func NewMockCache() *MockCache {
+	return &MockCache{Values: make(map[string][]byte), TTLs: make(map[string]time.Duration)}
+}
+
+func (m *MockCache) Get(key string) ([]byte, error) {
+	value, ok := m.Values[key]
+	if !ok {
+		return nil, cache.ErrMiss
+	}
+	return value, nil
+}
+
+func (m *MockCache) Set(key string, value []byte, ttl time.Duration) error {
+	m.Values[key] = value
+	m.TTLs[key] = ttl
+	return nil
+}
+
+func (m *MockCache) Delete(key string) error {
+	delete(m.Values, key)
+	delete(m.TTLs, key)
+	return nil
+}
+
+// StreamRecorder is an httptest.ResponseRecorder that also satisfies
+// http.CloseNotifier, which gin's c.Stream requires
+type StreamRecorder struct {
+	*httptest.ResponseRecorder
+	closed chan bool
+}
+
+func NewStreamRecorder() *StreamRecorder {
+	return &StreamRecorder{ResponseRecorder: httptest.NewRecorder(), closed: make(chan bool, 1)}
+}
+
+func (r *StreamRecorder) CloseNotify() <-chan bool {
+	return r.closed
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"voting-api/models"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +26,7 @@ func TestVote(t *testing.T) {
 		ballotItemID := 1
 
 		// Mock ballot exists and is active
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
 
@@ -47,7 +49,7 @@ func TestVote(t *testing.T) {
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		// Mock update vote count
-		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1 WHERE id = $1").
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
 			WithArgs(ballotItemID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -87,7 +89,7 @@ func TestVote(t *testing.T) {
 		newBallotItemID := 2
 
 		// Mock ballot exists and is active
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
 
@@ -105,17 +107,17 @@ func TestVote(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_item_id"}).AddRow(1, oldBallotItemID))
 
 		// Mock decrease vote count for old choice
-		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1 WHERE id = $1").
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1").
 			WithArgs(oldBallotItemID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		// Mock update vote record
-		testSetup.Mock.ExpectExec("UPDATE votes SET ballot_item_id = $1 WHERE id = $2").
+		testSetup.Mock.ExpectExec("UPDATE votes SET previous_ballot_item_id = ballot_item_id, ballot_item_id = $1 WHERE id = $2").
 			WithArgs(newBallotItemID, 1).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
 		// Mock increase vote count for new choice
-		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1 WHERE id = $1").
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
 			WithArgs(newBallotItemID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -148,7 +150,7 @@ func TestVote(t *testing.T) {
 		ballotItemID := 1
 
 		// Mock ballot not found
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnError(sql.ErrNoRows)
 
@@ -177,7 +179,7 @@ func TestVote(t *testing.T) {
 		ballotItemID := 1
 
 		// Mock ballot exists but is inactive
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(false))
 
@@ -206,7 +208,7 @@ func TestVote(t *testing.T) {
 		ballotItemID := 999
 
 		// Mock ballot exists and is active
-		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1").
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
 
@@ -334,15 +336,16 @@ func TestGetBallotResults(t *testing.T) {
 		ballotID := 1
 
 		// Mock ballot exists
-		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1)").
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			WillReturnRows(ballotTypeRows("plurality", nil))
 
 		// Mock ballot results
-		testSetup.Mock.ExpectQuery(`SELECT id, ballot_id, title, description, vote_count
-FROM ballot_items 
-WHERE ballot_id = $1 
-ORDER BY vote_count DESC, id ASC`).
+		testSetup.Mock.ExpectQuery(`SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0) AS vote_count
+FROM ballot_items bi
+LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+WHERE bi.ballot_id = $1
+ORDER BY vote_count DESC, bi.id ASC`).
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
 				AddRow(1, ballotID, "Option 1", "First option", 10).
@@ -372,7 +375,75 @@ ORDER BY vote_count DESC, id ASC`).
 		firstResult := results[0].(map[string]interface{})
 		assert.Equal(t, float64(10), firstResult["vote_count"])
 		assert.Equal(t, "Option 1", firstResult["title"])
+		assert.Equal(t, "cached", recorder.Header().Get("X-Result-Mode"))
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Get Ballot Results Live Mode", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+		ballotID := 1
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(ballotID).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		// Denormalized counts have drifted; live mode must ignore them
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, ballotID, "Option 1", "First option", 10).
+				AddRow(2, ballotID, "Option 2", "Second option", 5))
+
+		testSetup.Mock.ExpectQuery("SELECT ballot_item_id, COUNT(*) FROM votes WHERE ballot_id = $1 GROUP BY ballot_item_id").
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id", "count"}).
+				AddRow(1, 4).
+				AddRow(2, 6))
+
+		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results?mode=live", ballotID), nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "live", recorder.Header().Get("X-Result-Mode"))
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(10), response["total_votes"])
+		assert.Contains(t, response, "computation_time_ms")
+
+		results := response["results"].([]interface{})
+		require.Len(t, results, 2)
+		firstResult := results[0].(map[string]interface{})
+		assert.Equal(t, "Option 2", firstResult["title"])
+		assert.Equal(t, float64(6), firstResult["vote_count"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Get Ballot Results Invalid Mode", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?mode=fast", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
 
+		AssertErrorResponse(t, recorder, 400, "mode must be live or cached")
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
 
@@ -384,9 +455,9 @@ ORDER BY vote_count DESC, id ASC`).
 		ballotID := 999
 
 		// Mock ballot doesn't exist
-		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1)").
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			WillReturnError(sql.ErrNoRows)
 
 		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results", ballotID), nil)
 		require.NoError(t, err)
@@ -406,15 +477,16 @@ ORDER BY vote_count DESC, id ASC`).
 		ballotID := 1
 
 		// Mock ballot exists
-		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1)").
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
 			WithArgs(ballotID).
-			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			WillReturnRows(ballotTypeRows("plurality", nil))
 
 		// Mock empty results
-		testSetup.Mock.ExpectQuery(`SELECT id, ballot_id, title, description, vote_count
-FROM ballot_items 
-WHERE ballot_id = $1 
-ORDER BY vote_count DESC, id ASC`).
+		testSetup.Mock.ExpectQuery(`SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0) AS vote_count
+FROM ballot_items bi
+LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+WHERE bi.ballot_id = $1
+ORDER BY vote_count DESC, bi.id ASC`).
 			WithArgs(ballotID).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}))
 
@@ -440,3 +512,2959 @@ ORDER BY vote_count DESC, id ASC`).
 		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
 	})
 }
+
+const ballotTypeSQL = "SELECT COALESCE(ballot_type, 'plurality'), minimum_quorum FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+// ballotTypeRows answers ballotTypeSQL; minimumQuorum is nil for ballots without one.
+func ballotTypeRows(ballotType string, minimumQuorum interface{}) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"ballot_type", "minimum_quorum"}).AddRow(ballotType, minimumQuorum)
+}
+
+const ballotResultsSQL = `SELECT bi.id, bi.ballot_id, bi.title, bi.description, COALESCE(vc.vote_count, 0) AS vote_count
+FROM ballot_items bi
+LEFT JOIN ballot_item_vote_counts vc ON vc.ballot_item_id = bi.id
+WHERE bi.ballot_id = $1
+ORDER BY vote_count DESC, bi.id ASC`
+
+func TestLongPollBallotResults(t *testing.T) {
+	resultRows := func(ballotID, firstCount, secondCount int) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+			AddRow(1, ballotID, "Option 1", "First option", firstCount).
+			AddRow(2, ballotID, "Option 2", "Second option", secondCount)
+	}
+
+	t.Run("Returns Immediately When Votes Already Exceed Count", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		ballotID := 1
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(ballotID).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 4, 2))
+
+		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results?long_poll=true&last_vote_count=5&timeout_seconds=30", ballotID), nil)
+		require.NoError(t, err)
+
+		start := time.Now()
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Less(t, time.Since(start), time.Second)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(6), response["total_votes"])
+		assert.Equal(t, false, response["timed_out"])
+		assert.NotEmpty(t, response["polling_since"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Returns When A Vote Is Cast", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		email := "test@example.com"
+		ballotID := 1
+
+		// Long poll: initial read finds no new votes
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(ballotID).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 0, 0))
+
+		// Vote cast by another client
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("SELECT ballot_id FROM ballot_items WHERE id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_id"}).AddRow(ballotID))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(userID, ballotID).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectExec("INSERT INTO votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)").
+			WithArgs(userID, ballotID, 1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+
+		// Long poll: re-read after notification
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 1, 0))
+
+		pollReq, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results?long_poll=true&last_vote_count=0&timeout_seconds=10", ballotID), nil)
+		require.NoError(t, err)
+
+		pollRecorder := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			testSetup.Router.ServeHTTP(pollRecorder, pollReq)
+			close(done)
+		}()
+
+		// Give the poller time to subscribe before voting
+		time.Sleep(100 * time.Millisecond)
+
+		voteReq, err := CreateAuthenticatedRequest("POST", fmt.Sprintf("/api/v1/ballots/%d/vote", ballotID), models.VoteRequest{BallotItemID: 1}, userID, email)
+		require.NoError(t, err)
+		voteRecorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(voteRecorder, voteReq)
+		assert.Equal(t, 200, voteRecorder.Code)
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("long poll did not return after vote")
+		}
+
+		assert.Equal(t, 200, pollRecorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(pollRecorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(1), response["total_votes"])
+		assert.Equal(t, false, response["timed_out"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Keeps Waiting When A Changed Vote Leaves The Total", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID := 1
+		email := "test@example.com"
+		ballotID := 1
+
+		// Long poll: initial read finds the one vote the client already has
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(ballotID).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 0, 1))
+
+		// The voter moves their vote from option 2 to option 1
+		testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+		testSetup.Mock.ExpectQuery("SELECT ballot_id FROM ballot_items WHERE id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_id"}).AddRow(ballotID))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(userID, ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_item_id"}).AddRow(7, 2))
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE votes SET previous_ballot_item_id = ballot_item_id, ballot_item_id = $1 WHERE id = $2").
+			WithArgs(1, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+
+		// Long poll: re-read after notification, total is still 1
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 1, 0))
+
+		pollReq, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results?long_poll=true&last_vote_count=1&timeout_seconds=1", ballotID), nil)
+		require.NoError(t, err)
+
+		pollRecorder := httptest.NewRecorder()
+		done := make(chan struct{})
+		go func() {
+			testSetup.Router.ServeHTTP(pollRecorder, pollReq)
+			close(done)
+		}()
+
+		// Give the poller time to subscribe before voting
+		time.Sleep(100 * time.Millisecond)
+
+		voteReq, err := CreateAuthenticatedRequest("POST", fmt.Sprintf("/api/v1/ballots/%d/vote", ballotID), models.VoteRequest{BallotItemID: 1}, userID, email)
+		require.NoError(t, err)
+		voteRecorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(voteRecorder, voteReq)
+		assert.Equal(t, 200, voteRecorder.Code)
+
+		select {
+		case <-done:
+			t.Fatal("long poll returned although the total did not change")
+		case <-time.After(500 * time.Millisecond):
+		}
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("long poll did not time out")
+		}
+
+		assert.Equal(t, 200, pollRecorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(pollRecorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(1), response["total_votes"])
+		assert.Equal(t, true, response["timed_out"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Times Out Without New Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		ballotID := 1
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(ballotID).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(resultRows(ballotID, 2, 1))
+
+		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results?long_poll=true&last_vote_count=3&timeout_seconds=1", ballotID), nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(3), response["total_votes"])
+		assert.Equal(t, true, response["timed_out"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsMarginOfVictory(t *testing.T) {
+	getResults := func(t *testing.T, rows *sqlmock.Rows) map[string]interface{} {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		return response
+	}
+
+	resultColumns := []string{"id", "ballot_id", "title", "description", "vote_count"}
+
+	t.Run("Clear Winner", func(t *testing.T) {
+		response := getResults(t, sqlmock.NewRows(resultColumns).
+			AddRow(2, 1, "Option 2", "", 6).
+			AddRow(1, 1, "Option 1", "", 3).
+			AddRow(3, 1, "Option 3", "", 1))
+
+		margin, ok := response["margin_of_victory"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, float64(2), margin["leader_id"])
+		assert.Equal(t, float64(1), margin["runner_up_id"])
+		assert.Equal(t, float64(3), margin["vote_difference"])
+		assert.Equal(t, 30.0, margin["percentage_difference"])
+		assert.NotContains(t, margin, "tied")
+	})
+
+	t.Run("Tie For First", func(t *testing.T) {
+		response := getResults(t, sqlmock.NewRows(resultColumns).
+			AddRow(1, 1, "Option 1", "", 4).
+			AddRow(2, 1, "Option 2", "", 4).
+			AddRow(3, 1, "Option 3", "", 2))
+
+		margin, ok := response["margin_of_victory"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Nil(t, margin["leader_id"])
+		assert.Nil(t, margin["runner_up_id"])
+		assert.Equal(t, float64(0), margin["vote_difference"])
+		assert.Equal(t, 0.0, margin["percentage_difference"])
+		assert.Equal(t, true, margin["tied"])
+	})
+
+	t.Run("Single Item Ballot", func(t *testing.T) {
+		response := getResults(t, sqlmock.NewRows(resultColumns).
+			AddRow(1, 1, "Option 1", "", 5))
+
+		assert.Contains(t, response, "margin_of_victory")
+		assert.Nil(t, response["margin_of_victory"])
+	})
+
+	t.Run("Zero Votes", func(t *testing.T) {
+		response := getResults(t, sqlmock.NewRows(resultColumns).
+			AddRow(1, 1, "Option 1", "", 0).
+			AddRow(2, 1, "Option 2", "", 0))
+
+		assert.Contains(t, response, "margin_of_victory")
+		assert.Nil(t, response["margin_of_victory"])
+	})
+}
+
+func TestBordaBallotResults(t *testing.T) {
+	t.Run("Ranked Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("ranked", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Alpha", "", 0).
+				AddRow(2, 1, "Beta", "", 0).
+				AddRow(3, 1, "Gamma", "", 0))
+		testSetup.Mock.ExpectQuery("SELECT user_id, ballot_item_id FROM ranked_votes WHERE ballot_id = $1 ORDER BY user_id, rank").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ballot_item_id"}).
+				AddRow(10, 3).AddRow(10, 2).AddRow(10, 1).
+				AddRow(11, 3).AddRow(11, 1).AddRow(11, 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?scoring=borda", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Scoring     string               `json:"scoring"`
+			Results     []models.BordaResult `json:"results"`
+			TotalVoters int                  `json:"total_voters"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "borda", response.Scoring)
+		assert.Equal(t, 2, response.TotalVoters)
+		assert.Equal(t, []models.BordaResult{
+			{ItemID: 3, Title: "Gamma", BordaScore: 4, Rank: 1},
+			{ItemID: 1, Title: "Alpha", BordaScore: 1, Rank: 2},
+			{ItemID: 2, Title: "Beta", BordaScore: 1, Rank: 2},
+		}, response.Results)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?scoring=borda", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Borda scoring is only available for ranked ballots")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestIRVBallotResults(t *testing.T) {
+	t.Run("Ranked Ballot Elimination Sequence", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("ranked", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Alpha", "", 0).
+				AddRow(2, 1, "Beta", "", 0).
+				AddRow(3, 1, "Gamma", "", 0).
+				AddRow(4, 1, "Delta", "", 0))
+		// First preferences: Alpha 3, Beta 2, Gamma 2, Delta 1
+		testSetup.Mock.ExpectQuery("SELECT user_id, ballot_item_id FROM ranked_votes WHERE ballot_id = $1 ORDER BY user_id, rank").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ballot_item_id"}).
+				AddRow(10, 1).AddRow(11, 1).AddRow(12, 1).
+				AddRow(13, 2).AddRow(13, 3).
+				AddRow(14, 2).AddRow(14, 3).
+				AddRow(15, 3).AddRow(15, 2).
+				AddRow(16, 3).
+				AddRow(17, 4).AddRow(17, 3))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?simulate_irv=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			WinnerID          *int                         `json:"winner_id"`
+			EliminationRounds []models.IRVEliminationRound `json:"elimination_rounds"`
+			TotalVoters       int                          `json:"total_voters"`
+			SimulationNote    string                       `json:"simulation_note"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		// Delta's vote moves to Gamma, then Beta is eliminated and both its ballots
+		// move to Gamma, giving Gamma a majority.
+		require.NotNil(t, response.WinnerID)
+		assert.Equal(t, 3, *response.WinnerID)
+		assert.Equal(t, []models.IRVEliminationRound{
+			{Round: 1, EliminatedItemID: 4, EliminatedItemTitle: "Delta", RedistributedVotes: 1},
+			{Round: 2, EliminatedItemID: 2, EliminatedItemTitle: "Beta", RedistributedVotes: 2},
+		}, response.EliminationRounds)
+		assert.Equal(t, 8, response.TotalVoters)
+		assert.Equal(t, "Results may change as more votes are cast", response.SimulationNote)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?simulate_irv=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "IRV simulation is only available for ranked ballots")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestRetractVote(t *testing.T) {
+	const retractionSettingsSQL = "SELECT is_active, COALESCE(allow_vote_retraction, true) FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+	t.Run("Successful Retraction", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		userID, ballotID, ballotItemID := 1, 1, 2
+
+		testSetup.Mock.ExpectQuery(retractionSettingsSQL).
+			WithArgs(ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "allow_vote_retraction"}).AddRow(true, true))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(userID, ballotID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_item_id"}).AddRow(10, ballotItemID))
+		// The vote count for the previously chosen item must go down by one
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(ballotItemID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("DELETE FROM votes WHERE id = $1").
+			WithArgs(10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+
+		req, err := CreateAuthenticatedRequest("DELETE", fmt.Sprintf("/api/v1/ballots/%d/my-vote", ballotID), nil, userID, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Vote retracted successfully"})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Retraction Not Allowed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(retractionSettingsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "allow_vote_retraction"}).AddRow(true, false))
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/ballots/1/my-vote", nil, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "This ballot does not allow vote retraction")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Vote To Retract", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(retractionSettingsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "allow_vote_retraction"}).AddRow(true, true))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(1, 1).
+			WillReturnError(sql.ErrNoRows)
+		testSetup.Mock.ExpectRollback()
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/ballots/1/my-vote", nil, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "No vote found for this ballot")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Retraction Via Vote Route", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(retractionSettingsSQL).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "allow_vote_retraction"}).AddRow(true, true))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(4, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_item_id"}).AddRow(21, 7))
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id = $1").
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("DELETE FROM votes WHERE id = $1").
+			WithArgs(21).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectCommit()
+
+		req, err := CreateAuthenticatedRequest("DELETE", "/api/v1/ballots/3/vote", nil, 4, "voter@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertJSONResponse(t, recorder, 200, map[string]interface{}{"message": "Vote retracted successfully"})
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestApprovalBallotResults(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	// Voter A approves options 1 and 2, voter B approves option 1 only
+	testSetup.Mock.ExpectQuery(ballotTypeSQL).
+		WithArgs(1).
+		WillReturnRows(ballotTypeRows("approval", nil))
+	testSetup.Mock.ExpectQuery(ballotResultsSQL).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+			AddRow(1, 1, "Option 1", "", 2).
+			AddRow(2, 1, "Option 2", "", 1).
+			AddRow(3, 1, "Option 3", "", 0))
+	testSetup.Mock.ExpectQuery("SELECT COUNT(DISTINCT user_id) FROM multi_votes WHERE ballot_id = $1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+
+	var response struct {
+		UniqueVoters int `json:"unique_voters"`
+		Results      []struct {
+			ID               int     `json:"id"`
+			VoteCount        int     `json:"vote_count"`
+			ApprovalRate     float64 `json:"approval_rate"`
+			DisapprovalCount int     `json:"disapproval_count"`
+			NetApproval      int     `json:"net_approval"`
+		} `json:"results"`
+	}
+	err = parseJSONResponse(recorder, &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, response.UniqueVoters)
+	require.Len(t, response.Results, 3)
+
+	assert.Equal(t, 1, response.Results[0].ID)
+	assert.Equal(t, 100.0, response.Results[0].ApprovalRate)
+	assert.Equal(t, 0, response.Results[0].DisapprovalCount)
+	assert.Equal(t, 2, response.Results[0].NetApproval)
+
+	assert.Equal(t, 2, response.Results[1].ID)
+	assert.Equal(t, 50.0, response.Results[1].ApprovalRate)
+	assert.Equal(t, 1, response.Results[1].DisapprovalCount)
+	assert.Equal(t, 0, response.Results[1].NetApproval)
+
+	assert.Equal(t, 3, response.Results[2].ID)
+	assert.Equal(t, 0.0, response.Results[2].ApprovalRate)
+	assert.Equal(t, 2, response.Results[2].DisapprovalCount)
+	assert.Equal(t, -2, response.Results[2].NetApproval)
+
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
+
+func TestMultiVote(t *testing.T) {
+	const multiVoteBallotSQL = "SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+	t.Run("Replaces Previous Selections", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(multiVoteBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, "approval"))
+		testSetup.Mock.ExpectQuery("SELECT id FROM ballot_items WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count - 1, updated_at = NOW() WHERE id IN (SELECT ballot_item_id FROM multi_votes WHERE user_id = $1 AND ballot_id = $2)").
+			WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		testSetup.Mock.ExpectExec("DELETE FROM multi_votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		for _, itemID := range []int{1, 2} {
+			testSetup.Mock.ExpectExec("INSERT INTO multi_votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)").
+				WithArgs(1, 1, itemID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+			testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+				WithArgs(itemID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := models.MultiVoteRequest{BallotItemIDs: []int{1, 2, 1}}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/multi-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(multiVoteBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, "plurality"))
+
+		reqBody := models.MultiVoteRequest{BallotItemIDs: []int{1}}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/multi-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "This ballot does not accept multiple selections")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestScoreVote(t *testing.T) {
+	const scoreVoteBallotSQL = "SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+	score := func(n int) *int { return &n }
+
+	t.Run("Replaces Previous Scores", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(scoreVoteBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, "score"))
+		testSetup.Mock.ExpectQuery("SELECT id FROM ballot_items WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("DELETE FROM score_votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		testSetup.Mock.ExpectExec("INSERT INTO score_votes (user_id, ballot_id, ballot_item_id, score) VALUES ($1, $2, $3, $4)").
+			WithArgs(1, 1, 1, 8).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec("INSERT INTO score_votes (user_id, ballot_id, ballot_item_id, score) VALUES ($1, $2, $3, $4)").
+			WithArgs(1, 1, 2, 0).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		reqBody := []models.ScoreVoteRequest{
+			{BallotItemID: 1, Score: score(8)},
+			{BallotItemID: 2, Score: score(0)},
+		}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/score-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Every Item Must Be Scored", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(scoreVoteBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, "score"))
+		testSetup.Mock.ExpectQuery("SELECT id FROM ballot_items WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		reqBody := []models.ScoreVoteRequest{{BallotItemID: 1, Score: score(8)}}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/score-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Every ballot item must be scored")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Score Out Of Range", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		reqBody := []models.ScoreVoteRequest{{BallotItemID: 1, Score: score(11)}}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/score-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(scoreVoteBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, "plurality"))
+
+		reqBody := []models.ScoreVoteRequest{{BallotItemID: 1, Score: score(5)}}
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/score-vote", reqBody, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "This ballot does not accept scores")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestScoreBallotResults(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	testSetup.Mock.ExpectQuery(ballotTypeSQL).
+		WithArgs(1).
+		WillReturnRows(ballotTypeRows("score", nil))
+	testSetup.Mock.ExpectQuery(ballotResultsSQL).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+			AddRow(1, 1, "Option 1", "", 0).
+			AddRow(2, 1, "Option 2", "", 0))
+	testSetup.Mock.ExpectQuery("SELECT user_id, ballot_item_id, score FROM score_votes WHERE ballot_id = $1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "ballot_item_id", "score"}).
+			AddRow(1, 1, 4).AddRow(1, 2, 9).
+			AddRow(2, 1, 6).AddRow(2, 2, 10))
+
+	req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, 200, recorder.Code)
+
+	var response struct {
+		WinnerID    int                  `json:"winner_id"`
+		TotalVoters int                  `json:"total_voters"`
+		Results     []models.ScoreResult `json:"results"`
+	}
+	err = parseJSONResponse(recorder, &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, response.WinnerID)
+	assert.Equal(t, 2, response.TotalVoters)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, models.ScoreResult{ItemID: 2, Title: "Option 2", AverageScore: 9.5, ScoreDistribution: [11]int{9: 1, 10: 1}, Rank: 1}, response.Results[0])
+	assert.Equal(t, models.ScoreResult{ItemID: 1, Title: "Option 1", AverageScore: 5, ScoreDistribution: [11]int{4: 1, 6: 1}, Rank: 2}, response.Results[1])
+
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
+
+func TestGetItemCorrelation(t *testing.T) {
+	const itemCorrelationBallotSQL = "SELECT COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	const itemCorrelationSQL = `SELECT a.ballot_item_id, b.ballot_item_id, COUNT(*)
+		FROM multi_votes a
+		JOIN multi_votes b ON a.user_id = b.user_id AND a.ballot_id = b.ballot_id
+		WHERE a.ballot_id = $1 AND a.ballot_item_id < b.ballot_item_id
+		GROUP BY a.ballot_item_id, b.ballot_item_id
+		HAVING COUNT(*) >= $2
+		ORDER BY COUNT(*) DESC`
+
+	t.Run("Multi-Select Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemCorrelationBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_type"}).AddRow("multi_select"))
+		testSetup.Mock.ExpectQuery(itemCorrelationSQL).
+			WithArgs(1, 5).
+			WillReturnRows(sqlmock.NewRows([]string{"item_a", "item_b", "count"}).
+				AddRow(1, 3, 12).
+				AddRow(1, 2, 7))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/item-correlation", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, float64(1), response["ballot_id"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"item_a_id": float64(1), "item_b_id": float64(3), "count": float64(12)},
+			map[string]interface{}{"item_a_id": float64(1), "item_b_id": float64(2), "count": float64(7)},
+		}, response["pairs"])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemCorrelationBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_type"}).AddRow("plurality"))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/item-correlation", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Item correlation is only available for multi-select ballots")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestVoteRefreshesBallotItemUpdatedAt(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	votedAt := createdAt.Add(time.Hour)
+
+	expectGetBallot := func(voteCount int, itemUpdatedAt time.Time) {
+		testSetup.Mock.ExpectQuery(getBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotColumns).
+				AddRow(1, "Test Ballot", "", "", "", "", 2, true, "plurality", false, nil, createdAt, createdAt, 0))
+		testSetup.Mock.ExpectQuery(getBallotItemsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(getBallotItemColumns).
+				AddRow(1, 1, "Option 1", "", voteCount, itemUpdatedAt).
+				AddRow(2, 1, "Option 2", "", 0, createdAt))
+	}
+
+	getItemUpdatedAt := func() time.Time {
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var ballot models.Ballot
+		require.NoError(t, parseJSONResponse(recorder, &ballot))
+		require.NotNil(t, ballot.Items[0].UpdatedAt)
+		return *ballot.Items[0].UpdatedAt
+	}
+
+	expectGetBallot(0, createdAt)
+	before := getItemUpdatedAt()
+
+	testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+	testSetup.Mock.ExpectQuery("SELECT ballot_id FROM ballot_items WHERE id = $1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"ballot_id"}).AddRow(1))
+	testSetup.Mock.ExpectBegin()
+	testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+		WithArgs(1, 1).
+		WillReturnError(sql.ErrNoRows)
+	testSetup.Mock.ExpectExec("INSERT INTO votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)").
+		WithArgs(1, 1, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	testSetup.Mock.ExpectCommit()
+
+	req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/vote", models.VoteRequest{BallotItemID: 1}, 1, "test@example.com")
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+
+	expectGetBallot(1, votedAt)
+	after := getItemUpdatedAt()
+
+	assert.True(t, after.After(before))
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
+
+func TestReplayBallotResults(t *testing.T) {
+	t.Run("As Of Between Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Votes were cast at 10:00 (option 1), 11:00 (option 2) and 13:00 (option 1);
+		// replaying as of 12:00 should only see the first two
+		asOf := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Option 1", "", 2).
+				AddRow(2, 1, "Option 2", "", 1).
+				AddRow(3, 1, "Option 3", "", 0))
+		testSetup.Mock.ExpectQuery("SELECT ballot_item_id, COUNT(*) FROM votes WHERE ballot_id = $1 AND created_at <= $2 GROUP BY ballot_item_id").
+			WithArgs(1, asOf).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id", "count"}).
+				AddRow(1, 1).
+				AddRow(2, 1))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?replay=true&as_of=2026-01-01T12:00:00Z", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			AsOf       string `json:"as_of"`
+			TotalVotes int    `json:"total_votes"`
+			Results    []struct {
+				ID        int `json:"id"`
+				VoteCount int `json:"vote_count"`
+			} `json:"results"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		assert.Equal(t, "2026-01-01T12:00:00Z", response.AsOf)
+		assert.Equal(t, 2, response.TotalVotes)
+		require.Len(t, response.Results, 3)
+		assert.Equal(t, 1, response.Results[0].VoteCount)
+		assert.Equal(t, 1, response.Results[1].VoteCount)
+		assert.Equal(t, 0, response.Results[2].VoteCount)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("As Of In The Future", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		asOf := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?replay=true&as_of="+asOf, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "as_of cannot be in the future")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetActivityHeatmap(t *testing.T) {
+	const heatmapSQL = `SELECT EXTRACT(DOW FROM created_at) AS day_of_week, EXTRACT(HOUR FROM created_at) AS hour_of_day, COUNT(*) AS votes
+		FROM votes
+		WHERE ballot_id = $1 AND created_at > NOW() - make_interval(days => $2)
+		GROUP BY day_of_week, hour_of_day`
+
+	t.Run("Matrix Placement", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(heatmapSQL).
+			WithArgs(1, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"day_of_week", "hour_of_day", "votes"}).
+				AddRow(0, 9, 4).
+				AddRow(3, 18, 7).
+				AddRow(6, 23, 1))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/activity-heatmap", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Heatmap    [][]int  `json:"heatmap"`
+			DayLabels  []string `json:"day_labels"`
+			HourLabels []string `json:"hour_labels"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Heatmap, 7)
+		total := 0
+		for _, hours := range response.Heatmap {
+			require.Len(t, hours, 24)
+			for _, votes := range hours {
+				total += votes
+			}
+		}
+		assert.Equal(t, 12, total)
+		assert.Equal(t, 4, response.Heatmap[0][9])
+		assert.Equal(t, 7, response.Heatmap[3][18])
+		assert.Equal(t, 1, response.Heatmap[6][23])
+
+		assert.Equal(t, []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}, response.DayLabels)
+		require.Len(t, response.HourLabels, 24)
+		assert.Equal(t, "00:00", response.HourLabels[0])
+		assert.Equal(t, "23:00", response.HourLabels[23])
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Votes Still 7x24", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(heatmapSQL).
+			WithArgs(1, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"day_of_week", "hour_of_day", "votes"}))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/activity-heatmap", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			Heatmap [][]int `json:"heatmap"`
+		}
+		err = parseJSONResponse(recorder, &response)
+		require.NoError(t, err)
+
+		require.Len(t, response.Heatmap, 7)
+		for _, hours := range response.Heatmap {
+			assert.Equal(t, make([]int, 24), hours)
+		}
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetVotersMap(t *testing.T) {
+	const votersMapSQL = `SELECT LOWER(NULLIF(ua.state, '')) AS state, COUNT(DISTINCT v.user_id) AS voter_count
+		FROM votes v
+		LEFT JOIN user_addresses ua ON ua.user_id = v.user_id
+		WHERE v.ballot_id = $1
+		GROUP BY LOWER(NULLIF(ua.state, ''))`
+
+	type votersMapResponse struct {
+		States      []models.StateVoterCount `json:"states"`
+		Unknown     int                      `json:"unknown"`
+		TotalVoters int                      `json:"total_voters"`
+	}
+
+	expectVotersMap := func(testSetup *TestSetup, rows *sqlmock.Rows) {
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(votersMapSQL).
+			WithArgs(1).
+			WillReturnRows(rows)
+	}
+
+	t.Run("Percentages And Unknown Bucket", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectVotersMap(testSetup, sqlmock.NewRows([]string{"state", "voter_count"}).
+			AddRow("vermont", 10).
+			AddRow("maine", 6).
+			AddRow(nil, 4))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/voters-map", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response votersMapResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, []models.StateVoterCount{
+			{State: "vermont", VoterCount: 10, Percentage: 50},
+			{State: "maine", VoterCount: 6, Percentage: 30},
+		}, response.States)
+		assert.Equal(t, 4, response.Unknown)
+		assert.Equal(t, 20, response.TotalVoters)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Small State Suppressed", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectVotersMap(testSetup, sqlmock.NewRows([]string{"state", "voter_count"}).
+			AddRow("vermont", 6).
+			AddRow("rhode-island", 2))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/voters-map", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response votersMapResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, []models.StateVoterCount{
+			{State: "vermont", VoterCount: 6, Percentage: 75},
+		}, response.States)
+		assert.Equal(t, 8, response.TotalVoters)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Superstate Filter", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectVotersMap(testSetup, sqlmock.NewRows([]string{"state", "voter_count"}).
+			AddRow("vermont", 5).
+			AddRow("texas", 9).
+			AddRow("florida", 7).
+			AddRow("maine", 5))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/voters-map?superstate=new-england", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response votersMapResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, []models.StateVoterCount{
+			{State: "maine", VoterCount: 5, Percentage: 50},
+			{State: "vermont", VoterCount: 5, Percentage: 50},
+		}, response.States)
+		assert.Equal(t, 10, response.TotalVoters)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown Superstate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/voters-map?superstate=atlantis", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Unknown superstate")
+	})
+}
+
+func TestExportBallotResultsPDF(t *testing.T) {
+	const pdfBallotSQL = "SELECT title, COALESCE(description, ''), created_at, closes_at FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	const partyBreakdownSQL = `SELECT COALESCE(NULLIF(upa.party_affiliation, ''), 'Not specified') AS demographic_group, v.ballot_item_id, COUNT(*) AS votes
+			FROM votes v
+			LEFT JOIN user_political_affiliations upa ON upa.user_id = v.user_id
+			WHERE v.ballot_id = $1
+			GROUP BY demographic_group, v.ballot_item_id`
+	const religionBreakdownSQL = `SELECT COALESCE(NULLIF(ura.religion, ''), 'Not specified') AS demographic_group, v.ballot_item_id, COUNT(*) AS votes
+			FROM votes v
+			LEFT JOIN user_religious_affiliations ura ON ura.user_id = v.user_id
+			WHERE v.ballot_id = $1
+			GROUP BY demographic_group, v.ballot_item_id`
+
+	expectBallotAndResults := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(pdfBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title", "description", "created_at", "closes_at"}).
+				AddRow("Park Budget", "Annual parks budget", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Increase", "", 7).
+				AddRow(2, 1, "Keep", "", 3))
+	}
+
+	t.Run("Returns PDF", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectBallotAndResults(testSetup)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results/export-pdf", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="ballot_1_results.pdf"`, recorder.Header().Get("Content-Disposition"))
+		assert.NotEmpty(t, recorder.Body.Bytes())
+		assert.True(t, bytes.HasPrefix(recorder.Body.Bytes(), []byte("%PDF-")))
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Admin Demographic Breakdown", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(9, "admin")
+		expectBallotAndResults(testSetup)
+		testSetup.Mock.ExpectQuery(partyBreakdownSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"demographic_group", "ballot_item_id", "votes"}).
+				AddRow("Independent", 1, 4).
+				AddRow("Independent", 2, 2).
+				AddRow("Not specified", 1, 3).
+				AddRow("Not specified", 2, 1))
+		testSetup.Mock.ExpectQuery(religionBreakdownSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"demographic_group", "ballot_item_id", "votes"}))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/public/ballots/1/results/export-pdf?include_demographic_breakdown=true", nil, 9, "admin@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+		assert.NotEmpty(t, recorder.Body.Bytes())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Demographic Breakdown Requires Admin", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.MockUserRole(2, "user")
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/public/ballots/1/results/export-pdf?include_demographic_breakdown=true", nil, 2, "user@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 403, "Admin access required")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Demographic Breakdown Requires Token", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results/export-pdf?include_demographic_breakdown=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Unauthorized")
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(pdfBallotSQL).
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/results/export-pdf", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsParticipationRate(t *testing.T) {
+	const scopedEligibleSQL = `SELECT COUNT(*)
+			FROM users u
+			JOIN user_addresses ua ON ua.user_id = u.id
+			WHERE u.deleted_at IS NULL AND LOWER(ua.state) = ANY($1)`
+	const ballotRegionSQL = "SELECT COALESCE(state, ''), COALESCE(superstate, '') FROM ballots WHERE id = $1"
+
+	expectResults := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Yes", "", 20).
+				AddRow(2, 1, "No", "", 5))
+	}
+
+	getResults := func(t *testing.T, testSetup *TestSetup, url string) map[string]interface{} {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("National Rate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM users WHERE deleted_at IS NULL").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(800))
+
+		response := getResults(t, testSetup, "/api/v1/public/ballots/1/results?include_participation_rate=true")
+
+		assert.Equal(t, 3.13, response["participation_rate"])
+		assert.Equal(t, "national", response["scope"])
+		assert.Equal(t, float64(800), response["eligible_voters"])
+		assert.NotContains(t, response, "scope_value")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("State Scoped Rate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(ballotRegionSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "superstate"}).AddRow("vermont", "new-england"))
+		testSetup.Mock.ExpectQuery(scopedEligibleSQL).
+			WithArgs(pq.Array([]string{"vermont"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(100))
+
+		response := getResults(t, testSetup, "/api/v1/public/ballots/1/results?include_participation_rate=true&scope=state")
+
+		assert.Equal(t, float64(25), response["participation_rate"])
+		assert.Equal(t, "state", response["scope"])
+		assert.Equal(t, "vermont", response["scope_value"])
+		assert.Equal(t, float64(100), response["eligible_voters"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Superstate Scoped Rate", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(ballotRegionSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "superstate"}).AddRow("vermont", "new-england"))
+		testSetup.Mock.ExpectQuery(scopedEligibleSQL).
+			WithArgs(pq.Array([]string{"connecticut", "maine", "massachusetts", "new-hampshire", "rhode-island", "vermont"})).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(250))
+
+		response := getResults(t, testSetup, "/api/v1/public/ballots/1/results?include_participation_rate=true&scope=superstate")
+
+		assert.Equal(t, float64(10), response["participation_rate"])
+		assert.Equal(t, "superstate", response["scope"])
+		assert.Equal(t, "new-england", response["scope_value"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("State Scope Requires Ballot State", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(ballotRegionSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "superstate"}).AddRow("", ""))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?include_participation_rate=true&scope=state", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Ballot has no state")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Scope", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?include_participation_rate=true&scope=county", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "scope must be state or superstate")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsWriteInSentiment(t *testing.T) {
+	t.Run("Summarises Write-In Titles", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Yes", "", 4).
+				AddRow(2, 1, "Better transit", "", 2).
+				AddRow(3, 1, "Transit is terrible", "", 1))
+		testSetup.Mock.ExpectQuery("SELECT title FROM ballot_items WHERE ballot_id = $1 AND is_write_in = true").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"title"}).
+				AddRow("Better transit").
+				AddRow("Transit is terrible").
+				AddRow("Pat Lee"))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?analyze_write_ins=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			WriteInSentiment models.WriteInSentiment `json:"write_in_sentiment"`
+		}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, 1, response.WriteInSentiment.PositiveCount)
+		assert.Equal(t, 1, response.WriteInSentiment.NegativeCount)
+		assert.Equal(t, 1, response.WriteInSentiment.NeutralCount)
+		assert.Equal(t, "transit", response.WriteInSentiment.MostCommonWords[0])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Omitted Unless Requested", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Yes", "", 4))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.NotContains(t, response, "write_in_sentiment")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsNormalized(t *testing.T) {
+	type normalizedResponse struct {
+		EffectiveN int `json:"effective_n"`
+		TotalVotes int `json:"total_votes"`
+		Results    []struct {
+			ID              int      `json:"id"`
+			VoteCount       int      `json:"vote_count"`
+			NormalizedScore *float64 `json:"normalized_score"`
+		} `json:"results"`
+	}
+
+	t.Run("Proportion Of Total Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Option 1", "", 2).
+				AddRow(2, 1, "Option 2", "", 1).
+				AddRow(3, 1, "Option 3", "", 0))
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?normalize=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response normalizedResponse
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		// effective_n comes from the votes table, not the sum of vote_count
+		assert.Equal(t, 3, response.TotalVotes)
+		assert.Equal(t, 4, response.EffectiveN)
+		require.Len(t, response.Results, 3)
+		require.NotNil(t, response.Results[0].NormalizedScore)
+		assert.Equal(t, 0.6667, *response.Results[0].NormalizedScore)
+		assert.Equal(t, 2, response.Results[0].VoteCount)
+		require.NotNil(t, response.Results[1].NormalizedScore)
+		assert.Equal(t, 0.3333, *response.Results[1].NormalizedScore)
+		require.NotNil(t, response.Results[2].NormalizedScore)
+		assert.Equal(t, 0.0, *response.Results[2].NormalizedScore)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Null Without Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Option 1", "", 0).
+				AddRow(2, 1, "Option 2", "", 0))
+		testSetup.Mock.ExpectQuery("SELECT COUNT(*) FROM votes WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?normalize=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, float64(0), response["effective_n"])
+		results := response["results"].([]interface{})
+		require.Len(t, results, 2)
+		for _, result := range results {
+			item := result.(map[string]interface{})
+			assert.Contains(t, item, "normalized_score")
+			assert.Nil(t, item["normalized_score"])
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsThreshold(t *testing.T) {
+	expectResults := func(testSetup *TestSetup, counts ...int) {
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		rows := sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"})
+		for i, count := range counts {
+			rows.AddRow(i+1, 1, fmt.Sprintf("Option %d", i+1), "", count)
+		}
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(rows)
+	}
+
+	t.Run("Items Above And Below Threshold", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 42, 35, 23)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?threshold=40", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			ThresholdApplied      int `json:"threshold_applied"`
+			OptionsAboveThreshold int `json:"options_above_threshold"`
+			Results               []struct {
+				ID              int  `json:"id"`
+				PassedThreshold bool `json:"passed_threshold"`
+			} `json:"results"`
+		}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, 40, response.ThresholdApplied)
+		assert.Equal(t, 1, response.OptionsAboveThreshold)
+		require.Len(t, response.Results, 3)
+		assert.True(t, response.Results[0].PassedThreshold)
+		assert.False(t, response.Results[1].PassedThreshold)
+		assert.False(t, response.Results[2].PassedThreshold)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Votes Cast", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 0, 0)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?threshold=1", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, float64(0), response["options_above_threshold"])
+		for _, result := range response["results"].([]interface{}) {
+			assert.Equal(t, false, result.(map[string]interface{})["passed_threshold"])
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Absent Without Threshold", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 42, 35)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.NotContains(t, response, "threshold_applied")
+		assert.NotContains(t, response, "options_above_threshold")
+		for _, result := range response["results"].([]interface{}) {
+			assert.NotContains(t, result, "passed_threshold")
+		}
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Threshold Out Of Range", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?threshold=150", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "threshold must be an integer from 1 to 100")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsWinner(t *testing.T) {
+	expectResults := func(testSetup *TestSetup, minimumQuorum interface{}, counts ...int) {
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", minimumQuorum))
+		rows := sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"})
+		for i, count := range counts {
+			rows.AddRow(i+1, 1, fmt.Sprintf("Option %d", i+1), "", count)
+		}
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(rows)
+	}
+
+	getResults := func(t *testing.T, testSetup *TestSetup) map[string]interface{} {
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("Clear Winner", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, nil, 5, 3)
+
+		response := getResults(t, testSetup)
+
+		assert.Equal(t, map[string]interface{}{
+			"item_id":    float64(1),
+			"title":      "Option 1",
+			"vote_count": float64(5),
+			"percentage": 62.5,
+		}, response["winner"])
+		assert.NotContains(t, response, "tied_items")
+		assert.Equal(t, "low", response["confidence_level"])
+		assert.Equal(t, 34.65, response["margin_of_error_95_percent"])
+		declaredAt, err := time.Parse(time.RFC3339Nano, response["declared_at"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), declaredAt, time.Minute)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Tie For First", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, nil, 4, 4, 2)
+
+		response := getResults(t, testSetup)
+
+		assert.Contains(t, response, "winner")
+		assert.Nil(t, response["winner"])
+		tied := response["tied_items"].([]interface{})
+		require.Len(t, tied, 2)
+		assert.Equal(t, float64(1), tied[0].(map[string]interface{})["item_id"])
+		assert.Equal(t, float64(2), tied[1].(map[string]interface{})["item_id"])
+		assert.Equal(t, 40.0, tied[1].(map[string]interface{})["percentage"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Quorum Not Met", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 10, 5, 3)
+
+		response := getResults(t, testSetup)
+
+		assert.Contains(t, response, "winner")
+		assert.Nil(t, response["winner"])
+		assert.Equal(t, true, response["winner_pending_quorum"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Quorum Met", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 8, 5, 3)
+
+		response := getResults(t, testSetup)
+
+		require.NotNil(t, response["winner"])
+		assert.Equal(t, float64(1), response["winner"].(map[string]interface{})["item_id"])
+		assert.NotContains(t, response, "winner_pending_quorum")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup, 10, 0, 0)
+
+		response := getResults(t, testSetup)
+
+		assert.Contains(t, response, "winner")
+		assert.Nil(t, response["winner"])
+		assert.NotContains(t, response, "tied_items")
+		assert.NotContains(t, response, "winner_pending_quorum")
+		assert.Equal(t, "none", response["confidence_level"])
+		assert.Contains(t, response, "margin_of_error_95_percent")
+		assert.Nil(t, response["margin_of_error_95_percent"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsBinary(t *testing.T) {
+	getResults := func(t *testing.T, titles []string, counts []int) map[string]interface{} {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		rows := sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"})
+		for i, title := range titles {
+			rows.AddRow(i+1, 1, title, "", counts[i])
+		}
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("Unbalanced Rejection Leads", func(t *testing.T) {
+		response := getResults(t, []string{"No", "Yes, approve the levy"}, []int{15, 5})
+
+		assert.Equal(t, true, response["is_binary_ballot"])
+		assert.Equal(t, float64(2), response["approval_item_id"])
+		assert.Equal(t, float64(1), response["rejection_item_id"])
+		assert.Equal(t, -50.0, response["net_approval_percentage"])
+	})
+
+	t.Run("Balanced", func(t *testing.T) {
+		response := getResults(t, []string{"Support", "Oppose"}, []int{6, 6})
+
+		assert.Equal(t, true, response["is_binary_ballot"])
+		assert.Equal(t, float64(1), response["approval_item_id"])
+		assert.Equal(t, float64(2), response["rejection_item_id"])
+		assert.Equal(t, 0.0, response["net_approval_percentage"])
+	})
+
+	t.Run("Three Items", func(t *testing.T) {
+		response := getResults(t, []string{"Yes", "No", "Abstain"}, []int{3, 2, 1})
+
+		assert.Equal(t, false, response["is_binary_ballot"])
+		assert.NotContains(t, response, "approval_item_id")
+		assert.NotContains(t, response, "net_approval_percentage")
+	})
+}
+
+func TestGetLeadingItemTimeline(t *testing.T) {
+	const ballotExistsSQL = "SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)"
+	const snapshotsSQL = "SELECT snapshotted_at, results FROM ballot_result_snapshots WHERE ballot_id = $1 ORDER BY snapshotted_at"
+
+	t.Run("Lead Change Between Snapshots", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		first := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery(ballotExistsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(snapshotsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"snapshotted_at", "results"}).
+				AddRow(first, []byte(`[{"item_id":1,"title":"Parks","vote_count":5},{"item_id":2,"title":"Roads","vote_count":3}]`)).
+				AddRow(first.Add(time.Hour), []byte(`[{"item_id":2,"title":"Roads","vote_count":9},{"item_id":1,"title":"Parks","vote_count":7}]`)).
+				AddRow(first.Add(2*time.Hour), []byte(`[{"item_id":1,"title":"Parks","vote_count":8},{"item_id":2,"title":"Roads","vote_count":12}]`)))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/leading-item-timeline", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response []map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		require.Len(t, response, 3)
+
+		assert.Equal(t, map[string]interface{}{
+			"snapshotted_at":     "2026-05-01T09:00:00Z",
+			"leading_item_id":    float64(1),
+			"leading_item_title": "Parks",
+			"vote_count":         float64(5),
+			"lead_over_second":   float64(2),
+		}, response[0])
+
+		assert.Equal(t, float64(2), response[1]["leading_item_id"])
+		assert.Equal(t, float64(2), response[1]["lead_over_second"])
+		assert.Equal(t, true, response[1]["was_lead_change"])
+
+		// Items are re-sorted, so the leader is found regardless of stored order
+		assert.Equal(t, float64(2), response[2]["leading_item_id"])
+		assert.Equal(t, float64(12), response[2]["vote_count"])
+		assert.Equal(t, float64(4), response[2]["lead_over_second"])
+		assert.NotContains(t, response[2], "was_lead_change")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotExistsSQL).
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/leading-item-timeline", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestBallotResultsTimeDecay(t *testing.T) {
+	t.Run("Weighted Scores Alongside Raw Counts", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		now := time.Now()
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Old Favorite", "", 2).
+				AddRow(2, 1, "New Favorite", "", 1))
+		testSetup.Mock.ExpectQuery("SELECT ballot_item_id, created_at FROM votes WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_item_id", "created_at"}).
+				AddRow(1, now.AddDate(0, 0, -7)).
+				AddRow(1, now.AddDate(0, 0, -7)).
+				AddRow(2, now))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?time_decay_factor=0.5", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+
+		var response struct {
+			TimeDecayFactor float64 `json:"time_decay_factor"`
+			Results         []struct {
+				ID            int      `json:"id"`
+				VoteCount     int      `json:"vote_count"`
+				WeightedScore *float64 `json:"weighted_score"`
+			} `json:"results"`
+		}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+
+		assert.Equal(t, 0.5, response.TimeDecayFactor)
+		require.Len(t, response.Results, 2)
+		assert.Equal(t, 2, response.Results[0].VoteCount)
+		require.NotNil(t, response.Results[0].WeightedScore)
+		assert.InDelta(t, 0.0604, *response.Results[0].WeightedScore, 0.0001)
+		assert.Equal(t, 1, response.Results[1].VoteCount)
+		require.NotNil(t, response.Results[1].WeightedScore)
+		assert.InDelta(t, 1.0, *response.Results[1].WeightedScore, 0.0001)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	for _, value := range []string{"1.5", "-0.1", "fast"} {
+		t.Run("Invalid Factor "+value, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(ballotTypeSQL).
+				WithArgs(1).
+				WillReturnRows(ballotTypeRows("plurality", nil))
+
+			req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?time_decay_factor="+value, nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertErrorResponse(t, recorder, 400, "time_decay_factor must be a number from 0 to 1")
+		})
+	}
+}
+
+func TestBallotResultsEligibleTurnout(t *testing.T) {
+	const statePopulationSQL = `SELECT b.state, sp.population
+		FROM ballots b
+		JOIN state_populations sp ON sp.state_code = b.state
+		WHERE b.id = $1`
+	const stateRegisteredSQL = `SELECT COUNT(*)
+		FROM users u
+		JOIN user_addresses ua ON ua.user_id = u.id
+		WHERE u.deleted_at IS NULL AND LOWER(ua.state) = $1`
+
+	expectResults := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery(ballotTypeSQL).
+			WithArgs(1).
+			WillReturnRows(ballotTypeRows("plurality", nil))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Yes", "", 20).
+				AddRow(2, 1, "No", "", 5))
+	}
+
+	getResults := func(t *testing.T, testSetup *TestSetup) map[string]interface{} {
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/results?as_percentage_of_eligible=true", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	t.Run("Turnout Against Population And Registered Users", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(statePopulationSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "population"}).AddRow("vermont", 643077))
+		testSetup.Mock.ExpectQuery(stateRegisteredSQL).
+			WithArgs("vermont").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(200))
+
+		response := getResults(t, testSetup)
+
+		assert.Equal(t, 0.003888, response["turnout_rate_vs_population"])
+		assert.Equal(t, 12.5, response["turnout_rate_vs_registered_users"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Registered Users In State", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(statePopulationSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "population"}).AddRow("wyoming", 576851))
+		testSetup.Mock.ExpectQuery(stateRegisteredSQL).
+			WithArgs("wyoming").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		response := getResults(t, testSetup)
+
+		assert.Equal(t, 0.004334, response["turnout_rate_vs_population"])
+		assert.Equal(t, 0.0, response["turnout_rate_vs_registered_users"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Without State Or Seeded Population", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectResults(testSetup)
+		testSetup.Mock.ExpectQuery(statePopulationSQL).
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+
+		response := getResults(t, testSetup)
+
+		assert.NotContains(t, response, "turnout_rate_vs_population")
+		assert.NotContains(t, response, "turnout_rate_vs_registered_users")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetVoterStats(t *testing.T) {
+	const voterStatesSQL = `SELECT LOWER(NULLIF(ua.state, '')) AS state, COUNT(DISTINCT v.user_id) AS voter_count
+		FROM votes v
+		LEFT JOIN user_addresses ua ON ua.user_id = v.user_id
+		WHERE v.ballot_id = $1
+		GROUP BY LOWER(NULLIF(ua.state, ''))`
+
+	getStats := func(t *testing.T, testSetup *TestSetup) (*httptest.ResponseRecorder, models.VoterStats) {
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/1/voters", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		var stats models.VoterStats
+		if recorder.Code == 200 {
+			require.NoError(t, parseJSONResponse(recorder, &stats))
+		}
+		return recorder, stats
+	}
+
+	t.Run("Voters With And Without Addresses", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(voterStatesSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "voter_count"}).
+				AddRow("vermont", 8).
+				AddRow("maine", 4).
+				AddRow("florida", 3).
+				AddRow("atlantis", 2).
+				AddRow(nil, 5))
+
+		recorder, stats := getStats(t, testSetup)
+
+		require.Equal(t, 200, recorder.Code)
+		assert.Equal(t, models.VoterStats{
+			BallotID:     1,
+			UniqueVoters: 22,
+			// Vermont and Maine combine into new-england; florida-georgia is too small to show
+			BySuperstate: map[string]int{"new-england": 12},
+			Unknown:      7,
+		}, stats)
+		assert.NotContains(t, recorder.Body.String(), "user_id")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		testSetup.Mock.ExpectQuery(voterStatesSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"state", "voter_count"}))
+
+		recorder, stats := getStats(t, testSetup)
+
+		require.Equal(t, 200, recorder.Code)
+		assert.Equal(t, models.VoterStats{BallotID: 1, BySuperstate: map[string]int{}}, stats)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery("SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		recorder, _ := getStats(t, testSetup)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetUserVoteHistory(t *testing.T) {
+	const voteHistorySQL = `SELECT v.id, v.ballot_id, b.title, v.ballot_item_id, bi.title, v.created_at
+		FROM votes v
+		JOIN ballots b ON b.id = v.ballot_id
+		JOIN ballot_items bi ON bi.id = v.ballot_item_id
+		WHERE v.user_id = $1
+		ORDER BY v.created_at DESC, v.id DESC
+		LIMIT $2 OFFSET $3`
+	historyColumns := []string{"id", "ballot_id", "title", "ballot_item_id", "title", "created_at"}
+
+	t.Run("Returns Votes Newest First", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		votedAt1 := time.Date(2023, 3, 2, 0, 0, 0, 0, time.UTC)
+		votedAt2 := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+		testSetup.Mock.ExpectQuery(voteHistorySQL).
+			WithArgs(1, 20, 0).
+			WillReturnRows(sqlmock.NewRows(historyColumns).
+				AddRow(12, 4, "Library Hours", 9, "Extend", votedAt1).
+				AddRow(7, 2, "Park Budget", 3, "Approve", votedAt2))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/my-votes", nil, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		require.Equal(t, 200, recorder.Code)
+
+		var history []models.VoteHistoryEntry
+		require.NoError(t, parseJSONResponse(recorder, &history))
+		require.Len(t, history, 2)
+		assert.Equal(t, models.VoteHistoryEntry{
+			VoteID: 12, BallotID: 4, BallotTitle: "Library Hours", BallotItemID: 9, ChosenOptionTitle: "Extend", VotedAt: votedAt1,
+		}, history[0])
+		assert.Equal(t, "Park Budget", history[1].BallotTitle)
+
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Applies Limit And Offset", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		// Limits above the maximum are capped rather than rejected
+		testSetup.Mock.ExpectQuery(voteHistorySQL).
+			WithArgs(1, 100, 40).
+			WillReturnRows(sqlmock.NewRows(historyColumns))
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/my-votes?limit=500&offset=40", nil, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, "[]", recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Offset", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateAuthenticatedRequest("GET", "/api/v1/my-votes?offset=-1", nil, 1, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid offset")
+	})
+
+	t.Run("Without Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/my-votes", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 401, "Authorization header required")
+	})
+}
+
+func TestGetParticipantsCount(t *testing.T) {
+	const (
+		ballotExistsSQL      = "SELECT EXISTS(SELECT 1 FROM ballots WHERE id = $1 AND deleted_at IS NULL)"
+		participantsCountSQL = "SELECT COUNT(DISTINCT user_id) FROM votes WHERE ballot_id = $1"
+	)
+
+	for _, tc := range []struct {
+		name         string
+		participants int
+	}{
+		{"No Participants", 0},
+		{"One Participant", 1},
+		{"Many Participants", 42},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(ballotExistsSQL).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			testSetup.Mock.ExpectQuery(participantsCountSQL).
+				WithArgs(3).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.participants))
+
+			req, err := CreateTestRequest("GET", "/api/v1/public/ballots/3/participants-count", nil)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertJSONResponse(t, recorder, 200, map[string]interface{}{
+				"ballot_id":         float64(3),
+				"participant_count": float64(tc.participants),
+			})
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(ballotExistsSQL).
+			WithArgs(99).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/99/participants-count", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Ballot ID", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/public/ballots/abc/participants-count", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid ballot ID")
+	})
+}
+
+const itemVotesAuthSQL = `SELECT creator_id,
+		       EXISTS(SELECT 1 FROM ballot_co_creators WHERE ballot_id = $1 AND user_id = $2),
+		       EXISTS(SELECT 1 FROM ballot_items WHERE id = $3 AND ballot_id = $1)
+		FROM ballots WHERE id = $1 AND deleted_at IS NULL`
+
+const itemVotesSQL = `SELECT user_id, created_at FROM (
+			SELECT user_id, created_at FROM votes WHERE ballot_item_id = $1 AND user_id IS NOT NULL
+			UNION ALL
+			SELECT user_id, created_at FROM multi_votes WHERE ballot_item_id = $1
+		) item_votes
+		ORDER BY created_at ASC, user_id ASC
+		LIMIT $2 OFFSET $3`
+
+var itemVotesAuthColumns = []string{"creator_id", "is_co_creator", "item_exists"}
+
+func TestGetItemVotes(t *testing.T) {
+	getItemVotes := func(t *testing.T, testSetup *TestSetup, url string, userID int) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("GET", url, nil, userID, "creator@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Creator Lists Voters", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		first := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+		second := first.Add(time.Hour)
+		testSetup.Mock.ExpectQuery(itemVotesAuthSQL).
+			WithArgs(5, 1, 12).
+			WillReturnRows(sqlmock.NewRows(itemVotesAuthColumns).AddRow(1, false, true))
+		testSetup.Mock.ExpectQuery(itemVotesSQL).
+			WithArgs(12, 20, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "created_at"}).
+				AddRow(8, first).
+				AddRow(3, second))
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/12/votes", 1)
+		require.Equal(t, 200, recorder.Code)
+
+		var result models.ItemVotes
+		require.NoError(t, parseJSONResponse(recorder, &result))
+		assert.Equal(t, 12, result.ItemID)
+		require.Len(t, result.Voters, 2)
+		assert.Equal(t, 8, result.Voters[0].UserID)
+		assert.True(t, first.Equal(result.Voters[0].VotedAt))
+		assert.Equal(t, 3, result.Voters[1].UserID)
+
+		// Only opaque IDs are exposed
+		assert.NotContains(t, recorder.Body.String(), "email")
+		assert.NotContains(t, recorder.Body.String(), "username")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Co-Creator Paginates", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemVotesAuthSQL).
+			WithArgs(5, 2, 12).
+			WillReturnRows(sqlmock.NewRows(itemVotesAuthColumns).AddRow(1, true, true))
+		testSetup.Mock.ExpectQuery(itemVotesSQL).
+			WithArgs(12, 10, 30).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "created_at"}))
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/12/votes?limit=10&offset=30", 2)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, []interface{}{}, response["voters"])
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Forbidden For Non-Creator", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemVotesAuthSQL).
+			WithArgs(5, 9, 12).
+			WillReturnRows(sqlmock.NewRows(itemVotesAuthColumns).AddRow(1, false, true))
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/12/votes", 9)
+
+		AssertErrorResponse(t, recorder, 403, "Only the ballot creator can view item votes")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemVotesAuthSQL).
+			WithArgs(99, 1, 12).
+			WillReturnError(sql.ErrNoRows)
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/99/items/12/votes", 1)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Item Not On Ballot", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(itemVotesAuthSQL).
+			WithArgs(5, 1, 77).
+			WillReturnRows(sqlmock.NewRows(itemVotesAuthColumns).AddRow(1, false, false))
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/77/votes", 1)
+
+		AssertErrorResponse(t, recorder, 404, "Ballot item not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Item ID", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/abc/votes", 1)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid item ID")
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := getItemVotes(t, testSetup, "/api/v1/ballots/5/items/12/votes?limit=0", 1)
+
+		AssertErrorResponse(t, recorder, 400, "Invalid limit")
+	})
+}
+
+func TestCountUserVotes(t *testing.T) {
+	const countUserVotesSQL = "SELECT COUNT(*) FROM votes WHERE user_id = $1"
+
+	testCases := []struct {
+		name  string
+		count int
+	}{
+		{"No Votes", 0},
+		{"Several Votes", 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSetup, err := SetupTestEnvironment()
+			require.NoError(t, err)
+			defer testSetup.DB.Close()
+
+			testSetup.Mock.ExpectQuery(countUserVotesSQL).
+				WithArgs(6).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tc.count))
+
+			req, err := CreateAuthenticatedRequest("GET", "/api/v1/my-votes/count", nil, 6, "voter@example.com")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			testSetup.Router.ServeHTTP(recorder, req)
+
+			AssertJSONResponse(t, recorder, 200, map[string]interface{}{"count": float64(tc.count)})
+			assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Requires Authentication", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		req, err := CreateTestRequest("GET", "/api/v1/my-votes/count", nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, 401, recorder.Code)
+	})
+}
+
+func TestBallotResultsCache(t *testing.T) {
+	testSetup, err := SetupTestEnvironment()
+	require.NoError(t, err)
+	defer testSetup.DB.Close()
+
+	ballotID, itemID, userID := 1, 2, 5
+	getResults := func() map[string]interface{} {
+		req, err := CreateTestRequest("GET", fmt.Sprintf("/api/v1/public/ballots/%d/results", ballotID), nil)
+		require.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		require.Equal(t, 200, recorder.Code)
+
+		var response map[string]interface{}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		return response
+	}
+
+	// Miss: the tally is read from the database
+	testSetup.Mock.ExpectQuery(ballotTypeSQL).
+		WithArgs(ballotID).
+		WillReturnRows(ballotTypeRows("plurality", nil))
+	testSetup.Mock.ExpectQuery(ballotResultsSQL).
+		WithArgs(ballotID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+			AddRow(itemID, ballotID, "Option 1", "First option", 4))
+	assert.Equal(t, float64(4), getResults()["total_votes"])
+
+	// Hit: only the ballot lookup runs
+	testSetup.Mock.ExpectQuery(ballotTypeSQL).
+		WithArgs(ballotID).
+		WillReturnRows(ballotTypeRows("plurality", nil))
+	assert.Equal(t, float64(4), getResults()["total_votes"])
+	require.NoError(t, testSetup.Mock.ExpectationsWereMet())
+
+	// A committed vote evicts the cached tally
+	testSetup.Mock.ExpectQuery("SELECT is_active FROM ballots WHERE id = $1 AND deleted_at IS NULL").
+		WithArgs(ballotID).
+		WillReturnRows(sqlmock.NewRows([]string{"is_active"}).AddRow(true))
+	testSetup.Mock.ExpectQuery("SELECT ballot_id FROM ballot_items WHERE id = $1").
+		WithArgs(itemID).
+		WillReturnRows(sqlmock.NewRows([]string{"ballot_id"}).AddRow(ballotID))
+	testSetup.Mock.ExpectBegin()
+	testSetup.Mock.ExpectQuery("SELECT id, ballot_item_id FROM votes WHERE user_id = $1 AND ballot_id = $2").
+		WithArgs(userID, ballotID).
+		WillReturnError(sql.ErrNoRows)
+	testSetup.Mock.ExpectExec("INSERT INTO votes (user_id, ballot_id, ballot_item_id) VALUES ($1, $2, $3)").
+		WithArgs(userID, ballotID, itemID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	testSetup.Mock.ExpectExec("UPDATE ballot_items SET vote_count = vote_count + 1, updated_at = NOW() WHERE id = $1").
+		WithArgs(itemID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	testSetup.Mock.ExpectCommit()
+
+	req, err := CreateAuthenticatedRequest("POST", fmt.Sprintf("/api/v1/ballots/%d/vote", ballotID), models.VoteRequest{BallotItemID: itemID}, userID, "voter@example.com")
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	testSetup.Router.ServeHTTP(recorder, req)
+	require.Equal(t, 200, recorder.Code)
+
+	testSetup.Mock.ExpectQuery(ballotTypeSQL).
+		WithArgs(ballotID).
+		WillReturnRows(ballotTypeRows("plurality", nil))
+	testSetup.Mock.ExpectQuery(ballotResultsSQL).
+		WithArgs(ballotID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+			AddRow(itemID, ballotID, "Option 1", "First option", 5))
+	assert.Equal(t, float64(5), getResults()["total_votes"])
+
+	assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+}
+
+func TestRankedVote(t *testing.T) {
+	const rankedBallotSQL = "SELECT is_active, COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+	const insertRankingSQL = "INSERT INTO ranked_votes (user_id, ballot_id, ballot_item_id, rank) VALUES ($1, $2, $3, $4)"
+
+	expectRankedBallot := func(testSetup *TestSetup, ballotType string) {
+		testSetup.Mock.ExpectQuery(rankedBallotSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"is_active", "ballot_type"}).AddRow(true, ballotType))
+	}
+	expectItems := func(testSetup *TestSetup) {
+		testSetup.Mock.ExpectQuery("SELECT id FROM ballot_items WHERE ballot_id = $1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+	}
+	post := func(t *testing.T, testSetup *TestSetup, body interface{}) *httptest.ResponseRecorder {
+		req, err := CreateAuthenticatedRequest("POST", "/api/v1/ballots/1/ranked-vote", body, 5, "test@example.com")
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Replaces Previous Ranking", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectRankedBallot(testSetup, "ranked")
+		expectItems(testSetup)
+		testSetup.Mock.ExpectBegin()
+		testSetup.Mock.ExpectExec("DELETE FROM ranked_votes WHERE user_id = $1 AND ballot_id = $2").
+			WithArgs(5, 1).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		// Stored in rank order whatever order they were sent in
+		testSetup.Mock.ExpectExec(insertRankingSQL).WithArgs(5, 1, 3, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectExec(insertRankingSQL).WithArgs(5, 1, 1, 2).WillReturnResult(sqlmock.NewResult(1, 1))
+		testSetup.Mock.ExpectCommit()
+
+		recorder := post(t, testSetup, models.RankedVoteRequest{Rankings: []models.RankedChoice{
+			{BallotItemID: 1, Rank: 2},
+			{BallotItemID: 3, Rank: 1},
+		}})
+
+		assert.Equal(t, 200, recorder.Code)
+		var response struct {
+			Rankings []models.RankedChoice `json:"rankings"`
+		}
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, []models.RankedChoice{{BallotItemID: 3, Rank: 1}, {BallotItemID: 1, Rank: 2}}, response.Rankings)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid Rankings", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			rankings []models.RankedChoice
+			expected string
+		}{
+			{"Gap In Ranks", []models.RankedChoice{{BallotItemID: 1, Rank: 1}, {BallotItemID: 2, Rank: 3}}, "Ranks must run from 1 without gaps or repeats"},
+			{"Repeated Rank", []models.RankedChoice{{BallotItemID: 1, Rank: 1}, {BallotItemID: 2, Rank: 1}}, "Ranks must run from 1 without gaps or repeats"},
+			{"Item Ranked Twice", []models.RankedChoice{{BallotItemID: 1, Rank: 1}, {BallotItemID: 1, Rank: 2}}, "Each ballot item can only be ranked once"},
+			{"Item From Another Ballot", []models.RankedChoice{{BallotItemID: 9, Rank: 1}}, "Ballot item does not belong to this ballot"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				testSetup, err := SetupTestEnvironment()
+				require.NoError(t, err)
+				defer testSetup.DB.Close()
+
+				expectRankedBallot(testSetup, "ranked")
+				expectItems(testSetup)
+
+				AssertErrorResponse(t, post(t, testSetup, models.RankedVoteRequest{Rankings: tt.rankings}), 400, tt.expected)
+				assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+			})
+		}
+	})
+
+	t.Run("Empty Rankings", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		recorder := post(t, testSetup, models.RankedVoteRequest{Rankings: []models.RankedChoice{}})
+
+		assert.Equal(t, 400, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "VALIDATION_FAILED")
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		expectRankedBallot(testSetup, "plurality")
+
+		recorder := post(t, testSetup, models.RankedVoteRequest{Rankings: []models.RankedChoice{{BallotItemID: 1, Rank: 1}}})
+
+		AssertErrorResponse(t, recorder, 400, "This ballot does not accept rankings")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetRankedResults(t *testing.T) {
+	const rankedTypeSQL = "SELECT COALESCE(ballot_type, 'plurality') FROM ballots WHERE id = $1 AND deleted_at IS NULL"
+
+	get := func(t *testing.T, testSetup *TestSetup, url string) *httptest.ResponseRecorder {
+		req, err := CreateTestRequest("GET", url, nil)
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		testSetup.Router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	t.Run("Instant Runoff Winner", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(rankedTypeSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_type"}).AddRow("ranked"))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Alpha", "", 0).
+				AddRow(2, 1, "Beta", "", 0).
+				AddRow(3, 1, "Gamma", "", 0))
+		// First preferences: Alpha 2, Beta 2, Gamma 1; Gamma's voter prefers Beta next
+		testSetup.Mock.ExpectQuery("SELECT user_id, ballot_item_id FROM ranked_votes WHERE ballot_id = $1 ORDER BY user_id, rank").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ballot_item_id"}).
+				AddRow(10, 1).AddRow(10, 2).
+				AddRow(11, 1).
+				AddRow(12, 2).AddRow(12, 1).
+				AddRow(13, 2).
+				AddRow(14, 3).AddRow(14, 2))
+
+		recorder := get(t, testSetup, "/api/v1/public/ballots/1/ranked-results")
+		require.Equal(t, 200, recorder.Code)
+
+		var response models.RankedResults
+		require.NoError(t, parseJSONResponse(recorder, &response))
+		assert.Equal(t, 1, response.BallotID)
+		require.NotNil(t, response.WinnerID)
+		assert.Equal(t, 2, *response.WinnerID)
+		require.NotNil(t, response.WinnerTitle)
+		assert.Equal(t, "Beta", *response.WinnerTitle)
+		assert.Equal(t, []models.IRVEliminationRound{
+			{Round: 1, EliminatedItemID: 3, EliminatedItemTitle: "Gamma", RedistributedVotes: 1},
+		}, response.EliminationRounds)
+		assert.Equal(t, 5, response.TotalVoters)
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("No Rankings Cast", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(rankedTypeSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_type"}).AddRow("ranked"))
+		testSetup.Mock.ExpectQuery(ballotResultsSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ballot_id", "title", "description", "vote_count"}).
+				AddRow(1, 1, "Alpha", "", 0))
+		testSetup.Mock.ExpectQuery("SELECT user_id, ballot_item_id FROM ranked_votes WHERE ballot_id = $1 ORDER BY user_id, rank").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ballot_item_id"}))
+
+		recorder := get(t, testSetup, "/api/v1/public/ballots/1/ranked-results")
+		require.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"ballot_id": 1, "winner_id": null, "winner_title": null, "elimination_rounds": [], "total_voters": 0}`, recorder.Body.String())
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Plurality Ballot Rejected", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(rankedTypeSQL).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"ballot_type"}).AddRow("plurality"))
+
+		AssertErrorResponse(t, get(t, testSetup, "/api/v1/public/ballots/1/ranked-results"), 400, "Ranked results are only available for ranked ballots")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ballot Not Found", func(t *testing.T) {
+		testSetup, err := SetupTestEnvironment()
+		require.NoError(t, err)
+		defer testSetup.DB.Close()
+
+		testSetup.Mock.ExpectQuery(rankedTypeSQL).
+			WithArgs(9).
+			WillReturnError(sql.ErrNoRows)
+
+		AssertErrorResponse(t, get(t, testSetup, "/api/v1/public/ballots/9/ranked-results"), 404, "Ballot not found")
+		assert.NoError(t, testSetup.Mock.ExpectationsWereMet())
+	})
+}
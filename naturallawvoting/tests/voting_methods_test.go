@@ -0,0 +1,299 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	"voting-api/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBordaCount(t *testing.T) {
+	t.Run("Three Candidates Five Voters", func(t *testing.T) {
+		rankings := [][]int{
+			{1, 2, 3},
+			{1, 3, 2},
+			{2, 3, 1},
+			{3, 2, 1},
+			{1, 2, 3},
+		}
+
+		scores := utils.BordaCount([]int{1, 2, 3}, rankings)
+
+		assert.Equal(t, []utils.BordaScore{
+			{ItemID: 1, Score: 6, Rank: 1},
+			{ItemID: 2, Score: 5, Rank: 2},
+			{ItemID: 3, Score: 4, Rank: 3},
+		}, scores)
+	})
+
+	t.Run("Tied Scores Share Rank", func(t *testing.T) {
+		rankings := [][]int{
+			{1, 2, 3},
+			{2, 1, 3},
+		}
+
+		scores := utils.BordaCount([]int{1, 2, 3}, rankings)
+
+		assert.Equal(t, []utils.BordaScore{
+			{ItemID: 1, Score: 3, Rank: 1},
+			{ItemID: 2, Score: 3, Rank: 1},
+			{ItemID: 3, Score: 0, Rank: 3},
+		}, scores)
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		scores := utils.BordaCount([]int{1, 2}, nil)
+
+		assert.Equal(t, []utils.BordaScore{
+			{ItemID: 1, Score: 0, Rank: 1},
+			{ItemID: 2, Score: 0, Rank: 1},
+		}, scores)
+	})
+}
+
+func TestScoreVoting(t *testing.T) {
+	t.Run("Averages And Distribution", func(t *testing.T) {
+		scores := []utils.ItemScore{
+			{ItemID: 1, Score: 10}, {ItemID: 2, Score: 3},
+			{ItemID: 1, Score: 7}, {ItemID: 2, Score: 10},
+			{ItemID: 1, Score: 7}, {ItemID: 2, Score: 0},
+		}
+
+		tallies := utils.ScoreVoting([]int{1, 2}, scores)
+
+		require.Len(t, tallies, 2)
+		assert.Equal(t, 1, tallies[0].ItemID)
+		assert.Equal(t, 8.0, tallies[0].AverageScore)
+		assert.Equal(t, [11]int{7: 2, 10: 1}, tallies[0].Distribution)
+		assert.Equal(t, 1, tallies[0].Rank)
+
+		assert.Equal(t, 2, tallies[1].ItemID)
+		assert.Equal(t, 4.33, tallies[1].AverageScore)
+		assert.Equal(t, [11]int{0: 1, 3: 1, 10: 1}, tallies[1].Distribution)
+		assert.Equal(t, 2, tallies[1].Rank)
+	})
+
+	t.Run("Tied Averages Share Rank", func(t *testing.T) {
+		scores := []utils.ItemScore{
+			{ItemID: 1, Score: 5}, {ItemID: 2, Score: 6}, {ItemID: 3, Score: 5},
+			{ItemID: 1, Score: 5}, {ItemID: 2, Score: 4}, {ItemID: 3, Score: 1},
+		}
+
+		tallies := utils.ScoreVoting([]int{1, 2, 3}, scores)
+
+		assert.Equal(t, []int{1, 2, 3}, []int{tallies[0].ItemID, tallies[1].ItemID, tallies[2].ItemID})
+		assert.Equal(t, []int{1, 1, 3}, []int{tallies[0].Rank, tallies[1].Rank, tallies[2].Rank})
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		tallies := utils.ScoreVoting([]int{1, 2}, nil)
+
+		assert.Equal(t, []utils.ScoreTally{
+			{ItemID: 1, Rank: 1},
+			{ItemID: 2, Rank: 1},
+		}, tallies)
+	})
+}
+
+func TestInstantRunoff(t *testing.T) {
+	t.Run("Eliminated Votes Decide Winner", func(t *testing.T) {
+		rankings := [][]int{
+			{1, 3}, {1, 3}, {1, 3}, {1, 3},
+			{2, 3}, {2, 3}, {2},
+			{3, 2}, {3, 2},
+		}
+
+		result := utils.InstantRunoff([]int{1, 2, 3}, rankings)
+
+		assert.Equal(t, 2, result.WinnerID)
+		assert.Equal(t, []utils.IRVRound{
+			{Round: 1, EliminatedItemID: 3, RedistributedVotes: 2},
+		}, result.Rounds)
+	})
+
+	t.Run("Exhausted Ballots And Tied Elimination", func(t *testing.T) {
+		rankings := [][]int{
+			{1}, {1},
+			{2}, {2},
+			{3},
+		}
+
+		result := utils.InstantRunoff([]int{1, 2, 3}, rankings)
+
+		assert.Equal(t, 1, result.WinnerID)
+		assert.Equal(t, []utils.IRVRound{
+			{Round: 1, EliminatedItemID: 3, RedistributedVotes: 0},
+			{Round: 2, EliminatedItemID: 2, RedistributedVotes: 0},
+		}, result.Rounds)
+	})
+
+	t.Run("First Round Majority", func(t *testing.T) {
+		rankings := [][]int{{1, 2}, {1, 2}, {2, 1}}
+
+		result := utils.InstantRunoff([]int{1, 2}, rankings)
+
+		assert.Equal(t, 1, result.WinnerID)
+		assert.Empty(t, result.Rounds)
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		result := utils.InstantRunoff([]int{1, 2}, nil)
+
+		assert.Equal(t, 0, result.WinnerID)
+		assert.Empty(t, result.Rounds)
+	})
+}
+
+func TestClassifySentiment(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected utils.Sentiment
+	}{
+		{"Great parks and safe streets", utils.SentimentPositive},
+		{"Corrupt and wasteful, the worst option", utils.SentimentNegative},
+		{"Jane Smith", utils.SentimentNeutral},
+		{"Not good", utils.SentimentNegative},
+		{"Never corrupt", utils.SentimentPositive},
+		{"Good but expensive", utils.SentimentNeutral},
+		{"", utils.SentimentNeutral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.ClassifySentiment(tt.text))
+		})
+	}
+}
+
+func TestAnalyzeSentiment(t *testing.T) {
+	t.Run("Counts And Common Words", func(t *testing.T) {
+		summary := utils.AnalyzeSentiment([]string{
+			"Better parks",
+			"Parks are terrible",
+			"Mayor Jones",
+			"More parks for the kids",
+		})
+
+		assert.Equal(t, 1, summary.PositiveCount)
+		assert.Equal(t, 1, summary.NegativeCount)
+		assert.Equal(t, 2, summary.NeutralCount)
+		assert.Equal(t, "parks", summary.MostCommonWords[0])
+		assert.NotContains(t, summary.MostCommonWords, "the")
+		assert.NotContains(t, summary.MostCommonWords, "are")
+	})
+
+	t.Run("Caps Common Words", func(t *testing.T) {
+		summary := utils.AnalyzeSentiment([]string{"one two three four five six seven eight nine ten eleven twelve"})
+
+		assert.Len(t, summary.MostCommonWords, 10)
+	})
+
+	t.Run("No Write-Ins", func(t *testing.T) {
+		summary := utils.AnalyzeSentiment(nil)
+
+		assert.Equal(t, utils.SentimentSummary{MostCommonWords: []string{}}, summary)
+	})
+}
+
+func TestConfidenceLevel(t *testing.T) {
+	tests := []struct {
+		totalVotes int
+		expected   string
+	}{
+		{0, utils.ConfidenceNone},
+		{1, utils.ConfidenceLow},
+		{29, utils.ConfidenceLow},
+		{30, utils.ConfidenceMedium},
+		{100, utils.ConfidenceMedium},
+		{101, utils.ConfidenceHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d Votes", tt.totalVotes), func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.ConfidenceLevel(tt.totalVotes))
+		})
+	}
+}
+
+func TestMarginOfError95(t *testing.T) {
+	t.Run("Worst Case Margin", func(t *testing.T) {
+		margin := utils.MarginOfError95(100)
+		require.NotNil(t, margin)
+		assert.Equal(t, 9.8, *margin)
+
+		margin = utils.MarginOfError95(30)
+		require.NotNil(t, margin)
+		assert.Equal(t, 17.89, *margin)
+
+		margin = utils.MarginOfError95(1)
+		require.NotNil(t, margin)
+		assert.Equal(t, 98.0, *margin)
+	})
+
+	t.Run("No Votes", func(t *testing.T) {
+		assert.Nil(t, utils.MarginOfError95(0))
+	})
+}
+
+func TestBinaryOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		titles    []string
+		approval  int
+		rejection int
+		ok        bool
+	}{
+		{"Yes And No", []string{"Yes", "No"}, 0, 1, true},
+		{"Case Insensitive", []string{"NO, keep current hours", "yes - extend hours"}, 1, 0, true},
+		{"Keyword Phrases", []string{"Oppose the levy", "Support the levy"}, 1, 0, true},
+		{"For And Against", []string{"For", "Against"}, 0, 1, true},
+		{"Keyword Must End Word", []string{"Nothing", "Forest"}, 0, 0, false},
+		{"Both Approving", []string{"Yes", "Approve"}, 0, 0, false},
+		{"Candidates", []string{"Jane Smith", "John Doe"}, 0, 0, false},
+		{"Three Items", []string{"Yes", "No", "Abstain"}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approval, rejection, ok := utils.BinaryOptions(tt.titles)
+
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.approval, approval)
+				assert.Equal(t, tt.rejection, rejection)
+			}
+		})
+	}
+}
+
+func TestDecayWeight(t *testing.T) {
+	t.Run("Same Day Vote Counts Fully", func(t *testing.T) {
+		assert.Equal(t, 1.0, utils.DecayWeight(0.5, 0))
+	})
+
+	t.Run("Week Old Vote With Factor 0.5", func(t *testing.T) {
+		// exp(-3.5)
+		assert.InDelta(t, 0.0302, utils.DecayWeight(0.5, 7), 0.0001)
+	})
+
+	t.Run("Zero Factor Disables Decay", func(t *testing.T) {
+		assert.Equal(t, 1.0, utils.DecayWeight(0, 30))
+	})
+}
+
+func TestTimeDecayScores(t *testing.T) {
+	now := time.Date(2026, 6, 15, 12, 0, 0, 0, time.UTC)
+	votes := []utils.TimedVote{
+		{ItemID: 1, VotedAt: now},
+		{ItemID: 1, VotedAt: now.AddDate(0, 0, -7)},
+		{ItemID: 2, VotedAt: now.AddDate(0, 0, -1)},
+		{ItemID: 99, VotedAt: now},
+	}
+
+	scores := utils.TimeDecayScores([]int{1, 2, 3}, votes, 0.5, now)
+
+	assert.Equal(t, map[int]float64{1: 1.0302, 2: 0.6065, 3: 0}, scores)
+}
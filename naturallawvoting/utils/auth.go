@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"os"
 	"time"
@@ -30,9 +33,16 @@ func CheckPassword(password, hash string) bool {
 }
 
 func GenerateJWT(userID int, email string) (string, error) {
+	return GenerateJWTWithRole(userID, email, "user")
+}
+
+// GenerateJWTWithRole is GenerateJWT with the user's role recorded as a claim.
+// The claim is informational; admin routes still check the role in the database.
+func GenerateJWTWithRole(userID int, email, role string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    role,
 		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
 	}
 
@@ -40,6 +50,40 @@ func GenerateJWT(userID int, email string) (string, error) {
 	return token.SignedString(jwtSecret)
 }
 
+// RefreshTokenTTL is how long a refresh token can be exchanged for a new access token.
+const RefreshTokenTTL = 7 * 24 * time.Hour
+
+// GenerateRefreshToken returns a random refresh token and the hash to store for it.
+// Only the hash is persisted, so a leaked table cannot be replayed.
+func GenerateRefreshToken() (token, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(raw)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the stored form of a refresh token.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateImpersonationJWT issues a token for userID that records the admin acting
+// on their behalf. It expires after ttl and should be kept short.
+func GenerateImpersonationJWT(userID int, email string, adminID int, ttl time.Duration) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id":         userID,
+		"email":           email,
+		"impersonated_by": adminID,
+		"exp":             time.Now().Add(ttl).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecret)
+}
+
 func ValidateJWT(tokenString string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -57,4 +101,4 @@ func ValidateJWT(tokenString string) (jwt.MapClaims, error) {
 	}
 
 	return nil, errors.New("invalid token")
-}
\ No newline at end of file
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	approvalKeywords  = []string{"yes", "for", "approve", "support"}
+	rejectionKeywords = []string{"no", "against", "reject", "oppose"}
+)
+
+// IsApprovalOption reports whether an item title reads as a vote in favour,
+// such as "Yes" or "Approve the levy".
+func IsApprovalOption(title string) bool {
+	return hasKeywordPrefix(title, approvalKeywords)
+}
+
+// IsRejectionOption reports whether an item title reads as a vote against,
+// such as "No" or "Oppose the levy".
+func IsRejectionOption(title string) bool {
+	return hasKeywordPrefix(title, rejectionKeywords)
+}
+
+// BinaryOptions detects a yes/no ballot from its item titles. It succeeds only
+// when there are exactly two items, one approving and the other rejecting, and
+// returns their indexes in titles.
+func BinaryOptions(titles []string) (approval, rejection int, ok bool) {
+	if len(titles) != 2 {
+		return 0, 0, false
+	}
+	for i := range titles {
+		other := 1 - i
+		if IsApprovalOption(titles[i]) && IsRejectionOption(titles[other]) && !IsRejectionOption(titles[i]) && !IsApprovalOption(titles[other]) {
+			return i, other, true
+		}
+	}
+	return 0, 0, false
+}
+
+// hasKeywordPrefix matches keywords case-insensitively at the start of title. A
+// keyword must end at a word boundary, so "No" matches but "November" does not.
+func hasKeywordPrefix(title string, keywords []string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, keyword := range keywords {
+		if !strings.HasPrefix(title, keyword) {
+			continue
+		}
+		rest := title[len(keyword):]
+		if rest == "" {
+			return true
+		}
+		if next := []rune(rest)[0]; !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+			return true
+		}
+	}
+	return false
+}
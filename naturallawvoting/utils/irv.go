@@ -0,0 +1,94 @@
+package utils
+
+// IRVRound records the item eliminated in one round of an instant-runoff count and
+// how many ballots moved on to another continuing item as a result.
+type IRVRound struct {
+	Round              int
+	EliminatedItemID   int
+	RedistributedVotes int
+}
+
+// IRVResult is the outcome of an instant-runoff count. WinnerID is 0 when no
+// ballots were cast.
+type IRVResult struct {
+	WinnerID int
+	Rounds   []IRVRound
+}
+
+// InstantRunoff counts ranked ballots by instant runoff. Each ranking lists item IDs
+// from first to last preference. Every round, each ballot counts toward its highest
+// ranked item still in the running; if one item holds a majority of those ballots it
+// wins, otherwise the item with the fewest votes is eliminated and its ballots move
+// to their next continuing preference. Ballots with no continuing preference are
+// exhausted. Ties for elimination go against the item listed later in itemIDs.
+func InstantRunoff(itemIDs []int, rankings [][]int) IRVResult {
+	continuing := make(map[int]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		continuing[id] = true
+	}
+
+	var result IRVResult
+	for round := 1; len(continuing) > 0; round++ {
+		tally := make(map[int]int, len(continuing))
+		choices := make([]int, len(rankings))
+		active := 0
+		for i, ranking := range rankings {
+			if choice, ok := topContinuingChoice(ranking, continuing); ok {
+				tally[choice]++
+				choices[i] = choice
+				active++
+			}
+		}
+		if active == 0 {
+			return result
+		}
+
+		leader := 0
+		for _, id := range itemIDs {
+			if continuing[id] && (leader == 0 || tally[id] > tally[leader]) {
+				leader = id
+			}
+		}
+		if tally[leader]*2 > active || len(continuing) == 1 {
+			result.WinnerID = leader
+			return result
+		}
+
+		eliminated := 0
+		for _, id := range itemIDs {
+			if continuing[id] && (eliminated == 0 || tally[id] <= tally[eliminated]) {
+				eliminated = id
+			}
+		}
+		delete(continuing, eliminated)
+
+		redistributed := 0
+		for i, ranking := range rankings {
+			if choices[i] != eliminated {
+				continue
+			}
+			if _, ok := topContinuingChoice(ranking, continuing); ok {
+				redistributed++
+			}
+		}
+
+		result.Rounds = append(result.Rounds, IRVRound{
+			Round:              round,
+			EliminatedItemID:   eliminated,
+			RedistributedVotes: redistributed,
+		})
+	}
+
+	return result
+}
+
+// topContinuingChoice returns the highest ranked item in ranking that is still in
+// the running.
+func topContinuingChoice(ranking []int, continuing map[int]bool) (int, bool) {
+	for _, id := range ranking {
+		if continuing[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,51 @@
+package utils
+
+// superstateStates lists the states that make up each superstate, using the same
+// slugs ballots are filed under.
+var superstateStates = map[string][]string{
+	"new-england":          {"connecticut", "maine", "massachusetts", "new-hampshire", "rhode-island", "vermont"},
+	"new-york":             {"long-island", "new-york-city", "upstate-new-york"},
+	"jersey-penn":          {"delaware", "maryland", "new-jersey", "pennsylvania", "washington-dc"},
+	"great-lakes":          {"indiana", "kentucky", "michigan", "ohio"},
+	"virginia-carolina":    {"north-carolina", "south-carolina", "virginia", "west-virginia"},
+	"florida-georgia":      {"florida", "georgia"},
+	"mississippi-valley":   {"alabama", "arkansas", "louisiana", "mississippi", "missouri", "tennessee"},
+	"north-central-plains": {"illinois", "iowa", "minnesota", "north-dakota", "south-dakota", "wisconsin"},
+	"texas": {
+		"central-east-texas", "north-east-dallas", "north-houston", "north-west-texas",
+		"south-central-texas", "south-coast-texas", "south-dallas", "south-east-dallas",
+		"south-east-texas", "south-west-houston", "south-west-texas", "west-texas",
+	},
+	"south-west": {"arizona", "colorado", "kansas", "nebraska", "new-mexico", "oklahoma"},
+	"pacific-nw": {"alaska", "hawaii", "idaho", "montana", "nevada", "oregon", "utah", "washington", "wyoming"},
+	"california": {
+		"central-california", "east-bay-area", "east-los-angeles", "north-california",
+		"north-coast-los-angeles", "north-east-los-angeles", "north-los-angeles", "san-diego-coast",
+		"south-coast-los-angeles", "south-east-bay-area", "south-east-california", "south-san-francisco",
+	},
+}
+
+// stateSuperstates maps each state slug back to its superstate.
+var stateSuperstates = func() map[string]string {
+	lookup := make(map[string]string)
+	for superstate, states := range superstateStates {
+		for _, state := range states {
+			lookup[state] = superstate
+		}
+	}
+	return lookup
+}()
+
+// SuperstateOfState returns the superstate a state belongs to, or false when the
+// state is not recognised.
+func SuperstateOfState(state string) (string, bool) {
+	superstate, ok := stateSuperstates[state]
+	return superstate, ok
+}
+
+// StatesInSuperstate returns the states that make up a superstate, or false when the
+// superstate is not recognised.
+func StatesInSuperstate(superstate string) ([]string, bool) {
+	states, ok := superstateStates[superstate]
+	return states, ok
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Sentiment is the polarity ClassifySentiment assigns to a piece of text.
+type Sentiment int
+
+const (
+	SentimentNeutral Sentiment = iota
+	SentimentPositive
+	SentimentNegative
+)
+
+// SentimentSummary aggregates the sentiment of a set of texts.
+type SentimentSummary struct {
+	PositiveCount   int
+	NegativeCount   int
+	NeutralCount    int
+	MostCommonWords []string
+}
+
+// maxCommonWords caps SentimentSummary.MostCommonWords.
+const maxCommonWords = 10
+
+var positiveWords = map[string]bool{
+	"accountable": true, "affordable": true, "agree": true, "approve": true, "benefit": true,
+	"best": true, "better": true, "clean": true, "excellent": true, "fair": true,
+	"free": true, "freedom": true, "good": true, "great": true, "happy": true,
+	"honest": true, "hope": true, "improve": true, "improved": true, "justice": true,
+	"like": true, "love": true, "peace": true, "progress": true, "protect": true,
+	"safe": true, "strong": true, "support": true, "thriving": true, "transparent": true,
+	"trust": true, "yes": true,
+}
+
+var negativeWords = map[string]bool{
+	"angry": true, "awful": true, "bad": true, "broken": true, "corrupt": true,
+	"corruption": true, "crime": true, "disagree": true, "dishonest": true, "expensive": true,
+	"fail": true, "failed": true, "failure": true, "fear": true, "hate": true,
+	"horrible": true, "oppose": true, "poor": true, "reject": true, "terrible": true,
+	"unfair": true, "unsafe": true, "waste": true, "worse": true, "worst": true,
+	"wrong": true,
+}
+
+// negators flip the polarity of the word that follows them ("not good").
+var negators = map[string]bool{
+	"no": true, "not": true, "never": true, "dont": true, "don't": true, "isn't": true, "isnt": true,
+}
+
+// stopWords are left out of MostCommonWords.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "our": true, "the": true, "to": true, "we": true, "with": true,
+}
+
+// tokenize lowercases text and splits it into words, keeping apostrophes so that
+// contractions such as "don't" survive.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+// ClassifySentiment scores text against a fixed lexicon: each positive word counts
+// +1 and each negative word -1, with the sign flipped when the word directly follows
+// a negator. The sign of the total decides the sentiment.
+func ClassifySentiment(text string) Sentiment {
+	score := 0
+	negate := false
+	for _, word := range tokenize(text) {
+		polarity := 0
+		if positiveWords[word] {
+			polarity = 1
+		} else if negativeWords[word] {
+			polarity = -1
+		}
+		if negate {
+			polarity = -polarity
+		}
+		score += polarity
+		negate = negators[word]
+	}
+
+	switch {
+	case score > 0:
+		return SentimentPositive
+	case score < 0:
+		return SentimentNegative
+	default:
+		return SentimentNeutral
+	}
+}
+
+// AnalyzeSentiment classifies each text and reports the counts along with the most
+// frequent words across all of them, most frequent first and alphabetical on ties.
+func AnalyzeSentiment(texts []string) SentimentSummary {
+	var summary SentimentSummary
+	frequency := make(map[string]int)
+	for _, text := range texts {
+		switch ClassifySentiment(text) {
+		case SentimentPositive:
+			summary.PositiveCount++
+		case SentimentNegative:
+			summary.NegativeCount++
+		default:
+			summary.NeutralCount++
+		}
+
+		for _, word := range tokenize(text) {
+			if !stopWords[word] {
+				frequency[word]++
+			}
+		}
+	}
+
+	words := make([]string, 0, len(frequency))
+	for word := range frequency {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if frequency[words[i]] != frequency[words[j]] {
+			return frequency[words[i]] > frequency[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > maxCommonWords {
+		words = words[:maxCommonWords]
+	}
+	summary.MostCommonWords = words
+
+	return summary
+}
@@ -0,0 +1,38 @@
+package utils
+
+import "math"
+
+// Confidence levels reported alongside ballot results, by sample size.
+const (
+	ConfidenceNone   = "none"
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// ConfidenceLevel rates how far results with totalVotes votes can be trusted:
+// low below 30 votes, medium from 30 to 100 and high above 100.
+func ConfidenceLevel(totalVotes int) string {
+	switch {
+	case totalVotes <= 0:
+		return ConfidenceNone
+	case totalVotes < 30:
+		return ConfidenceLow
+	case totalVotes <= 100:
+		return ConfidenceMedium
+	default:
+		return ConfidenceHigh
+	}
+}
+
+// MarginOfError95 returns the worst-case margin of error, in percentage points
+// rounded to 2 decimal places, for a sample of totalVotes at 95% confidence. It
+// assumes a 50/50 split, which maximises p(1-p). It returns nil when there are
+// no votes.
+func MarginOfError95(totalVotes int) *float64 {
+	if totalVotes <= 0 {
+		return nil
+	}
+	margin := math.Round(1.96*math.Sqrt(0.25/float64(totalVotes))*100*100) / 100
+	return &margin
+}
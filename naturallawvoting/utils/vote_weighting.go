@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"math"
+	"time"
+)
+
+// TimedVote is a single vote for an item and when it was cast.
+type TimedVote struct {
+	ItemID  int
+	VotedAt time.Time
+}
+
+// DecayWeight is the contribution of a vote cast daysSinceVote days ago when
+// recent votes count more: exp(-factor * days). A factor of 0 weights every vote
+// equally.
+func DecayWeight(factor, daysSinceVote float64) float64 {
+	return math.Exp(-factor * daysSinceVote)
+}
+
+// TimeDecayScores sums the decayed weight of each item's votes as of now. Every
+// item in itemIDs gets a score, rounded to four decimal places.
+func TimeDecayScores(itemIDs []int, votes []TimedVote, factor float64, now time.Time) map[int]float64 {
+	scores := make(map[int]float64, len(itemIDs))
+	for _, id := range itemIDs {
+		scores[id] = 0
+	}
+
+	for _, vote := range votes {
+		if _, ok := scores[vote.ItemID]; !ok {
+			continue
+		}
+		days := now.Sub(vote.VotedAt).Hours() / 24
+		if days < 0 {
+			days = 0
+		}
+		scores[vote.ItemID] += DecayWeight(factor, days)
+	}
+
+	for id, score := range scores {
+		scores[id] = math.Round(score*10000) / 10000
+	}
+	return scores
+}
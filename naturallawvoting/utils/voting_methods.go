@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// BordaScore is an item's Borda count and its position in the final standings.
+type BordaScore struct {
+	ItemID int
+	Score  int
+	Rank   int
+}
+
+// BordaCount scores ranked ballots. Each ranking lists item IDs from first to last
+// preference; with N items a first preference earns N-1 points, a second N-2 and so
+// on. Unranked items earn nothing. Scores are returned highest first and tied items
+// share a rank (1, 1, 3).
+func BordaCount(itemIDs []int, rankings [][]int) []BordaScore {
+	n := len(itemIDs)
+	scores := make(map[int]int, n)
+	for _, id := range itemIDs {
+		scores[id] = 0
+	}
+
+	for _, ranking := range rankings {
+		for position, itemID := range ranking {
+			if _, ok := scores[itemID]; ok && position < n {
+				scores[itemID] += n - 1 - position
+			}
+		}
+	}
+
+	results := make([]BordaScore, 0, n)
+	for _, id := range itemIDs {
+		results = append(results, BordaScore{ItemID: id, Score: scores[id]})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ItemID < results[j].ItemID
+	})
+
+	for i := range results {
+		if i > 0 && results[i].Score == results[i-1].Score {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+
+	return results
+}
+
+// MaxScore is the highest score a voter can give an item on a score ballot.
+const MaxScore = 10
+
+// ItemScore is one voter's score for one item.
+type ItemScore struct {
+	ItemID int
+	Score  int
+}
+
+// ScoreTally is an item's average score, how many voters gave each score from 0 to
+// MaxScore, and its position in the final standings.
+type ScoreTally struct {
+	ItemID       int
+	AverageScore float64
+	Distribution [MaxScore + 1]int
+	Rank         int
+}
+
+// ScoreVoting tallies score ballots. Averages are rounded to two decimal places and
+// items nobody scored average 0. Tallies are returned highest average first and tied
+// items share a rank (1, 1, 3).
+func ScoreVoting(itemIDs []int, scores []ItemScore) []ScoreTally {
+	tallies := make(map[int]*ScoreTally, len(itemIDs))
+	totals := make(map[int]int, len(itemIDs))
+	for _, id := range itemIDs {
+		tallies[id] = &ScoreTally{ItemID: id}
+	}
+
+	for _, score := range scores {
+		tally, ok := tallies[score.ItemID]
+		if !ok || score.Score < 0 || score.Score > MaxScore {
+			continue
+		}
+		tally.Distribution[score.Score]++
+		totals[score.ItemID] += score.Score
+	}
+
+	results := make([]ScoreTally, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		tally := tallies[id]
+		voters := 0
+		for _, count := range tally.Distribution {
+			voters += count
+		}
+		if voters > 0 {
+			tally.AverageScore = math.Round(float64(totals[id])/float64(voters)*100) / 100
+		}
+		results = append(results, *tally)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].AverageScore != results[j].AverageScore {
+			return results[i].AverageScore > results[j].AverageScore
+		}
+		return results[i].ItemID < results[j].ItemID
+	})
+
+	for i := range results {
+		if i > 0 && results[i].AverageScore == results[i-1].AverageScore {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+
+	return results
+}